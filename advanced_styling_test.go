@@ -140,3 +140,16 @@ func TestAdvancedRelationshipStyling(t *testing.T) {
 	relationshipStyle.WithPosition(position)
 	assert.Equal(t, &position, relationshipStyle.Position())
 }
+
+func TestStylesNode_AddRelationshipStyle(t *testing.T) {
+	styles := Workspace().Views().Configuration().Styles()
+
+	style := styles.AddRelationshipStyle(tags.Asynchronous).WithDash(true).WithThickness(3).WithWidth(200)
+	assert.Equal(t, []*RelationShipStyleNode{style}, styles.RelationshipStyles())
+	assert.True(t, style.Dash())
+	assert.Equal(t, 3, *style.Thickness())
+	assert.Equal(t, 200, *style.Width())
+
+	assert.False(t, style.WithDash(false).Dash())
+	assert.Equal(t, SolidLine, *style.LineStyle())
+}
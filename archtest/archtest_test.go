@@ -0,0 +1,111 @@
+package archtest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/platelk/gostructurizr"
+)
+
+type recorder struct {
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func shopModel() (*gostructurizr.ModelNode, map[string]gostructurizr.Namer) {
+	m := gostructurizr.Model()
+	customer := m.AddPerson("Customer", "").WithLocation(gostructurizr.ExternalLocation)
+	staff := m.AddPerson("Staff", "").WithLocation(gostructurizr.InternalLocation)
+	shop := m.AddSoftwareSystem("Shop", "")
+	gateway := shop.AddContainer("API Gateway", "", "Envoy")
+	web := shop.AddContainer("Web", "", "Go")
+	db := shop.AddContainer("Database", "", "")
+	db.WithTag("Database")
+	orders := web.AddComponent("Orders")
+	customer.Uses(gateway, "Calls")
+	customer.Uses(web, "Browses")
+	staff.Uses(web, "Manages")
+	gateway.Uses(web, "Forwards to")
+	orders.Uses(db, "Reads from")
+	web.Uses(db, "Writes to")
+	return m, map[string]gostructurizr.Namer{"shop": shop, "web": web, "gateway": gateway}
+}
+
+func TestRule_ShouldNotUse(t *testing.T) {
+	m, elements := shopModel()
+	rule := That(Components().In(elements["web"])).ShouldNotUse(Containers().Named("Database"))
+
+	r := &recorder{}
+	assert.False(t, rule.Assert(r, m))
+	assert.Equal(t, []string{
+		`components in "Web" should not use containers named "Database": "Shop/Web/Orders" uses "Shop/Database"`,
+	}, r.errors)
+
+	allowed := That(Components().In(elements["web"])).ShouldNotUse(Containers().Tagged("Cache"))
+	assert.True(t, allowed.Assert(t, m))
+}
+
+func TestRule_Should(t *testing.T) {
+	m, elements := shopModel()
+	violations := That(Containers().In(elements["shop"])).Should(HaveTechnology()).Check(m)
+	assert.Len(t, violations, 1)
+	assert.Equal(t, "Database", violations[0].Element.Name())
+	assert.Nil(t, violations[0].RelationShip)
+	assert.Equal(t, `"Shop/Database" doesn't have a technology`, violations[0].Message)
+
+	assert.Empty(t, That(Containers().Tagged("Database")).Should(HaveTag("Database")).Check(m))
+}
+
+func TestRule_allowLists(t *testing.T) {
+	m, elements := shopModel()
+	violations := That(Containers().Named("Web")).ShouldOnlyBeUsedBy(Elements().Matching("internal", func(n gostructurizr.Namer) bool {
+		p, ok := n.(*gostructurizr.PersonNode)
+		return !ok || p.Location() == gostructurizr.InternalLocation
+	})).Check(m)
+	assert.Len(t, violations, 1)
+	assert.Equal(t, `"Customer" uses "Shop/Web"`, violations[0].Message)
+
+	violations = That(People().Located(gostructurizr.ExternalLocation)).ShouldOnlyUse(Element(elements["gateway"])).Check(m)
+	assert.Len(t, violations, 1)
+	assert.Equal(t, "Browses", *violations[0].RelationShip.Description())
+}
+
+func TestRule_allowListsImpliedRelationships(t *testing.T) {
+	m, elements := shopModel()
+	m.WithImpliedRelationships(gostructurizr.CreateImpliedRelationshipsUnlessAnyExist)
+	m.AddPerson("Auditor", "").Uses(elements["gateway"], "Calls")
+
+	// the auditor using the gateway implies it uses the shop, which isn't a container
+	assert.Empty(t, That(People().Named("Auditor")).ShouldOnlyUse(Containers()).Check(m))
+	assert.Empty(t, That(SoftwareSystems()).ShouldOnlyBeUsedBy(Elements().Named("Customer")).Check(m))
+	// deny-lists still see the uses implied between the parents
+	assert.Len(t, That(People().Named("Auditor")).ShouldNotUse(SoftwareSystems()).Check(m), 1)
+}
+
+func TestSelector_derived(t *testing.T) {
+	m, elements := shopModel()
+	base := Containers()
+	web := base.Named("Web")
+	database := base.Named("Database")
+
+	assert.Equal(t, []gostructurizr.Namer{elements["web"]}, web.Select(m))
+	assert.Len(t, database.Select(m), 1)
+	assert.Len(t, base.Select(m), 3)
+	assert.Equal(t, `containers named "Database"`, database.String())
+	assert.Equal(t, "containers", base.String())
+}
+
+func TestSelector_WithProperty(t *testing.T) {
+	m := gostructurizr.Model()
+	server := m.AddProdNode("Server", "", "Linux")
+	server.Properties().Add("zone", "eu")
+	m.AddProdNode("Backup", "", "")
+
+	assert.Equal(t, []gostructurizr.Namer{server}, DeploymentNodes().WithProperty("zone", "eu").Select(m))
+	assert.Len(t, That(DeploymentNodes()).Should(HaveProperty("zone")).Check(m), 1)
+}
@@ -0,0 +1,199 @@
+package archtest
+
+import (
+	"fmt"
+
+	"github.com/platelk/gostructurizr"
+)
+
+// Violation is an element or a relationship breaking a rule
+type Violation struct {
+	Rule string
+	// Element is the offending element, the source of the relationship for relationship rules
+	Element gostructurizr.Namer
+	// RelationShip is the offending relationship, nil for the rules on elements
+	RelationShip *gostructurizr.RelationShipNode
+	Message      string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s", v.Rule, v.Message)
+}
+
+// TestingT is the part of *testing.T rules report their violations to
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+}
+
+// Rule is an architecture rule, checked against a model
+type Rule struct {
+	description string
+	check       func(m *gostructurizr.ModelNode) []Violation
+}
+
+func (r *Rule) String() string {
+	return r.description
+}
+
+// Check returns the violations of the rule in the model, in model order
+func (r *Rule) Check(m *gostructurizr.ModelNode) []Violation {
+	return r.check(m)
+}
+
+// Assert reports every violation of the rule as a test error, and returns whether the model follows the rule
+func (r *Rule) Assert(t TestingT, m *gostructurizr.ModelNode) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	violations := r.Check(m)
+	for _, v := range violations {
+		t.Errorf("%s", v)
+	}
+	return len(violations) == 0
+}
+
+// Condition is what the selected elements of a rule are expected to satisfy
+type Condition struct {
+	description string
+	check       func(gostructurizr.Namer) bool
+}
+
+// Satisfy builds a condition from a function, description completing "should ..." in the violations
+func Satisfy(description string, check func(gostructurizr.Namer) bool) Condition {
+	return Condition{description: description, check: check}
+}
+
+// HaveTechnology is satisfied by the containers and components with a technology
+func HaveTechnology() Condition {
+	return Satisfy("have a technology", func(n gostructurizr.Namer) bool {
+		switch e := n.(type) {
+		case *gostructurizr.ContainerNode:
+			return e.Technology() != nil && *e.Technology() != ""
+		case *gostructurizr.ComponentNode:
+			return e.Technology() != nil && *e.Technology() != ""
+		case *gostructurizr.DeploymentNodeNode:
+			return e.Technology() != ""
+		case *gostructurizr.InfrastructureNodeNode:
+			return e.Technology() != ""
+		}
+		return false
+	})
+}
+
+// HaveDescription is satisfied by the elements with a description
+func HaveDescription() Condition {
+	return Satisfy("have a description", func(n gostructurizr.Namer) bool {
+		switch d := n.(type) {
+		case interface{ Description() *string }:
+			return d.Description() != nil && *d.Description() != ""
+		case interface{ Description() string }:
+			return d.Description() != ""
+		}
+		return false
+	})
+}
+
+// HaveTag is satisfied by the elements carrying tag
+func HaveTag(tag string) Condition {
+	return Satisfy(fmt.Sprintf("be tagged %q", tag), func(n gostructurizr.Namer) bool {
+		return hasTag(n, tag)
+	})
+}
+
+// HaveProperty is satisfied by the elements having the property key, whatever its value
+func HaveProperty(key string) Condition {
+	return Satisfy(fmt.Sprintf("have property %s", key), func(n gostructurizr.Namer) bool {
+		properties := propertiesOf(n)
+		if properties == nil || properties.Properties == nil {
+			return false
+		}
+		_, ok := properties.Properties[key]
+		return ok
+	})
+}
+
+// RuleBuilder starts a rule on the selected elements
+type RuleBuilder struct {
+	selector *Selector
+}
+
+// That starts a rule on the elements selected by s
+func That(s *Selector) *RuleBuilder {
+	return &RuleBuilder{selector: s}
+}
+
+// Should checks every selected element satisfies c
+func (b *RuleBuilder) Should(c Condition) *Rule {
+	description := fmt.Sprintf("%s should %s", b.selector, c.description)
+	return &Rule{
+		description: description,
+		check: func(m *gostructurizr.ModelNode) []Violation {
+			var violations []Violation
+			for _, e := range b.selector.Select(m) {
+				if !c.check(e) {
+					violations = append(violations, Violation{
+						Rule:    description,
+						Element: e,
+						Message: fmt.Sprintf("%q doesn't %s", path(e), c.description),
+					})
+				}
+			}
+			return violations
+		},
+	}
+}
+
+// ShouldNotUse denies the relationships from the selected elements to the ones selected by to
+func (b *RuleBuilder) ShouldNotUse(to *Selector) *Rule {
+	description := fmt.Sprintf("%s should not use %s", b.selector, to)
+	return b.relationshipRule(description, func(r *gostructurizr.RelationShipNode) bool {
+		return b.selector.Matches(r.From()) && to.Matches(r.To())
+	})
+}
+
+// ShouldOnlyUse allows the relationships from the selected elements to the ones selected by to, and denies the others.
+// Implied relationships are left out, they link the parents of the allowed elements which would have to be allowed too.
+func (b *RuleBuilder) ShouldOnlyUse(to *Selector) *Rule {
+	description := fmt.Sprintf("%s should only use %s", b.selector, to)
+	return b.relationshipRule(description, func(r *gostructurizr.RelationShipNode) bool {
+		return !r.IsImplied() && b.selector.Matches(r.From()) && !to.Matches(r.To())
+	})
+}
+
+// ShouldNotBeUsedBy denies the relationships to the selected elements from the ones selected by from
+func (b *RuleBuilder) ShouldNotBeUsedBy(from *Selector) *Rule {
+	description := fmt.Sprintf("%s should not be used by %s", b.selector, from)
+	return b.relationshipRule(description, func(r *gostructurizr.RelationShipNode) bool {
+		return b.selector.Matches(r.To()) && from.Matches(r.From())
+	})
+}
+
+// ShouldOnlyBeUsedBy allows the relationships to the selected elements from the ones selected by from, and denies the others.
+// As for ShouldOnlyUse, implied relationships are left out.
+func (b *RuleBuilder) ShouldOnlyBeUsedBy(from *Selector) *Rule {
+	description := fmt.Sprintf("%s should only be used by %s", b.selector, from)
+	return b.relationshipRule(description, func(r *gostructurizr.RelationShipNode) bool {
+		return !r.IsImplied() && b.selector.Matches(r.To()) && !from.Matches(r.From())
+	})
+}
+
+// relationshipRule reports the relationships of the model for which violates returns true
+func (b *RuleBuilder) relationshipRule(description string, violates func(r *gostructurizr.RelationShipNode) bool) *Rule {
+	return &Rule{
+		description: description,
+		check: func(m *gostructurizr.ModelNode) []Violation {
+			var violations []Violation
+			for _, r := range m.RelationShip() {
+				if violates(r) {
+					violations = append(violations, Violation{
+						Rule:         description,
+						Element:      r.From(),
+						RelationShip: r,
+						Message:      fmt.Sprintf("%q uses %q", path(r.From()), path(r.To())),
+					})
+				}
+			}
+			return violations
+		},
+	}
+}
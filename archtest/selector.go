@@ -0,0 +1,248 @@
+// Package archtest checks architecture rules against a model, the way ArchUnit does for Java code:
+//
+//	rule := archtest.That(archtest.Components().In(web)).ShouldNotUse(archtest.Containers().Named("Database"))
+//	rule.Assert(t, workspace.Model())
+//
+// Rules select elements of the model, then either check the elements themselves (Should) or the
+// relationships they are the source or the destination of, through deny-lists (ShouldNotUse) and
+// allow-lists (ShouldOnlyUse, ShouldOnlyBeUsedBy). Deny-lists check the implied relationships as well, so a use
+// modelled between components is denied between their containers too, while allow-lists only check the
+// relationships which were added explicitly.
+package archtest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/platelk/gostructurizr"
+)
+
+// Selector selects elements of a model. Filters are combined, an element having to match all of them.
+// Adding a filter returns a new selector, so selectors can be derived from a common base.
+type Selector struct {
+	filters      []func(gostructurizr.Namer) bool
+	descriptions []string
+}
+
+func selector(description string, filter func(gostructurizr.Namer) bool) *Selector {
+	return (&Selector{}).where(description, filter)
+}
+
+func (s *Selector) where(description string, filter func(gostructurizr.Namer) bool) *Selector {
+	return &Selector{
+		filters:      append(append([]func(gostructurizr.Namer) bool(nil), s.filters...), filter),
+		descriptions: append(append([]string(nil), s.descriptions...), description),
+	}
+}
+
+// Elements selects every element of the model
+func Elements() *Selector {
+	return selector("elements", func(gostructurizr.Namer) bool { return true })
+}
+
+// People selects the people of the model
+func People() *Selector {
+	return selector("people", func(n gostructurizr.Namer) bool {
+		_, ok := n.(*gostructurizr.PersonNode)
+		return ok
+	})
+}
+
+// SoftwareSystems selects the software systems of the model
+func SoftwareSystems() *Selector {
+	return selector("software systems", func(n gostructurizr.Namer) bool {
+		_, ok := n.(*gostructurizr.SoftwareSystemNode)
+		return ok
+	})
+}
+
+// Containers selects the containers of the model
+func Containers() *Selector {
+	return selector("containers", func(n gostructurizr.Namer) bool {
+		_, ok := n.(*gostructurizr.ContainerNode)
+		return ok
+	})
+}
+
+// Components selects the components of the model
+func Components() *Selector {
+	return selector("components", func(n gostructurizr.Namer) bool {
+		_, ok := n.(*gostructurizr.ComponentNode)
+		return ok
+	})
+}
+
+// DeploymentNodes selects the deployment nodes of the model, whatever their environment
+func DeploymentNodes() *Selector {
+	return selector("deployment nodes", func(n gostructurizr.Namer) bool {
+		_, ok := n.(*gostructurizr.DeploymentNodeNode)
+		return ok
+	})
+}
+
+// Element selects a single element
+func Element(e gostructurizr.Namer) *Selector {
+	return selector(fmt.Sprintf("%q", e.Name()), func(n gostructurizr.Namer) bool { return n == e })
+}
+
+// Named keeps the elements with the given name
+func (s *Selector) Named(name string) *Selector {
+	return s.where(fmt.Sprintf("named %q", name), func(n gostructurizr.Namer) bool { return n.Name() == name })
+}
+
+// Tagged keeps the elements carrying the given tag
+func (s *Selector) Tagged(tag string) *Selector {
+	return s.where(fmt.Sprintf("tagged %q", tag), func(n gostructurizr.Namer) bool { return hasTag(n, tag) })
+}
+
+// In keeps the elements nested in parent, at any depth: the components of a software system are in it
+func (s *Selector) In(parent gostructurizr.Namer) *Selector {
+	return s.where(fmt.Sprintf("in %q", parent.Name()), func(n gostructurizr.Namer) bool {
+		for p := parentOf(n); p != nil; p = parentOf(p) {
+			if p == parent {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// WithProperty keeps the elements having the property key set to value
+func (s *Selector) WithProperty(key, value string) *Selector {
+	return s.where(fmt.Sprintf("with property %s=%q", key, value), func(n gostructurizr.Namer) bool {
+		properties := propertiesOf(n)
+		if properties == nil || properties.Properties == nil {
+			return false
+		}
+		v, ok := properties.Properties[key]
+		return ok && v == value
+	})
+}
+
+// Located keeps the people and software systems with the given location
+func (s *Selector) Located(location gostructurizr.Location) *Selector {
+	return s.where(fmt.Sprintf("located %s", location), func(n gostructurizr.Namer) bool {
+		switch e := n.(type) {
+		case *gostructurizr.PersonNode:
+			return e.Location() == location
+		case *gostructurizr.SoftwareSystemNode:
+			return e.Location() == location
+		}
+		return false
+	})
+}
+
+// Matching keeps the elements accepted by filter, described by description in the violations
+func (s *Selector) Matching(description string, filter func(gostructurizr.Namer) bool) *Selector {
+	return s.where(description, filter)
+}
+
+// Matches reports whether n is selected
+func (s *Selector) Matches(n gostructurizr.Namer) bool {
+	for _, filter := range s.filters {
+		if !filter(n) {
+			return false
+		}
+	}
+	return true
+}
+
+// Select returns the elements of the model which are selected, in model order
+func (s *Selector) Select(m *gostructurizr.ModelNode) []gostructurizr.Namer {
+	var selected []gostructurizr.Namer
+	for _, e := range elements(m) {
+		if s.Matches(e) {
+			selected = append(selected, e)
+		}
+	}
+	return selected
+}
+
+func (s *Selector) String() string {
+	return strings.Join(s.descriptions, " ")
+}
+
+// elements returns every element of the model, parents first
+func elements(m *gostructurizr.ModelNode) []gostructurizr.Namer {
+	var all []gostructurizr.Namer
+	for _, p := range m.Persons() {
+		all = append(all, p)
+	}
+	for _, s := range m.SoftwareSystems() {
+		all = append(all, s)
+		for _, c := range s.Containers() {
+			all = append(all, c)
+			for _, component := range c.Components() {
+				all = append(all, component)
+			}
+		}
+	}
+	var addNode func(d *gostructurizr.DeploymentNodeNode)
+	addNode = func(d *gostructurizr.DeploymentNodeNode) {
+		all = append(all, d)
+		for _, child := range d.Children() {
+			addNode(child)
+		}
+		for _, infra := range d.InfrastructureNodes() {
+			all = append(all, infra)
+		}
+		for _, instance := range d.ContainerInstances() {
+			all = append(all, instance)
+		}
+	}
+	for _, d := range m.DeploymentNodes() {
+		addNode(d)
+	}
+	return all
+}
+
+func parentOf(n gostructurizr.Namer) gostructurizr.Namer {
+	switch e := n.(type) {
+	case *gostructurizr.ContainerNode:
+		if e.Parent() != nil {
+			return e.Parent()
+		}
+	case *gostructurizr.ComponentNode:
+		if e.Parent() != nil {
+			return e.Parent()
+		}
+	}
+	return nil
+}
+
+func tagsOf(n gostructurizr.Namer) *gostructurizr.TagsNode {
+	if t, ok := n.(interface{ Tags() *gostructurizr.TagsNode }); ok {
+		return t.Tags()
+	}
+	return nil
+}
+
+func hasTag(n gostructurizr.Namer, tag string) bool {
+	t := tagsOf(n)
+	if t == nil {
+		return false
+	}
+	for _, value := range t.List() {
+		for _, each := range strings.Split(value, ",") {
+			if strings.TrimSpace(each) == tag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func propertiesOf(n gostructurizr.Namer) *gostructurizr.Properties {
+	if p, ok := n.(interface{ Properties() *gostructurizr.Properties }); ok {
+		return p.Properties()
+	}
+	return nil
+}
+
+// path names an element through its parents, Shop/API/Orders
+func path(n gostructurizr.Namer) string {
+	if p := parentOf(n); p != nil {
+		return path(p) + "/" + n.Name()
+	}
+	return n.Name()
+}
@@ -15,6 +15,11 @@ func Component(name string) *ComponentNode {
 	}
 }
 
+// Parent returns the parent container of this component
+func (c *ComponentNode) Parent() *ContainerNode {
+	return c.node
+}
+
 func (c *ComponentNode) Name() string {
 	return c.name
 }
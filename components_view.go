@@ -6,6 +6,12 @@ type ComponentsViewNode struct {
 	addAllElement    bool
 	addAllPeople     bool
 	autoLayout       bool
+	includes         []*ExpressionViewNode
+	excludes         []*ExpressionViewNode
+	elements         []Namer
+	layout           *LayoutNode
+	withoutImplied   bool
+	excludedRels     []*RelationShipNode
 }
 
 func componentsView(container *ContainerNode) *ComponentsViewNode {
@@ -47,6 +53,29 @@ func (s *ComponentsViewNode) WithAutoLayout() *ComponentsViewNode {
 	return s
 }
 
+// WithoutImpliedRelationships hides the implied relationships from the view, which only displays
+// the relationships added explicitly. The DSL renders it as an exclude statement per implied relationship.
+func (s *ComponentsViewNode) WithoutImpliedRelationships() *ComponentsViewNode {
+	s.withoutImplied = true
+	return s
+}
+
+// IsWithoutImpliedRelationships reports whether the implied relationships are hidden from the view
+func (s *ComponentsViewNode) IsWithoutImpliedRelationships() bool {
+	return s.withoutImplied
+}
+
+// ExcludeRelationShip hides a relationship from the view, its elements staying displayed
+func (s *ComponentsViewNode) ExcludeRelationShip(r *RelationShipNode) *ComponentsViewNode {
+	s.excludedRels = append(s.excludedRels, r)
+	return s
+}
+
+// ExcludedRelationShips returns the relationships hidden from the view
+func (s *ComponentsViewNode) ExcludedRelationShips() []*RelationShipNode {
+	return s.excludedRels
+}
+
 func (s *ComponentsViewNode) AutoLayout() bool {
 	return s.autoLayout
 }
@@ -63,3 +92,59 @@ func (s *ComponentsViewNode) AddAllPeople() *ComponentsViewNode {
 func (s *ComponentsViewNode) IsAllPeople() bool {
 	return s.addAllPeople
 }
+
+func (s *ComponentsViewNode) WithInclude(e *ExpressionViewNode) *ComponentsViewNode {
+	s.includes = append(s.includes, e)
+	return s
+}
+
+func (s *ComponentsViewNode) Includes() []*ExpressionViewNode {
+	return s.includes
+}
+
+// WithExclude removes the elements matched by the expression from the view
+func (s *ComponentsViewNode) WithExclude(e *ExpressionViewNode) *ComponentsViewNode {
+	s.excludes = append(s.excludes, e)
+	return s
+}
+
+func (s *ComponentsViewNode) Excludes() []*ExpressionViewNode {
+	return s.excludes
+}
+
+// AddAllComponents adds all components of the container to the view
+func (s *ComponentsViewNode) AddAllComponents() *ComponentsViewNode {
+	s.addAllElement = true
+	return s
+}
+
+// AddContainer adds a container, typically one outside of the container in scope, to the view
+func (s *ComponentsViewNode) AddContainer(container *ContainerNode) *ComponentsViewNode {
+	s.elements = append(s.elements, container)
+	return s
+}
+
+// AddSoftwareSystem adds a software system to the view
+func (s *ComponentsViewNode) AddSoftwareSystem(system *SoftwareSystemNode) *ComponentsViewNode {
+	s.elements = append(s.elements, system)
+	return s
+}
+
+// AddPerson adds a person to the view
+func (s *ComponentsViewNode) AddPerson(person *PersonNode) *ComponentsViewNode {
+	s.elements = append(s.elements, person)
+	return s
+}
+
+// Elements returns the elements added one by one to the view
+func (s *ComponentsViewNode) Elements() []Namer {
+	return s.elements
+}
+
+// Layout returns the manual layout of the view
+func (s *ComponentsViewNode) Layout() *LayoutNode {
+	if s.layout == nil {
+		s.layout = layout()
+	}
+	return s.layout
+}
@@ -0,0 +1,52 @@
+package gostructurizr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComponentsView_Content(t *testing.T) {
+	w := Workspace()
+	m := w.Model()
+	customer := m.AddPerson("Customer", "")
+	admin := m.AddPerson("Admin", "")
+	shop := m.AddSoftwareSystem("Shop", "")
+	mailer := m.AddSoftwareSystem("Mailer", "")
+	web := shop.AddContainer("Web", "", "")
+	api := shop.AddContainer("API", "", "")
+	db := shop.AddContainer("Database", "", "")
+	orders := api.AddComponent("Orders")
+	accounts := api.AddComponent("Accounts")
+	web.Uses(orders, "Places orders")
+	orders.Uses(accounts, "Checks")
+	orders.Uses(db, "Stores")
+	accounts.Uses(mailer, "Notifies")
+	customer.Uses(web, "Browses")
+
+	t.Run("include all", func(t *testing.T) {
+		c := w.Views().CreateComponentView(api).AddAllComponents().Content()
+		assert.Equal(t, []Namer{orders, accounts, web, db, mailer}, c.Elements())
+		assert.Len(t, c.RelationShips(), 4)
+	})
+
+	t.Run("exclude", func(t *testing.T) {
+		c := w.Views().CreateComponentView(api).AddAllComponents().WithExclude(On(db)).Content()
+		assert.Equal(t, []Namer{orders, accounts, web, mailer}, c.Elements())
+		assert.Len(t, c.RelationShips(), 3)
+	})
+
+	t.Run("afferent and efferent", func(t *testing.T) {
+		c := w.Views().CreateComponentView(api).
+			WithInclude(On(orders).WithAfferent(true).WithEfferent(true)).
+			AddPerson(admin).
+			Content()
+		assert.Equal(t, []Namer{admin, orders, web, accounts, db}, c.Elements())
+	})
+
+	t.Run("external container", func(t *testing.T) {
+		c := w.Views().CreateComponentView(api).WithInclude(On(orders)).AddContainer(web).Content()
+		assert.Equal(t, []Namer{web, orders}, c.Elements())
+		assert.Equal(t, web, c.RelationShips()[0].From())
+	})
+}
@@ -7,12 +7,15 @@ type ContainerNode struct {
 	tech       *string
 	tags       *TagsNode
 	components []*ComponentNode
+	groups     []*GroupNode[*ComponentNode]
+	properties Properties
 }
 
 func Container(name string) *ContainerNode {
 	return &ContainerNode{
-		name: name,
-		tags: &TagsNode{Tags: []string{}},
+		name:       name,
+		tags:       &TagsNode{Tags: []string{}},
+		properties: NewProperties(),
 	}
 }
 
@@ -52,6 +55,11 @@ func (c *ContainerNode) Tags() *TagsNode {
 	return c.tags
 }
 
+// Properties returns the properties of the container
+func (c *ContainerNode) Properties() *Properties {
+	return &c.properties
+}
+
 func (c *ContainerNode) AddComponent(name string) *ComponentNode {
 	component := Component(name)
 	component.node = c
@@ -64,6 +72,24 @@ func (c *ContainerNode) Components() []*ComponentNode {
 	return c.components
 }
 
+// AddGroup adds a group of components to the container
+func (c *ContainerNode) AddGroup(name string) *GroupNode[*ComponentNode] {
+	g := Group[*ComponentNode](name)
+	g.top = &c.groups
+	c.groups = append(c.groups, g)
+	return g
+}
+
+// Groups returns the top level groups of components
+func (c *ContainerNode) Groups() []*GroupNode[*ComponentNode] {
+	return c.groups
+}
+
+// GroupOf returns the innermost group holding a component, nil if it is in none
+func (c *ContainerNode) GroupOf(component *ComponentNode) *GroupNode[*ComponentNode] {
+	return groupOf(c.groups, component)
+}
+
 func (c *ContainerNode) Uses(to Namer, desc string) *RelationShipNode {
 	return c.sys.model.addRelationShip(c, to, desc)
 }
@@ -29,6 +29,9 @@ func ContainerInstance(container *ContainerNode) *ContainerInstanceNode {
 
 // Name returns the name of the container instance
 func (c *ContainerInstanceNode) Name() string {
+	if c.container == nil {
+		return ""
+	}
 	return c.container.Name()
 }
 
@@ -7,7 +7,11 @@ type ContainersViewNode struct {
 	addAllPeople     bool
 	autoLayout       bool
 	includes         []*ExpressionViewNode
+	excludes         []*ExpressionViewNode
 	softwareSystems  []*SoftwareSystemNode
+	layout           *LayoutNode
+	withoutImplied   bool
+	excludedRels     []*RelationShipNode
 }
 
 func containersView(softwareSystem *SoftwareSystemNode) *ContainersViewNode {
@@ -49,6 +53,29 @@ func (s *ContainersViewNode) WithAutoLayout() *ContainersViewNode {
 	return s
 }
 
+// WithoutImpliedRelationships hides the implied relationships from the view, which only displays
+// the relationships added explicitly. The DSL renders it as an exclude statement per implied relationship.
+func (s *ContainersViewNode) WithoutImpliedRelationships() *ContainersViewNode {
+	s.withoutImplied = true
+	return s
+}
+
+// IsWithoutImpliedRelationships reports whether the implied relationships are hidden from the view
+func (s *ContainersViewNode) IsWithoutImpliedRelationships() bool {
+	return s.withoutImplied
+}
+
+// ExcludeRelationShip hides a relationship from the view, its elements staying displayed
+func (s *ContainersViewNode) ExcludeRelationShip(r *RelationShipNode) *ContainersViewNode {
+	s.excludedRels = append(s.excludedRels, r)
+	return s
+}
+
+// ExcludedRelationShips returns the relationships hidden from the view
+func (s *ContainersViewNode) ExcludedRelationShips() []*RelationShipNode {
+	return s.excludedRels
+}
+
 func (s *ContainersViewNode) AutoLayout() bool {
 	return s.autoLayout
 }
@@ -66,14 +93,30 @@ func (s *ContainersViewNode) IsAllPeople() bool {
 	return s.addAllPeople
 }
 
-func (s *ContainersViewNode) WithInclude(e *ExpressionViewNode) {
+func (s *ContainersViewNode) WithInclude(e *ExpressionViewNode) *ContainersViewNode {
 	s.includes = append(s.includes, e)
+	return s
 }
 
 func (s *ContainersViewNode) Includes() []*ExpressionViewNode {
 	return s.includes
 }
 
+// WithExclude removes the elements matched by the expression from the view
+func (s *ContainersViewNode) WithExclude(e *ExpressionViewNode) *ContainersViewNode {
+	s.excludes = append(s.excludes, e)
+	return s
+}
+
+func (s *ContainersViewNode) Excludes() []*ExpressionViewNode {
+	return s.excludes
+}
+
+// SoftwareSystems returns the software systems added one by one to the view
+func (s *ContainersViewNode) SoftwareSystems() []*SoftwareSystemNode {
+	return s.softwareSystems
+}
+
 // AddAllContainers adds all containers to the view
 func (s *ContainersViewNode) AddAllContainers() *ContainersViewNode {
 	s.addAllElement = true
@@ -85,3 +128,11 @@ func (s *ContainersViewNode) AddSoftwareSystem(system *SoftwareSystemNode) *Cont
 	s.softwareSystems = append(s.softwareSystems, system)
 	return s
 }
+
+// Layout returns the manual layout of the view
+func (s *ContainersViewNode) Layout() *LayoutNode {
+	if s.layout == nil {
+		s.layout = layout()
+	}
+	return s.layout
+}
@@ -22,6 +22,30 @@ const (
 	ExternalLocation Location = "External"
 )
 
+// Tag returns the tag given to the people and software systems of the location, empty when unspecified
+func (l Location) Tag() tags.Tag {
+	switch l {
+	case InternalLocation:
+		return tags.Internal
+	case ExternalLocation:
+		return tags.External
+	}
+	return ""
+}
+
+// relocate swaps the tag of an element's former location for the tag of its new one
+func relocate(t *TagsNode, from, to Location) {
+	if from == to {
+		return
+	}
+	if from.Tag() != "" {
+		t.Remove(from.Tag().String())
+	}
+	if to.Tag() != "" {
+		t.Add(to.Tag().String())
+	}
+}
+
 // DeploymentNodeNode represents a deployment node in the architecture
 type DeploymentNodeNode struct {
 	name                string
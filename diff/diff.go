@@ -0,0 +1,542 @@
+// Package diff compares two workspaces and reports what changed in the architecture they describe:
+// the elements, relationships, views and styles added, removed or changed, rather than the lines of their DSL.
+//
+//	d := diff.Compare(before, after)
+//	d.WriteMarkdown(os.Stdout)
+//
+// Elements are keyed by their canonical path, the names of the elements containing them followed by their own
+// name (Shop/API/Orders, Production/AWS/Web Server), so renaming an element is reported as removing it and adding
+// another one. Relationships are keyed by the paths of their source and destination, views by their key and
+// styles by their tag.
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/platelk/gostructurizr"
+	"github.com/platelk/gostructurizr/renderer"
+)
+
+// Kind tells whether something was added, removed or changed
+type Kind string
+
+const (
+	Added   Kind = "added"
+	Removed Kind = "removed"
+	Changed Kind = "changed"
+)
+
+// Types of the things compared, Change.Type being one of them
+const (
+	TypeWorkspace          = "workspace"
+	TypePerson             = "person"
+	TypeSoftwareSystem     = "softwareSystem"
+	TypeContainer          = "container"
+	TypeComponent          = "component"
+	TypeDeploymentNode     = "deploymentNode"
+	TypeInfrastructureNode = "infrastructureNode"
+	TypeContainerInstance  = "containerInstance"
+	TypeRelationship       = "relationship"
+	TypeView               = "view"
+	TypeElementStyle       = "elementStyle"
+	TypeRelationshipStyle  = "relationshipStyle"
+)
+
+// Change is a difference between the two workspaces. Without a field, the whole element, relationship,
+// view or style was added or removed. Fields holding several values, the tags of an element or the elements
+// of a view, report each value added or removed as a change of its own.
+type Change struct {
+	Kind  Kind   `json:"kind"`
+	Type  string `json:"type"`
+	Path  string `json:"path"`
+	Field string `json:"field,omitempty"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+}
+
+// Diff lists the changes between two workspaces, grouped by section (workspace, elements, relationships,
+// views, styles) then sorted by path
+type Diff struct {
+	Changes []Change `json:"changes"`
+}
+
+// IsEmpty reports whether both workspaces describe the same architecture
+func (d *Diff) IsEmpty() bool {
+	return len(d.Changes) == 0
+}
+
+// Compare returns the changes turning before into after
+func Compare(before, after *gostructurizr.WorkspaceNode) *Diff {
+	d := &Diff{}
+	d.compareItems(workspaceItems(before), workspaceItems(after))
+	d.compareItems(elementItems(before.Model()), elementItems(after.Model()))
+	d.compareRelationships(before.Model(), after.Model())
+	d.compareItems(viewItems(before), viewItems(after))
+	d.compareItems(styleItems(before), styleItems(after))
+	sort.SliceStable(d.Changes, func(i, j int) bool {
+		a, b := d.Changes[i], d.Changes[j]
+		if section(a.Type) != section(b.Type) {
+			return section(a.Type) < section(b.Type)
+		}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Type < b.Type
+	})
+	return d
+}
+
+// item is anything compared: an element, a relationship, a view or a style
+type item struct {
+	typ, path string
+	// fields holds the single valued attributes, sets the multi valued ones
+	fields map[string]string
+	sets   map[string][]string
+}
+
+func newItem(typ, path string) *item {
+	return &item{typ: typ, path: path, fields: map[string]string{}, sets: map[string][]string{}}
+}
+
+// set records a field, skipping nil pointers and empty values
+func (i *item) set(field string, value interface{}) {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		return
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Slice && v.Len() == 0 {
+		return
+	}
+	if s := fmt.Sprint(v.Interface()); s != "" {
+		i.fields[field] = s
+	}
+}
+
+func (i *item) key() string {
+	return i.typ + "\x00" + i.path
+}
+
+// itemList keeps items in the order they were added, and makes their keys unique
+type itemList struct {
+	items []*item
+	keys  map[string]bool
+}
+
+func (l *itemList) add(i *item) *item {
+	if l.keys == nil {
+		l.keys = map[string]bool{}
+	}
+	path := i.path
+	for n := 2; l.keys[i.key()]; n++ {
+		i.path = fmt.Sprintf("%s (%d)", path, n)
+	}
+	l.keys[i.key()] = true
+	l.items = append(l.items, i)
+	return i
+}
+
+func (d *Diff) compareItems(before, after *itemList) {
+	afterByKey := map[string]*item{}
+	for _, i := range after.items {
+		afterByKey[i.key()] = i
+	}
+	beforeByKey := map[string]*item{}
+	for _, b := range before.items {
+		beforeByKey[b.key()] = b
+		if a, ok := afterByKey[b.key()]; ok {
+			d.compareItem(b, a)
+		} else {
+			d.Changes = append(d.Changes, Change{Kind: Removed, Type: b.typ, Path: b.path})
+		}
+	}
+	for _, a := range after.items {
+		if _, ok := beforeByKey[a.key()]; !ok {
+			d.Changes = append(d.Changes, Change{Kind: Added, Type: a.typ, Path: a.path})
+		}
+	}
+}
+
+// compareItem reports the fields changed between two versions of the same item
+func (d *Diff) compareItem(before, after *item) {
+	for _, field := range sortedKeys(before.fields, after.fields) {
+		if before.fields[field] != after.fields[field] {
+			d.Changes = append(d.Changes, Change{
+				Kind: Changed, Type: after.typ, Path: after.path, Field: field,
+				Old: before.fields[field], New: after.fields[field],
+			})
+		}
+	}
+	for _, field := range sortedKeys(before.sets, after.sets) {
+		removed, added := difference(before.sets[field], after.sets[field])
+		for _, v := range removed {
+			d.Changes = append(d.Changes, Change{Kind: Removed, Type: after.typ, Path: after.path, Field: field, Old: v})
+		}
+		for _, v := range added {
+			d.Changes = append(d.Changes, Change{Kind: Added, Type: after.typ, Path: after.path, Field: field, New: v})
+		}
+	}
+}
+
+// compareRelationships matches the relationships between the same elements, the ones with the same description
+// first, so several relationships between two elements are told apart
+func (d *Diff) compareRelationships(before, after *gostructurizr.ModelNode) {
+	beforeGroups, beforeOrder := relationshipGroups(before)
+	afterGroups, afterOrder := relationshipGroups(after)
+	for _, path := range beforeOrder {
+		b, a := beforeGroups[path], afterGroups[path]
+		var unmatched []*item
+		for _, r := range b {
+			if i := indexOfDescription(a, r.fields["description"]); i >= 0 {
+				d.compareItem(r, a[i])
+				a = append(a[:i:i], a[i+1:]...)
+			} else {
+				unmatched = append(unmatched, r)
+			}
+		}
+		for _, r := range unmatched {
+			if len(a) > 0 {
+				d.compareItem(r, a[0])
+				a = a[1:]
+				continue
+			}
+			d.Changes = append(d.Changes, Change{Kind: Removed, Type: TypeRelationship, Path: path, Old: r.fields["description"]})
+		}
+		afterGroups[path] = a
+	}
+	for _, path := range afterOrder {
+		for _, r := range afterGroups[path] {
+			d.Changes = append(d.Changes, Change{Kind: Added, Type: TypeRelationship, Path: path, New: r.fields["description"]})
+		}
+	}
+}
+
+func indexOfDescription(items []*item, description string) int {
+	for i, r := range items {
+		if r.fields["description"] == description {
+			return i
+		}
+	}
+	return -1
+}
+
+// relationshipGroups groups the relationships by source and destination. Implied relationships are left
+// out, they follow from the other ones.
+func relationshipGroups(m *gostructurizr.ModelNode) (map[string][]*item, []string) {
+	paths := elementPaths(m)
+	groups := map[string][]*item{}
+	var order []string
+	for _, r := range m.RelationShip() {
+		if r.IsImplied() {
+			continue
+		}
+		path := relationshipPath(paths, r)
+		i := newItem(TypeRelationship, path)
+		i.set("description", r.Description())
+		i.set("technology", r.Technology())
+		i.set("interactionStyle", r.InteractionStyle())
+		i.set("url", r.URL())
+		i.sets["tags"] = splitTags(r.Tags().List())
+		for key, value := range r.Properties().Properties {
+			i.fields["properties."+key] = value
+		}
+		if _, ok := groups[path]; !ok {
+			order = append(order, path)
+		}
+		groups[path] = append(groups[path], i)
+	}
+	return groups, order
+}
+
+func relationshipPath(paths map[gostructurizr.Namer]string, r *gostructurizr.RelationShipNode) string {
+	return pathOf(paths, r.From()) + " -> " + pathOf(paths, r.To())
+}
+
+// pathOf returns the canonical path of an element, its name for the elements outside of the model
+func pathOf(paths map[gostructurizr.Namer]string, n gostructurizr.Namer) string {
+	if path, ok := paths[n]; ok {
+		return path
+	}
+	return n.Name()
+}
+
+func workspaceItems(w *gostructurizr.WorkspaceNode) *itemList {
+	l := &itemList{}
+	i := l.add(newItem(TypeWorkspace, ""))
+	i.set("name", w.Name())
+	i.set("description", w.Desc())
+	return l
+}
+
+// elementPaths returns the canonical path of every element of the model
+func elementPaths(m *gostructurizr.ModelNode) map[gostructurizr.Namer]string {
+	paths := map[gostructurizr.Namer]string{}
+	walk(m, func(n gostructurizr.Namer, typ, path string) {
+		paths[n] = path
+	})
+	return paths
+}
+
+// walk visits the elements of the model, parents first, with their type and path
+func walk(m *gostructurizr.ModelNode, visit func(n gostructurizr.Namer, typ, path string)) {
+	for _, p := range m.Persons() {
+		visit(p, TypePerson, p.Name())
+	}
+	for _, s := range m.SoftwareSystems() {
+		visit(s, TypeSoftwareSystem, s.Name())
+		for _, c := range s.Containers() {
+			visit(c, TypeContainer, s.Name()+"/"+c.Name())
+			for _, component := range c.Components() {
+				visit(component, TypeComponent, s.Name()+"/"+c.Name()+"/"+component.Name())
+			}
+		}
+	}
+	var walkNode func(d *gostructurizr.DeploymentNodeNode, parent string)
+	walkNode = func(d *gostructurizr.DeploymentNodeNode, parent string) {
+		path := parent + "/" + d.Name()
+		visit(d, TypeDeploymentNode, path)
+		for _, child := range d.Children() {
+			walkNode(child, path)
+		}
+		for _, infra := range d.InfrastructureNodes() {
+			visit(infra, TypeInfrastructureNode, path+"/"+infra.Name())
+		}
+		for _, instance := range d.ContainerInstances() {
+			visit(instance, TypeContainerInstance, fmt.Sprintf("%s/%s[%d]", path, instance.Name(), instance.InstanceId()))
+		}
+	}
+	for _, d := range m.DeploymentNodes() {
+		walkNode(d, string(d.Environment()))
+	}
+}
+
+func elementItems(m *gostructurizr.ModelNode) *itemList {
+	l := &itemList{}
+	walk(m, func(n gostructurizr.Namer, typ, path string) {
+		i := l.add(newItem(typ, path))
+		switch e := n.(type) {
+		case *gostructurizr.PersonNode:
+			i.set("description", e.Description())
+			i.set("location", e.Location())
+			setGroup(i, m.GroupOf(e))
+		case *gostructurizr.SoftwareSystemNode:
+			i.set("description", e.Description())
+			i.set("location", e.Location())
+			setGroup(i, m.GroupOf(e))
+		case *gostructurizr.ContainerNode:
+			i.set("description", e.Description())
+			i.set("technology", e.Technology())
+			setGroup(i, e.Parent().GroupOf(e))
+		case *gostructurizr.ComponentNode:
+			i.set("description", e.Description())
+			i.set("technology", e.Technology())
+			setGroup(i, e.Parent().GroupOf(e))
+		case *gostructurizr.DeploymentNodeNode:
+			i.set("description", e.Description())
+			i.set("technology", e.Technology())
+		case *gostructurizr.InfrastructureNodeNode:
+			i.set("description", e.Description())
+			i.set("technology", e.Technology())
+		}
+		if t, ok := n.(interface {
+			Tags() *gostructurizr.TagsNode
+		}); ok && t.Tags() != nil {
+			i.sets["tags"] = splitTags(t.Tags().List())
+		}
+		if p, ok := n.(interface {
+			Properties() *gostructurizr.Properties
+		}); ok && p.Properties() != nil {
+			for key, value := range p.Properties().Properties {
+				i.fields["properties."+key] = value
+			}
+		}
+	})
+	return l
+}
+
+// setGroup records the full name of the group of an element, if any
+func setGroup[K gostructurizr.Namer](i *item, g *gostructurizr.GroupNode[K]) {
+	if g != nil {
+		i.fields["group"] = g.FullName()
+	}
+}
+
+func splitTags(values []string) []string {
+	var all []string
+	for _, value := range values {
+		for _, tag := range strings.Split(value, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				all = append(all, tag)
+			}
+		}
+	}
+	return all
+}
+
+func viewItems(w *gostructurizr.WorkspaceNode) *itemList {
+	l := &itemList{}
+	v := w.Views()
+	keys := renderer.ViewKeys(v)
+	paths := elementPaths(w.Model())
+	add := func(view interface{}, typ string, description *string, scope gostructurizr.Namer, autoLayout bool, content *gostructurizr.ViewContent) {
+		i := l.add(newItem(TypeView, keys[view]))
+		i.fields["type"] = typ
+		i.set("description", description)
+		if scope != nil {
+			i.fields["scope"] = pathOf(paths, scope)
+		}
+		if autoLayout {
+			i.fields["autoLayout"] = "true"
+		}
+		for _, e := range content.Elements() {
+			i.sets["elements"] = append(i.sets["elements"], pathOf(paths, e))
+		}
+		for _, r := range content.RelationShips() {
+			i.sets["relationships"] = append(i.sets["relationships"], relationshipPath(paths, r))
+		}
+	}
+	for _, s := range v.SystemLandscapeViews() {
+		add(s, "systemLandscape", s.Description(), nil, s.AutoLayout(), s.Content())
+	}
+	for _, s := range v.SystemContextViews() {
+		add(s, "systemContext", s.Description(), s.SoftwareSystem(), s.AutoLayout(), s.Content())
+	}
+	for _, c := range v.ContainerViews() {
+		add(c, "container", c.Description(), c.SoftwareSystem(), c.AutoLayout(), c.Content())
+	}
+	for _, c := range v.ComponentViews() {
+		add(c, "component", c.Description(), c.Container(), c.AutoLayout(), c.Content())
+	}
+	for _, d := range v.DynamicViews() {
+		add(d, "dynamic", d.Description(), d.Identifier(), d.AutoLayout(), d.Content())
+	}
+	for _, d := range v.DeploymentViews() {
+		description := d.GetDescription()
+		var scope gostructurizr.Namer
+		if d.SoftwareSystem() != nil {
+			scope = d.SoftwareSystem()
+		}
+		add(d, "deployment", &description, scope, d.IsAutoLayout(), d.Content())
+		l.items[len(l.items)-1].fields["environment"] = string(d.Environment())
+	}
+	for _, f := range v.FilteredViews() {
+		i := l.add(newItem(TypeView, keys[f]))
+		i.fields["type"] = "filtered"
+		i.set("title", f.Title())
+		i.set("description", f.Description())
+		if f.BaseView() != nil {
+			i.set("baseView", keys[f.BaseView()])
+		}
+		for _, c := range f.FilterCriteria() {
+			i.sets["filters"] = append(i.sets["filters"], fmt.Sprintf("%s %s %s", c.Mode, c.Type, c.Value))
+		}
+	}
+	return l
+}
+
+func styleItems(w *gostructurizr.WorkspaceNode) *itemList {
+	l := &itemList{}
+	styles := w.Views().Configuration().Styles()
+	for _, s := range styles.ElementsStyle() {
+		i := l.add(newItem(TypeElementStyle, string(s.Tag())))
+		i.set("width", s.Width())
+		i.set("height", s.Height())
+		i.set("background", s.Background())
+		i.set("stroke", s.Stroke())
+		i.set("strokeWidth", s.StrokeWidth())
+		i.set("color", s.Color())
+		i.set("fontSize", s.FontSize())
+		i.set("fontFamily", s.FontFamily())
+		i.set("fontStyle", s.FontStyle())
+		i.set("shape", s.Shape())
+		i.set("icon", s.Icon())
+		i.set("icons", s.MultipleIcons())
+		i.set("opacity", s.Opacity())
+		i.set("metadata", s.Metadata())
+		i.set("description", s.Description())
+		i.set("border", s.BorderStyle())
+		i.set("borderWidth", s.Border())
+		i.set("shadow", s.Shadow())
+		i.set("zIndex", s.ZIndex())
+		i.set("rotation", s.Rotation())
+		i.set("position", s.Position())
+	}
+	for _, s := range styles.RelationshipStyles() {
+		i := l.add(newItem(TypeRelationshipStyle, string(s.Tag())))
+		i.set("thickness", s.Thickness())
+		i.set("color", s.Color())
+		i.set("opacity", s.Opacity())
+		i.set("width", s.Width())
+		i.set("style", s.LineStyle())
+		i.set("fontSize", s.FontSize())
+		i.set("fontColor", s.FontColor())
+		i.set("fontFamily", s.FontFamily())
+		i.set("fontStyle", s.FontStyle())
+		i.set("routing", s.Routing())
+		i.set("position", s.Position())
+		i.set("startTerminator", s.StartTerminator())
+		i.set("endTerminator", s.EndTerminator())
+	}
+	return l
+}
+
+// section orders the changes: workspace, elements, relationships, views then styles
+func section(typ string) int {
+	switch typ {
+	case TypeWorkspace:
+		return 0
+	case TypeRelationship:
+		return 2
+	case TypeView:
+		return 3
+	case TypeElementStyle, TypeRelationshipStyle:
+		return 4
+	}
+	return 1
+}
+
+// difference returns the values only in before and the ones only in after, in their order
+func difference(before, after []string) (removed, added []string) {
+	in := func(values []string, v string) bool {
+		for _, each := range values {
+			if each == v {
+				return true
+			}
+		}
+		return false
+	}
+	for _, v := range before {
+		if !in(after, v) && !in(removed, v) {
+			removed = append(removed, v)
+		}
+	}
+	for _, v := range after {
+		if !in(before, v) && !in(added, v) {
+			added = append(added, v)
+		}
+	}
+	return removed, added
+}
+
+func sortedKeys[V any](maps ...map[string]V) []string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, m := range maps {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,106 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platelk/gostructurizr"
+	"github.com/platelk/gostructurizr/shapes"
+)
+
+// shop builds the workspace compared, changed applying the modifications of the new version
+func shop(changed bool) *gostructurizr.WorkspaceNode {
+	w := gostructurizr.Workspace().WithName("Shop")
+	m := w.Model()
+	customer := m.AddPerson("Customer", "Buys things")
+	system := m.AddSoftwareSystem("Shop", "Sells things")
+	web := system.AddContainer("Web", "Storefront", "Go")
+	db := system.AddContainer("Database", "Stores orders", "PostgreSQL")
+	customer.Uses(web, "Browses")
+	web.Uses(db, "Reads from")
+	web.Uses(db, "Writes to")
+	w.Views().CreateContainerView(system).WithKey("containers").AddAllElements()
+	style := w.Views().Configuration().Styles().AddElementStyle("Database")
+	if !changed {
+		m.AddSoftwareSystem("Legacy", "")
+		web.Uses(db, "Locks")
+		style.WithShape(shapes.Cylinder)
+		return w
+	}
+	web.WithDesc("Storefront and checkout").WithTag("Public")
+	api := system.AddContainer("API", "Serves the mobile app", "Go")
+	api.Uses(db, "Reads from").WithTechnology("SQL")
+	web.Uses(db, "Writes to").WithTechnology("SQL")
+	style.WithShape(shapes.Cylinder).WithBackground("#438dd5")
+	return w
+}
+
+func TestCompare(t *testing.T) {
+	d := Compare(shop(false), shop(true))
+
+	assert.Equal(t, []Change{
+		{Kind: Removed, Type: TypeSoftwareSystem, Path: "Legacy"},
+		{Kind: Added, Type: TypeContainer, Path: "Shop/API"},
+		{Kind: Changed, Type: TypeContainer, Path: "Shop/Web", Field: "description", Old: "Storefront", New: "Storefront and checkout"},
+		{Kind: Added, Type: TypeContainer, Path: "Shop/Web", Field: "tags", New: "Public"},
+		{Kind: Added, Type: TypeRelationship, Path: "Shop/API -> Shop/Database", New: "Reads from"},
+		{Kind: Changed, Type: TypeRelationship, Path: "Shop/Web -> Shop/Database", Field: "description", Old: "Locks", New: "Writes to"},
+		{Kind: Changed, Type: TypeRelationship, Path: "Shop/Web -> Shop/Database", Field: "technology", New: "SQL"},
+		{Kind: Added, Type: TypeView, Path: "containers", Field: "elements", New: "Shop/API"},
+		{Kind: Added, Type: TypeView, Path: "containers", Field: "relationships", New: "Shop/API -> Shop/Database"},
+		{Kind: Changed, Type: TypeElementStyle, Path: "Database", Field: "background", New: "#438dd5"},
+	}, d.Changes)
+}
+
+func TestCompare_same(t *testing.T) {
+	assert.True(t, Compare(shop(true), shop(true)).IsEmpty())
+}
+
+func TestDiff_WriteMarkdown(t *testing.T) {
+	buf := bytes.Buffer{}
+	require.NoError(t, Compare(shop(false), shop(true)).WriteMarkdown(&buf))
+	assert.Equal(t, "## Architecture changes\n"+`
+### Elements
+
+- Removed software system `+"`Legacy`"+`
+- Added container `+"`Shop/API`"+`
+- Changed description of container `+"`Shop/Web`"+`: "Storefront" → "Storefront and checkout"
+- Added tag `+"`Public`"+` to container `+"`Shop/Web`"+`
+
+### Relationships
+
+- Added relationship `+"`Shop/API -> Shop/Database`"+`: "Reads from"
+- Changed description of relationship `+"`Shop/Web -> Shop/Database`"+`: "Locks" → "Writes to"
+- Changed technology of relationship `+"`Shop/Web -> Shop/Database`"+`: _none_ → "SQL"
+
+### Views
+
+- Added element `+"`Shop/API`"+` to view `+"`containers`"+`
+- Added relationship `+"`Shop/API -> Shop/Database`"+` to view `+"`containers`"+`
+
+### Styles
+
+- Changed background of element style `+"`Database`"+`: _none_ → "#438dd5"
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, Compare(shop(true), shop(true)).WriteMarkdown(&buf))
+	assert.Equal(t, "## Architecture changes\n\nNo architecture changes.\n", buf.String())
+}
+
+func TestDiff_WriteJSON(t *testing.T) {
+	buf := bytes.Buffer{}
+	require.NoError(t, Compare(shop(false), shop(true)).WriteJSON(&buf))
+	var decoded Diff
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, Compare(shop(false), shop(true)), &decoded)
+	assert.Contains(t, buf.String(), `"kind": "removed"`)
+
+	buf.Reset()
+	require.NoError(t, Compare(shop(true), shop(true)).WriteJSON(&buf))
+	assert.JSONEq(t, `{"changes": []}`, buf.String())
+}
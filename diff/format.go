@@ -0,0 +1,112 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var sectionTitles = []string{"Workspace", "Elements", "Relationships", "Views", "Styles"}
+
+var typeNames = map[string]string{
+	TypeWorkspace:          "workspace",
+	TypePerson:             "person",
+	TypeSoftwareSystem:     "software system",
+	TypeContainer:          "container",
+	TypeComponent:          "component",
+	TypeDeploymentNode:     "deployment node",
+	TypeInfrastructureNode: "infrastructure node",
+	TypeContainerInstance:  "container instance",
+	TypeRelationship:       "relationship",
+	TypeView:               "view",
+	TypeElementStyle:       "element style",
+	TypeRelationshipStyle:  "relationship style",
+}
+
+// WriteMarkdown writes the changes as a Markdown changelog, a list of changes per section
+func (d *Diff) WriteMarkdown(w io.Writer) error {
+	rendered := &strings.Builder{}
+	rendered.WriteString("## Architecture changes\n")
+	if d.IsEmpty() {
+		rendered.WriteString("\nNo architecture changes.\n")
+	}
+	current := -1
+	for _, c := range d.Changes {
+		if s := section(c.Type); s != current {
+			current = s
+			fmt.Fprintf(rendered, "\n### %s\n\n", sectionTitles[s])
+		}
+		fmt.Fprintf(rendered, "- %s\n", c.markdown())
+	}
+	if _, err := w.Write([]byte(rendered.String())); err != nil {
+		return fmt.Errorf("can't write diff: %w", err)
+	}
+	return nil
+}
+
+// markdown describes the change in a sentence
+func (c Change) markdown() string {
+	subject := typeNames[c.Type]
+	if c.Path != "" {
+		subject += " " + code(c.Path)
+	}
+	switch {
+	case c.Field == "" && c.Kind == Added:
+		return withDescription("Added "+subject, c.New)
+	case c.Field == "" && c.Kind == Removed:
+		return withDescription("Removed "+subject, c.Old)
+	case c.Kind == Added:
+		return fmt.Sprintf("Added %s %s to %s", singular(c.Field), code(c.New), subject)
+	case c.Kind == Removed:
+		return fmt.Sprintf("Removed %s %s from %s", singular(c.Field), code(c.Old), subject)
+	}
+	field := c.Field
+	if key, ok := strings.CutPrefix(field, "properties."); ok {
+		field = "property " + code(key)
+	}
+	return fmt.Sprintf("Changed %s of %s: %s → %s", field, subject, value(c.Old), value(c.New))
+}
+
+func withDescription(sentence, description string) string {
+	if description == "" {
+		return sentence
+	}
+	return fmt.Sprintf("%s: %q", sentence, description)
+}
+
+// singular names one value of a multi valued field
+func singular(field string) string {
+	switch field {
+	case "properties":
+		return "property"
+	case "relationships":
+		return "relationship"
+	}
+	return strings.TrimSuffix(field, "s")
+}
+
+func code(s string) string {
+	return "`" + s + "`"
+}
+
+func value(s string) string {
+	if s == "" {
+		return "_none_"
+	}
+	return fmt.Sprintf("%q", s)
+}
+
+// WriteJSON writes the changes as a JSON document
+func (d *Diff) WriteJSON(w io.Writer) error {
+	doc := *d
+	if doc.Changes == nil {
+		doc.Changes = []Change{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("can't write diff: %w", err)
+	}
+	return nil
+}
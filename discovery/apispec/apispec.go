@@ -0,0 +1,246 @@
+// Package apispec attaches OpenAPI 3 and AsyncAPI documents to the containers providing them, and relates the
+// consumers of a mapping file to those containers:
+//
+//	err := apispec.NewImporter().
+//		WithOpenAPI(api, "api/openapi.yaml").
+//		WithAsyncAPI(api, "api/asyncapi.yaml").
+//		WithConsumers("api/consumers.yaml").
+//		Import(model)
+//
+// Each operation (GET /orders) or channel (orders/created) becomes a property of its container, valued by its
+// summary or description, and the title and version of each document one named openapi or asyncapi.
+//
+// The mapping file lists, per consumer, the containers it uses and the operations or channels it uses them for,
+// all of a container's documents being used when none is given. Elements are named by name, or by path
+// (Shop/API) when several share a name:
+//
+//	Web App:
+//	  Shop/API:
+//	    - GET /orders
+//	    - POST /orders
+//	Mailer:
+//	  Shop/API: [orders/created]
+//
+// Consumers use the container once per document and technology, synchronously over HTTPS/JSON for OpenAPI
+// operations and asynchronously over the protocol of their server for AsyncAPI channels.
+package apispec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/platelk/gostructurizr"
+)
+
+// HTTPTechnology is the technology of the relationships to OpenAPI operations
+const HTTPTechnology = "HTTPS/JSON"
+
+// Importer reads API documents from disk
+type Importer struct {
+	sources   []source
+	consumers []string
+}
+
+type source struct {
+	container *gostructurizr.ContainerNode
+	path      string
+	read      func(path string) (*document, error)
+}
+
+func NewImporter() *Importer {
+	return &Importer{}
+}
+
+// WithOpenAPI attaches the OpenAPI 3 document at path to container
+func (i *Importer) WithOpenAPI(container *gostructurizr.ContainerNode, path string) *Importer {
+	i.sources = append(i.sources, source{container: container, path: path, read: readOpenAPI})
+	return i
+}
+
+// WithAsyncAPI attaches the AsyncAPI 2 or 3 document at path to container
+func (i *Importer) WithAsyncAPI(container *gostructurizr.ContainerNode, path string) *Importer {
+	i.sources = append(i.sources, source{container: container, path: path, read: readAsyncAPI})
+	return i
+}
+
+// WithConsumers reads the consumers of the documents from the mapping file at path
+func (i *Importer) WithConsumers(path string) *Importer {
+	i.consumers = append(i.consumers, path)
+	return i
+}
+
+// Import reads the documents and mapping files, adding properties and relationships to the elements of m
+func (i *Importer) Import(m *gostructurizr.ModelNode) error {
+	documents := map[*gostructurizr.ContainerNode][]*document{}
+	for _, s := range i.sources {
+		d, err := s.read(s.path)
+		if err != nil {
+			return err
+		}
+		documents[s.container] = append(documents[s.container], d)
+		attach(s.container, d)
+	}
+	index := elementIndex(m)
+	for _, path := range i.consumers {
+		var mapping map[string]map[string][]string
+		if err := readFile(path, &mapping); err != nil {
+			return err
+		}
+		if err := relate(index, documents, mapping); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// attach adds the endpoints of a document to the properties of its container, the first document
+// declaring an endpoint describing it
+func attach(c *gostructurizr.ContainerNode, d *document) {
+	properties := c.Properties()
+	if _, ok := properties.Properties[d.kind]; !ok {
+		properties.Add(d.kind, strings.TrimSpace(d.title+" "+d.version))
+	}
+	for _, e := range d.endpoints {
+		if _, ok := properties.Properties[e.name]; !ok {
+			properties.Add(e.name, e.description)
+		}
+	}
+}
+
+// consumer is implemented by every element using others
+type consumer interface {
+	gostructurizr.Namer
+	Uses(to gostructurizr.Namer, desc string) *gostructurizr.RelationShipNode
+}
+
+// elementIndex indexes the elements of the model by name and by path
+func elementIndex(m *gostructurizr.ModelNode) map[string][]consumer {
+	index := map[string][]consumer{}
+	add := func(e consumer, path string) {
+		index[e.Name()] = append(index[e.Name()], e)
+		if path != e.Name() {
+			index[path] = append(index[path], e)
+		}
+	}
+	for _, p := range m.Persons() {
+		add(p, p.Name())
+	}
+	for _, s := range m.SoftwareSystems() {
+		add(s, s.Name())
+		for _, c := range s.Containers() {
+			add(c, s.Name()+"/"+c.Name())
+			for _, co := range c.Components() {
+				add(co, s.Name()+"/"+c.Name()+"/"+co.Name())
+			}
+		}
+	}
+	return index
+}
+
+func lookup(index map[string][]consumer, name string) (consumer, error) {
+	switch matched := index[name]; len(matched) {
+	case 0:
+		return nil, fmt.Errorf("unknown element %q", name)
+	case 1:
+		return matched[0], nil
+	default:
+		return nil, fmt.Errorf("%q matches several elements, use the path of the element", name)
+	}
+}
+
+// relate adds the relationships of a mapping file, consumers and containers being taken in alphabetical order
+func relate(index map[string][]consumer, documents map[*gostructurizr.ContainerNode][]*document, mapping map[string]map[string][]string) error {
+	for _, consumerName := range sortedKeys(mapping) {
+		from, err := lookup(index, consumerName)
+		if err != nil {
+			return err
+		}
+		for _, providerName := range sortedKeys(mapping[consumerName]) {
+			to, err := lookup(index, providerName)
+			if err != nil {
+				return err
+			}
+			c, ok := to.(*gostructurizr.ContainerNode)
+			if !ok || len(documents[c]) == 0 {
+				return fmt.Errorf("%q has no API document", providerName)
+			}
+			if err := uses(from, c, documents[c], mapping[consumerName][providerName]); err != nil {
+				return fmt.Errorf("%s uses %s: %w", consumerName, providerName, err)
+			}
+		}
+	}
+	return nil
+}
+
+// usage gathers the endpoints of a document a consumer uses over a technology
+type usage struct {
+	document   *document
+	technology string
+	endpoints  []string
+}
+
+// uses relates from to the container providing the named endpoints, or all of its documents if none is named
+func uses(from consumer, to *gostructurizr.ContainerNode, documents []*document, names []string) error {
+	var usages []*usage
+	add := func(d *document, e endpoint, named bool) {
+		for _, u := range usages {
+			if u.document == d && u.technology == e.technology {
+				if named {
+					u.endpoints = append(u.endpoints, e.name)
+				}
+				return
+			}
+		}
+		u := &usage{document: d, technology: e.technology}
+		if named {
+			u.endpoints = append(u.endpoints, e.name)
+		}
+		usages = append(usages, u)
+	}
+	if len(names) == 0 {
+		for _, d := range documents {
+			for _, e := range d.endpoints {
+				add(d, e, false)
+			}
+		}
+	}
+	for _, name := range names {
+		found := false
+		for _, d := range documents {
+			if e, ok := d.endpoint(name); ok {
+				add(d, e, true)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown operation or channel %q", name)
+		}
+	}
+	for _, u := range usages {
+		desc := "Uses " + strings.Join(u.endpoints, ", ")
+		if len(u.endpoints) == 0 {
+			desc = strings.TrimSpace("Uses " + u.document.title)
+		}
+		r := from.Uses(to, desc)
+		if u.technology != "" {
+			r.WithTechnology(u.technology)
+		}
+		if u.document.kind == "openapi" {
+			r.WithInteractionStyle(gostructurizr.Synchronous)
+		} else {
+			r.WithInteractionStyle(gostructurizr.Asynchronous)
+		}
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,107 @@
+package apispec
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platelk/gostructurizr"
+	"github.com/platelk/gostructurizr/parser"
+	"github.com/platelk/gostructurizr/renderer"
+)
+
+type shop struct {
+	m        *gostructurizr.ModelNode
+	api      *gostructurizr.ContainerNode
+	payments *gostructurizr.ContainerNode
+}
+
+func newShop() shop {
+	m := gostructurizr.Model()
+	m.AddPerson("Customer", "")
+	system := m.AddSoftwareSystem("Shop", "")
+	system.AddContainer("Mailer", "", "Go")
+	system.AddContainer("Ledger", "", "Go")
+	return shop{
+		m:        m,
+		api:      system.AddContainer("API", "", "Go"),
+		payments: system.AddContainer("Payments", "", "Go"),
+	}
+}
+
+func relationships(m *gostructurizr.ModelNode) []string {
+	var all []string
+	for _, r := range m.RelationShip() {
+		all = append(all, r.From().Name()+" -> "+r.To().Name()+": "+*r.Description()+" ["+*r.Technology()+", "+string(*r.InteractionStyle())+"]")
+	}
+	return all
+}
+
+func TestImporter_Import(t *testing.T) {
+	s := newShop()
+
+	err := NewImporter().
+		WithOpenAPI(s.api, "testdata/openapi.yaml").
+		WithAsyncAPI(s.api, "testdata/asyncapi.yaml").
+		WithAsyncAPI(s.payments, "testdata/asyncapi-v3.yaml").
+		WithConsumers("testdata/consumers.yaml").
+		Import(s.m)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"openapi":          "Orders API 1.2.0",
+		"GET /orders":      "listOrders",
+		"POST /orders":     "Places an order",
+		"GET /orders/{id}": "Returns an order",
+		"asyncapi":         "Order events 1.0.0",
+		"orders/created":   "Orders placed by the customers",
+		"orders/shipped":   "Shipping notices",
+	}, s.api.Properties().Properties)
+	assert.Equal(t, map[string]string{
+		"asyncapi":          "Payment events",
+		"payments.received": "Payments of the orders",
+	}, s.payments.Properties().Properties)
+	assert.Equal(t, []string{
+		"Customer -> API: Uses GET /orders, POST /orders [HTTPS/JSON, synchronous]",
+		"Ledger -> Payments: Uses Payment events [amqp, asynchronous]",
+		"Mailer -> API: Uses orders/created [kafka, asynchronous]",
+		"Mailer -> API: Uses orders/shipped [mqtt, asynchronous]",
+	}, relationships(s.m))
+}
+
+func TestImporter_Import_roundTrip(t *testing.T) {
+	w := gostructurizr.Workspace()
+	api := w.Model().AddSoftwareSystem("Shop", "").AddContainer("API", "", "Go")
+	require.NoError(t, NewImporter().WithOpenAPI(api, "testdata/openapi.yaml").Import(w.Model()))
+
+	dsl := bytes.Buffer{}
+	require.NoError(t, renderer.NewDSLRenderer(&dsl).Render(w))
+	assert.Contains(t, dsl.String(), `"GET /orders" "listOrders"`)
+	parsed, err := parser.NewDSLParser(&dsl).Parse()
+	require.NoError(t, err)
+	assert.Equal(t, api.Properties().Properties, parsed.Model().SoftwareSystems()[0].Containers()[0].Properties().Properties)
+}
+
+func TestImporter_Import_errors(t *testing.T) {
+	tests := map[string]func(s shop) *Importer{
+		"missing document": func(s shop) *Importer {
+			return NewImporter().WithOpenAPI(s.api, "testdata/unknown.yaml")
+		},
+		"wrong kind of document": func(s shop) *Importer {
+			return NewImporter().WithOpenAPI(s.api, "testdata/asyncapi.yaml")
+		},
+		"unknown operation": func(s shop) *Importer {
+			return NewImporter().WithAsyncAPI(s.api, "testdata/asyncapi.yaml").WithConsumers("testdata/consumers.yaml")
+		},
+		"container without document": func(s shop) *Importer {
+			return NewImporter().WithOpenAPI(s.api, "testdata/openapi.yaml").WithConsumers("testdata/consumers.yaml")
+		},
+	}
+	for name, importer := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := newShop()
+			assert.Error(t, importer(s).Import(s.m))
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package apispec
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// methods are the operations of an OpenAPI path item, in the order the specification lists them
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// endpoint is an operation of an OpenAPI document or a channel of an AsyncAPI document
+type endpoint struct {
+	// name is the method and path of an operation, GET /orders, or the address of a channel
+	name        string
+	description string
+	technology  string
+}
+
+// document is what the importer keeps of an OpenAPI or AsyncAPI document
+type document struct {
+	path      string
+	kind      string
+	title     string
+	version   string
+	endpoints []endpoint
+}
+
+func (d *document) endpoint(name string) (endpoint, bool) {
+	for _, e := range d.endpoints {
+		if e.name == name {
+			return e, true
+		}
+	}
+	return endpoint{}, false
+}
+
+type info struct {
+	Title   string `yaml:"title"`
+	Version string `yaml:"version"`
+}
+
+// summary holds the fields describing an operation, the first one set being used
+type summary struct {
+	Summary     string `yaml:"summary"`
+	Title       string `yaml:"title"`
+	OperationID string `yaml:"operationId"`
+	Description string `yaml:"description"`
+}
+
+func (s *summary) String() string {
+	for _, v := range []string{s.Summary, s.Title, s.OperationID, s.Description} {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func readFile(path string, v interface{}) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := yaml.Unmarshal(content, v); err != nil {
+		return fmt.Errorf("can't read %s: %w", path, err)
+	}
+	return nil
+}
+
+// readOpenAPI reads the operations of an OpenAPI 3 document, in the order they are declared
+func readOpenAPI(path string) (*document, error) {
+	var doc struct {
+		OpenAPI string    `yaml:"openapi"`
+		Info    info      `yaml:"info"`
+		Paths   yaml.Node `yaml:"paths"`
+	}
+	if err := readFile(path, &doc); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("%s is not an OpenAPI 3 document", path)
+	}
+	d := &document{path: path, kind: "openapi", title: doc.Info.Title, version: doc.Info.Version}
+	err := eachEntry(doc.Paths, func(p string, item *yaml.Node) error {
+		// path items also hold parameters and servers, only operations are read
+		operations := map[string]summary{}
+		err := eachEntry(*item, func(key string, node *yaml.Node) error {
+			var operation summary
+			if !isMethod(key) {
+				return nil
+			}
+			if err := node.Decode(&operation); err != nil {
+				return fmt.Errorf("%s: can't read %s %s: %w", path, strings.ToUpper(key), p, err)
+			}
+			operations[key] = operation
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, method := range methods {
+			if operation, ok := operations[method]; ok {
+				d.endpoints = append(d.endpoints, endpoint{
+					name:        strings.ToUpper(method) + " " + p,
+					description: operation.String(),
+					technology:  HTTPTechnology,
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func isMethod(key string) bool {
+	for _, method := range methods {
+		if key == method {
+			return true
+		}
+	}
+	return false
+}
+
+type channel struct {
+	summary `yaml:",inline"`
+	// Address is the address of an AsyncAPI 3 channel, the channels of AsyncAPI 2 being keyed by theirs
+	Address   *string     `yaml:"address"`
+	Servers   []serverRef `yaml:"servers"`
+	Publish   *summary    `yaml:"publish"`
+	Subscribe *summary    `yaml:"subscribe"`
+}
+
+// description is the one of the channel, or of its first operation in AsyncAPI 2
+func (c *channel) description() string {
+	for _, operation := range []*summary{&c.summary, c.Publish, c.Subscribe} {
+		if operation != nil && operation.String() != "" {
+			return operation.String()
+		}
+	}
+	return ""
+}
+
+// serverRef reads the servers of a channel, given by name in AsyncAPI 2 and by reference in AsyncAPI 3
+type serverRef string
+
+func (s *serverRef) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*s = serverRef(node.Value)
+		return nil
+	}
+	var ref struct {
+		Ref string `yaml:"$ref"`
+	}
+	if err := node.Decode(&ref); err != nil {
+		return err
+	}
+	*s = serverRef(ref.Ref[strings.LastIndex(ref.Ref, "/")+1:])
+	return nil
+}
+
+// readAsyncAPI reads the channels of an AsyncAPI 2 or 3 document, in the order they are declared. The technology
+// of a channel is the protocol of its first server, or of the first server of the document.
+func readAsyncAPI(path string) (*document, error) {
+	var doc struct {
+		AsyncAPI string    `yaml:"asyncapi"`
+		Info     info      `yaml:"info"`
+		Servers  yaml.Node `yaml:"servers"`
+		Channels yaml.Node `yaml:"channels"`
+	}
+	if err := readFile(path, &doc); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(doc.AsyncAPI, "2.") && !strings.HasPrefix(doc.AsyncAPI, "3.") {
+		return nil, fmt.Errorf("%s is not an AsyncAPI 2 or 3 document", path)
+	}
+	var defaultProtocol string
+	protocols := map[string]string{}
+	err := eachEntry(doc.Servers, func(name string, node *yaml.Node) error {
+		var server struct {
+			Protocol string `yaml:"protocol"`
+		}
+		if err := node.Decode(&server); err != nil {
+			return fmt.Errorf("%s: can't read server %s: %w", path, name, err)
+		}
+		if defaultProtocol == "" {
+			defaultProtocol = server.Protocol
+		}
+		protocols[name] = server.Protocol
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	d := &document{path: path, kind: "asyncapi", title: doc.Info.Title, version: doc.Info.Version}
+	err = eachEntry(doc.Channels, func(name string, node *yaml.Node) error {
+		var c channel
+		if err := node.Decode(&c); err != nil {
+			return fmt.Errorf("%s: can't read channel %s: %w", path, name, err)
+		}
+		if c.Address != nil && *c.Address != "" {
+			name = *c.Address
+		}
+		protocol := defaultProtocol
+		if len(c.Servers) > 0 {
+			protocol = protocols[string(c.Servers[0])]
+		}
+		d.endpoints = append(d.endpoints, endpoint{name: name, description: c.description(), technology: protocol})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// eachEntry calls f with the entries of a mapping, in order
+func eachEntry(node yaml.Node, f func(key string, value *yaml.Node) error) error {
+	if node.Kind == 0 {
+		return nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: expected a mapping", node.Line)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if err := f(node.Content[i].Value, node.Content[i+1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+// Package compose imports a docker-compose file into a model, so local development stacks are documented
+// from the file running them:
+//
+//	f, _ := os.Open("docker-compose.yml")
+//	shop, err := compose.NewImporter(f).WithDeployment(gostructurizr.DevelopmentEnvironment).Import(model, "Shop")
+//
+// Each service becomes a container of the software system, its technology being the image it runs. Services use
+// the ones they depend on or link to. Networks aren't imported, being on a same network doesn't tell which service
+// calls the other. With a deployment environment, a deployment node holds a node per service running its container
+// instances, with the health checks of the service.
+package compose
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/platelk/gostructurizr"
+)
+
+// Technology is the technology of the containers built from a Dockerfile instead of an image,
+// and of the deployment nodes running them
+const Technology = "Docker"
+
+// Importer reads a docker-compose file
+type Importer struct {
+	reader      io.Reader
+	deployment  bool
+	environment gostructurizr.DeploymentEnvironment
+}
+
+func NewImporter(reader io.Reader) *Importer {
+	return &Importer{reader: reader}
+}
+
+// WithDeployment also builds the deployment nodes running the services, in environment
+func (i *Importer) WithDeployment(environment gostructurizr.DeploymentEnvironment) *Importer {
+	i.deployment = true
+	i.environment = environment
+	return i
+}
+
+// Import adds a software system named name to m, with a container per service of the compose file
+func (i *Importer) Import(m *gostructurizr.ModelNode, name string) (*gostructurizr.SoftwareSystemNode, error) {
+	var f file
+	if err := yaml.NewDecoder(i.reader).Decode(&f); err != nil {
+		return nil, fmt.Errorf("can't read compose file: %w", err)
+	}
+	services, err := f.services()
+	if err != nil {
+		return nil, err
+	}
+
+	system := m.AddSoftwareSystem(name, "")
+	containers := map[string]*gostructurizr.ContainerNode{}
+	for _, s := range services {
+		containers[s.name] = system.AddContainer(s.name, s.Labels["org.opencontainers.image.description"], s.technology())
+	}
+
+	related := map[[2]string]bool{}
+	uses := func(from, to, desc string) error {
+		if _, ok := containers[to]; !ok {
+			return fmt.Errorf("service %s references unknown service %q", from, to)
+		}
+		if from == to || related[[2]string{from, to}] {
+			return nil
+		}
+		related[[2]string{from, to}] = true
+		containers[from].Uses(containers[to], desc)
+		return nil
+	}
+	for _, s := range services {
+		for _, dependency := range s.DependsOn {
+			if err := uses(s.name, dependency, "Depends on"); err != nil {
+				return nil, err
+			}
+		}
+		for _, link := range s.Links {
+			service, _, _ := strings.Cut(link, ":")
+			if err := uses(s.name, service, "Links to"); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if i.deployment {
+		if err := i.importDeployment(m, f, services, containers); err != nil {
+			return nil, err
+		}
+	}
+	return system, nil
+}
+
+func (i *Importer) importDeployment(m *gostructurizr.ModelNode, f file, services []*service, containers map[string]*gostructurizr.ContainerNode) error {
+	name := f.Name
+	if name == "" {
+		name = "Docker Compose"
+	}
+	root := m.AddDeploymentNode(name, "", "Docker Compose", i.environment)
+	for _, s := range services {
+		nodeName := s.name
+		if s.ContainerName != "" {
+			nodeName = s.ContainerName
+		}
+		node := root.AddChildNode(nodeName, "", Technology)
+		replicas := 1
+		if s.Deploy.Replicas != nil {
+			replicas = *s.Deploy.Replicas
+		}
+		for id := 1; id <= replicas; id++ {
+			instance := node.AddContainerInstance(containers[s.name]).WithInstanceId(id)
+			if len(s.Ports) > 0 {
+				instance.Properties().Add("ports", strings.Join(s.Ports, ","))
+			}
+			if err := addHealthCheck(instance, s); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+var healthCheckURL = regexp.MustCompile(`https?://[^\s'"]+`)
+
+// addHealthCheck turns the healthcheck block of a service into a health check of its instance. The URL is the
+// one the test command calls, or the command itself when it doesn't call a URL.
+func addHealthCheck(instance *gostructurizr.ContainerInstanceNode, s *service) error {
+	h := s.HealthCheck
+	if h == nil || h.Disable || len(h.Test) == 0 || h.Test[0] == "NONE" {
+		return nil
+	}
+	test := h.Test
+	if test[0] == "CMD" || test[0] == "CMD-SHELL" {
+		test = test[1:]
+	}
+	command := strings.Join(test, " ")
+	url := healthCheckURL.FindString(command)
+	if url == "" {
+		url = command
+	}
+	check := instance.AddHealthCheck(s.name+" health check", url)
+	check.Properties().Add("test", command)
+	if h.Interval != "" {
+		interval, err := time.ParseDuration(h.Interval)
+		if err != nil {
+			return fmt.Errorf("service %s: invalid healthcheck interval: %w", s.name, err)
+		}
+		check.WithInterval(int(interval / time.Second))
+	}
+	if h.Timeout != "" {
+		timeout, err := time.ParseDuration(h.Timeout)
+		if err != nil {
+			return fmt.Errorf("service %s: invalid healthcheck timeout: %w", s.name, err)
+		}
+		check.WithTimeout(int(timeout / time.Millisecond))
+	}
+	if h.Retries != nil {
+		check.Properties().Add("retries", fmt.Sprint(*h.Retries))
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package compose
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platelk/gostructurizr"
+)
+
+const stack = `
+name: shop
+services:
+  web:
+    build: ./web
+    depends_on:
+      api:
+        condition: service_healthy
+    ports:
+      - "8080:80"
+    networks: [front]
+  api:
+    image: ghcr.io/acme/shop-api:1.2@sha256:0123
+    links:
+      - "db:database"
+    networks:
+      front:
+      back:
+    deploy:
+      replicas: 2
+    healthcheck:
+      test: ["CMD", "curl", "-f", "http://localhost:8080/health"]
+      interval: 1m30s
+      timeout: 500ms
+      retries: 3
+  db:
+    image: postgres:15
+    container_name: shop-db
+    networks: [back]
+    labels:
+      org.opencontainers.image.description: Stores the orders
+    healthcheck:
+      test: pg_isready -U shop
+  cache:
+    image: docker.io/library/redis
+    networks: [back]
+    healthcheck:
+      disable: true
+`
+
+func relationships(m *gostructurizr.ModelNode) []string {
+	var all []string
+	for _, r := range m.RelationShip() {
+		all = append(all, r.From().Name()+" -> "+r.To().Name()+": "+*r.Description())
+	}
+	return all
+}
+
+func TestImporter_Import(t *testing.T) {
+	m := gostructurizr.Model()
+
+	shop, err := NewImporter(strings.NewReader(stack)).Import(m, "Shop")
+	require.NoError(t, err)
+	require.Len(t, shop.Containers(), 4)
+	var technologies []string
+	for _, c := range shop.Containers() {
+		technologies = append(technologies, c.Name()+" "+*c.Technology())
+	}
+	assert.Equal(t, []string{"web Docker", "api acme/shop-api:1.2", "db postgres:15", "cache redis"}, technologies)
+	assert.Equal(t, "Stores the orders", *shop.Containers()[2].Description())
+	assert.Equal(t, []string{
+		"web -> api: Depends on",
+		"api -> db: Links to",
+	}, relationships(m))
+	assert.Empty(t, m.DeploymentNodes())
+}
+
+func TestImporter_Import_relationshipDirections(t *testing.T) {
+	m := gostructurizr.Model()
+	_, err := NewImporter(strings.NewReader(`
+services:
+  worker:
+    image: acme/worker
+    depends_on: [queue]
+    links: [queue]
+  queue:
+    image: rabbitmq
+    links: [worker]
+`)).Import(m, "Jobs")
+	require.NoError(t, err)
+	// the same pair is related once, the opposite direction is another relationship
+	assert.Equal(t, []string{
+		"worker -> queue: Depends on",
+		"queue -> worker: Links to",
+	}, relationships(m))
+}
+
+func TestImporter_WithDeployment(t *testing.T) {
+	m := gostructurizr.Model()
+
+	_, err := NewImporter(strings.NewReader(stack)).WithDeployment(gostructurizr.DevelopmentEnvironment).Import(m, "Shop")
+	require.NoError(t, err)
+	require.Len(t, m.DeploymentNodes(), 1)
+	root := m.DeploymentNodes()[0]
+	assert.Equal(t, "shop", root.Name())
+	assert.Equal(t, gostructurizr.DevelopmentEnvironment, root.Environment())
+	require.Len(t, root.Children(), 4)
+	assert.Equal(t, "shop-db", root.Children()[2].Name())
+
+	web := root.Children()[0].ContainerInstances()
+	require.Len(t, web, 1)
+	assert.Equal(t, "8080:80", web[0].Properties().Get("ports"))
+	assert.Empty(t, web[0].HealthChecks())
+
+	api := root.Children()[1].ContainerInstances()
+	require.Len(t, api, 2)
+	assert.Equal(t, 2, api[1].InstanceId())
+	require.Len(t, api[0].HealthChecks(), 1)
+	check := api[0].HealthChecks()[0]
+	assert.Equal(t, "http://localhost:8080/health", check.Url())
+	assert.Equal(t, 90, check.Interval())
+	assert.Equal(t, 500, check.Timeout())
+	assert.Equal(t, "3", check.Properties().Get("retries"))
+
+	db := root.Children()[2].ContainerInstances()[0]
+	require.Len(t, db.HealthChecks(), 1)
+	assert.Equal(t, "pg_isready -U shop", db.HealthChecks()[0].Url())
+
+	assert.Empty(t, root.Children()[3].ContainerInstances()[0].HealthChecks())
+}
+
+func TestImporter_listLabelsAndLongPorts(t *testing.T) {
+	m := gostructurizr.Model()
+	file := `
+services:
+  db:
+    image: postgres:15
+    labels:
+      - "org.opencontainers.image.description=Stores the orders"
+      - "com.example.team"
+    ports:
+      - target: 5432
+        published: 15432
+        host_ip: 127.0.0.1
+        protocol: tcp
+      - "9187"
+`
+
+	shop, err := NewImporter(strings.NewReader(file)).WithDeployment(gostructurizr.DevelopmentEnvironment).Import(m, "Shop")
+	require.NoError(t, err)
+	require.Len(t, shop.Containers(), 1)
+	assert.Equal(t, "Stores the orders", *shop.Containers()[0].Description())
+	instance := m.DeploymentNodes()[0].Children()[0].ContainerInstances()[0]
+	assert.Equal(t, "127.0.0.1:15432:5432/tcp,9187", instance.Properties().Get("ports"))
+}
+
+func TestImporter_Import_errors(t *testing.T) {
+	tests := map[string]string{
+		"invalid yaml":     "services: [",
+		"unknown service":  "services:\n  web:\n    depends_on: [api]\n",
+		"invalid interval": "services:\n  web:\n    healthcheck:\n      test: true\n      interval: soon\n",
+		"services list":    "services:\n  - web\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			importer := NewImporter(strings.NewReader(content)).WithDeployment(gostructurizr.DevelopmentEnvironment)
+			_, err := importer.Import(gostructurizr.Model(), "Shop")
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package compose
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// file is the part of a docker-compose file the importer reads
+type file struct {
+	Name     string    `yaml:"name"`
+	Services yaml.Node `yaml:"services"`
+}
+
+// services returns the services of the file, in the order they are declared
+func (f file) services() ([]*service, error) {
+	if f.Services.Kind == 0 {
+		return nil, nil
+	}
+	if f.Services.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: services should be a mapping", f.Services.Line)
+	}
+	var services []*service
+	for i := 0; i+1 < len(f.Services.Content); i += 2 {
+		s := &service{name: f.Services.Content[i].Value}
+		if err := f.Services.Content[i+1].Decode(s); err != nil {
+			return nil, fmt.Errorf("can't read service %s: %w", s.name, err)
+		}
+		services = append(services, s)
+	}
+	return services, nil
+}
+
+type service struct {
+	name          string
+	Image         string       `yaml:"image"`
+	ContainerName string       `yaml:"container_name"`
+	DependsOn     listOrMap    `yaml:"depends_on"`
+	Links         []string     `yaml:"links"`
+	Ports         ports        `yaml:"ports"`
+	Labels        labels       `yaml:"labels"`
+	HealthCheck   *healthCheck `yaml:"healthcheck"`
+	Deploy        struct {
+		Replicas *int `yaml:"replicas"`
+	} `yaml:"deploy"`
+}
+
+// technology is the image run by the service without its registry nor digest, postgres:15 for
+// docker.io/library/postgres:15, or Docker for the services built from a Dockerfile
+func (s *service) technology() string {
+	if s.Image == "" {
+		return Technology
+	}
+	image, _, _ := strings.Cut(s.Image, "@")
+	if registry, rest, ok := strings.Cut(image, "/"); ok && (strings.ContainsAny(registry, ".:") || registry == "localhost") {
+		image = rest
+	}
+	return strings.TrimPrefix(image, "library/")
+}
+
+type healthCheck struct {
+	Test     stringOrList `yaml:"test"`
+	Interval string       `yaml:"interval"`
+	Timeout  string       `yaml:"timeout"`
+	Retries  *int         `yaml:"retries"`
+	Disable  bool         `yaml:"disable"`
+}
+
+// stringOrList reads the commands given either as a string run by the shell or as a list
+type stringOrList []string
+
+func (s *stringOrList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*s = []string{"CMD-SHELL", node.Value}
+		return nil
+	}
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*s = list
+	return nil
+}
+
+// listOrMap reads the names given either as a list or as the keys of a mapping, in order
+type listOrMap []string
+
+func (l *listOrMap) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i < len(node.Content); i += 2 {
+			*l = append(*l, node.Content[i].Value)
+		}
+		return nil
+	}
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+// labels reads the labels given either as a mapping or as a list of key=value
+type labels map[string]string
+
+func (l *labels) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.MappingNode {
+		var m map[string]string
+		if err := node.Decode(&m); err != nil {
+			return err
+		}
+		*l = m
+		return nil
+	}
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*l = labels{}
+	for _, label := range list {
+		key, value, _ := strings.Cut(label, "=")
+		(*l)[key] = value
+	}
+	return nil
+}
+
+// ports reads the ports given either in the short syntax, 8080:80/tcp, or in the long one,
+// which is turned into the short syntax
+type ports []string
+
+func (p *ports) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.SequenceNode {
+		return fmt.Errorf("line %d: ports should be a list", node.Line)
+	}
+	for _, item := range node.Content {
+		if item.Kind != yaml.MappingNode {
+			*p = append(*p, item.Value)
+			continue
+		}
+		var port struct {
+			Target    string `yaml:"target"`
+			Published string `yaml:"published"`
+			HostIP    string `yaml:"host_ip"`
+			Protocol  string `yaml:"protocol"`
+		}
+		if err := item.Decode(&port); err != nil {
+			return err
+		}
+		short := port.Target
+		if port.Published != "" {
+			short = port.Published + ":" + short
+		}
+		if port.HostIP != "" {
+			short = port.HostIP + ":" + short
+		}
+		if port.Protocol != "" {
+			short += "/" + port.Protocol
+		}
+		*p = append(*p, short)
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+module github.com/platelk/gostructurizr/discovery
+
+go 1.22.0
+
+require (
+	github.com/platelk/gostructurizr v0.0.0
+	github.com/stretchr/testify v1.8.4
+	golang.org/x/tools v0.26.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/iancoleman/strcase v0.3.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/mod v0.21.0 // indirect
+	golang.org/x/sync v0.8.0 // indirect
+)
+
+replace github.com/platelk/gostructurizr => ../
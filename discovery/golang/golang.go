@@ -0,0 +1,172 @@
+// Package golang discovers the components of a container from its Go source code, so component views
+// follow the code instead of being maintained by hand:
+//
+//	api := shop.AddContainer("API", "Serves the shop", "Go")
+//	_, err := golang.New("./...").WithDir("services/api").
+//		WithRules(golang.CommentDirective(), golang.NamingConvention(regexp.MustCompile(`Service$`))).
+//		Discover(api)
+//
+// Packages are loaded with go/packages, rules then map packages or types to components. The components of a
+// package use the components of the packages it imports, directly or through packages without components.
+package golang
+
+import (
+	"fmt"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/platelk/gostructurizr"
+)
+
+// Technology is the technology of the discovered components
+const Technology = "Go"
+
+// Discoverer loads Go packages and maps them to components
+type Discoverer struct {
+	dir      string
+	patterns []string
+	rules    []Rule
+}
+
+// New discovers the components of the packages matching patterns, ./... when none is given
+func New(patterns ...string) *Discoverer {
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+	return &Discoverer{patterns: patterns}
+}
+
+// WithDir sets the directory the patterns are relative to, the current directory by default
+func (d *Discoverer) WithDir(dir string) *Discoverer {
+	d.dir = dir
+	return d
+}
+
+// WithRules adds rules mapping packages or types to components. Without rules, each package is a component.
+func (d *Discoverer) WithRules(rules ...Rule) *Discoverer {
+	d.rules = append(d.rules, rules...)
+	return d
+}
+
+// Discover adds the components found in the code to container, with the relationships derived from the imports
+// of their packages. Components already in the container with the same name are reused, as are their
+// relationships, two packages giving the same name to their components is an error. It returns the components
+// found, in package order.
+func (d *Discoverer) Discover(container *gostructurizr.ContainerNode) ([]*gostructurizr.ComponentNode, error) {
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedSyntax,
+		Dir:  d.dir,
+	}, d.patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("can't load packages: %w", err)
+	}
+	for _, p := range pkgs {
+		if len(p.Errors) > 0 {
+			return nil, fmt.Errorf("can't load package %s: %w", p.PkgPath, p.Errors[0])
+		}
+	}
+
+	rules := d.rules
+	if len(rules) == 0 {
+		rules = []Rule{RuleFunc(func(p *packages.Package) []Match {
+			return []Match{{Name: p.PkgPath, Description: synopsis(packageDoc(p))}}
+		})}
+	}
+	var found []*gostructurizr.ComponentNode
+	byPackage := map[string][]*gostructurizr.ComponentNode{}
+	// owners holds the package each component was found in, several rules may match the same one
+	owners := map[string]string{}
+	for _, p := range pkgs {
+		for _, rule := range rules {
+			for _, m := range rule.Match(p) {
+				if owner, ok := owners[m.Name]; ok {
+					if owner != p.PkgPath {
+						return nil, fmt.Errorf("component %q is found in packages %s and %s", m.Name, owner, p.PkgPath)
+					}
+					continue
+				}
+				owners[m.Name] = p.PkgPath
+				c := component(container, m)
+				found = append(found, c)
+				byPackage[p.PkgPath] = append(byPackage[p.PkgPath], c)
+			}
+		}
+	}
+
+	loaded := map[string]*packages.Package{}
+	for _, p := range pkgs {
+		loaded[p.PkgPath] = p
+	}
+	// components related in the model, by a previous discovery or by hand, are left as they are
+	related := map[[2]gostructurizr.Namer]bool{}
+	for _, r := range container.Parent().Model().RelationShip() {
+		related[[2]gostructurizr.Namer{r.From(), r.To()}] = true
+	}
+	for _, p := range pkgs {
+		for _, from := range byPackage[p.PkgPath] {
+			for _, path := range usedPackages(p, loaded, byPackage) {
+				for _, to := range byPackage[path] {
+					if related[[2]gostructurizr.Namer{from, to}] {
+						continue
+					}
+					related[[2]gostructurizr.Namer{from, to}] = true
+					from.Uses(to, "Uses")
+				}
+			}
+		}
+	}
+	return found, nil
+}
+
+// component returns the component of container named after m, adding it if needed
+func component(container *gostructurizr.ContainerNode, m Match) *gostructurizr.ComponentNode {
+	for _, c := range container.Components() {
+		if c.Name() == m.Name {
+			return c
+		}
+	}
+	c := container.AddComponent(m.Name).WithTechnology(Technology)
+	if m.Description != "" {
+		c.WithDesc(m.Description)
+	}
+	return c
+}
+
+// usedPackages returns the packages with components p imports, directly or through loaded packages without
+// components, in import order
+func usedPackages(p *packages.Package, loaded map[string]*packages.Package, byPackage map[string][]*gostructurizr.ComponentNode) []string {
+	var used []string
+	visited := map[string]bool{p.PkgPath: true}
+	var visit func(p *packages.Package)
+	visit = func(p *packages.Package) {
+		for _, path := range sortedImports(p) {
+			if visited[path] {
+				continue
+			}
+			visited[path] = true
+			if len(byPackage[path]) > 0 {
+				used = append(used, path)
+			} else if next, ok := loaded[path]; ok {
+				visit(next)
+			}
+		}
+	}
+	visit(p)
+	return used
+}
+
+// sortedImports returns the import paths of a package in the order they appear in its files
+func sortedImports(p *packages.Package) []string {
+	var paths []string
+	seen := map[string]bool{}
+	for _, f := range p.Syntax {
+		for _, spec := range f.Imports {
+			path := spec.Path.Value[1 : len(spec.Path.Value)-1]
+			if _, ok := p.Imports[path]; ok && !seen[path] {
+				seen[path] = true
+				paths = append(paths, path)
+			}
+		}
+	}
+	return paths
+}
@@ -0,0 +1,113 @@
+package golang
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/platelk/gostructurizr"
+)
+
+func newContainer() (*gostructurizr.ModelNode, *gostructurizr.ContainerNode) {
+	m := gostructurizr.Model()
+	return m, m.AddSoftwareSystem("Shop", "").AddContainer("API", "", "Go")
+}
+
+func names(components []*gostructurizr.ComponentNode) []string {
+	var all []string
+	for _, c := range components {
+		all = append(all, c.Name())
+	}
+	return all
+}
+
+func uses(m *gostructurizr.ModelNode) []string {
+	var all []string
+	for _, r := range m.RelationShip() {
+		all = append(all, r.From().Name()+" -> "+r.To().Name())
+	}
+	return all
+}
+
+func TestDiscoverer_Discover_packagePrefix(t *testing.T) {
+	m, api := newContainer()
+
+	components, err := New().WithDir("testdata/shop").WithRules(PackagePrefix("example.com/shop")).Discover(api)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"cmd/shop", "internal/store", "orders", "payments"}, names(components))
+	assert.Equal(t, components, api.Components())
+	orders := components[indexOf(names(components), "orders")]
+	assert.Equal(t, "Package orders takes the orders of the customers.", *orders.Description())
+	assert.Equal(t, Technology, *orders.Technology())
+	assert.ElementsMatch(t, []string{
+		"cmd/shop -> orders",
+		"orders -> internal/store",
+		"internal/store -> payments",
+	}, uses(m))
+}
+
+func TestDiscoverer_Discover_directiveAndNamingConvention(t *testing.T) {
+	m, api := newContainer()
+
+	components, err := New("./...").WithDir("testdata/shop").WithRules(
+		CommentDirective(),
+		NamingConvention(regexp.MustCompile(`(Service|Repository)$`)),
+	).Discover(api)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"OrderService", "OrderRepository", "Payment Gateway"}, names(components))
+	service := components[indexOf(names(components), "OrderService")]
+	assert.Equal(t, "OrderService places and tracks orders.", *service.Description())
+	// orders reaches payments through the store package, which has no component
+	assert.ElementsMatch(t, []string{
+		"OrderService -> Payment Gateway",
+		"OrderRepository -> Payment Gateway",
+	}, uses(m))
+}
+
+func TestDiscoverer_Discover_reusesComponents(t *testing.T) {
+	_, api := newContainer()
+	existing := api.AddComponent("payments").WithDesc("Hand written")
+
+	components, err := New().WithDir("testdata/shop").WithRules(PackagePrefix("example.com/shop")).Discover(api)
+	require.NoError(t, err)
+	assert.Len(t, api.Components(), 4)
+	assert.Contains(t, components, existing)
+	assert.Equal(t, "Hand written", *existing.Description())
+}
+
+func TestDiscoverer_Discover_twice(t *testing.T) {
+	m, api := newContainer()
+	discoverer := New().WithDir("testdata/shop").WithRules(PackagePrefix("example.com/shop"))
+
+	first, err := discoverer.Discover(api)
+	require.NoError(t, err)
+	relationships := uses(m)
+	second, err := discoverer.Discover(api)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Len(t, api.Components(), 4)
+	assert.Equal(t, relationships, uses(m))
+}
+
+func TestDiscoverer_Discover_errors(t *testing.T) {
+	_, api := newContainer()
+	_, err := New("./unknown").WithDir("testdata/shop").Discover(api)
+	assert.Error(t, err)
+
+	_, err = New().WithDir("testdata/shop").WithRules(RuleFunc(func(p *packages.Package) []Match {
+		return []Match{{Name: "Shop"}}
+	})).Discover(api)
+	assert.ErrorContains(t, err, `component "Shop" is found in packages`)
+}
+
+func indexOf(values []string, v string) int {
+	for i, each := range values {
+		if each == v {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,152 @@
+package golang
+
+import (
+	"go/ast"
+	"go/doc"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Directive is the comment marking a package or a type as a component, optionally followed by the
+// name of the component, quoted or not:
+//
+//	// OrderService places and tracks orders.
+//	//
+//	//structurizr:component "Order Service"
+//	type OrderService struct{}
+const Directive = "//structurizr:component"
+
+// Match is a component found by a rule
+type Match struct {
+	Name        string
+	Description string
+}
+
+// Rule finds the components of a package
+type Rule interface {
+	Match(p *packages.Package) []Match
+}
+
+// RuleFunc turns a function into a rule
+type RuleFunc func(p *packages.Package) []Match
+
+func (f RuleFunc) Match(p *packages.Package) []Match {
+	return f(p)
+}
+
+// PackagePrefix maps every package whose import path starts with prefix to a component, named by its
+// path relative to prefix, or by its name for the package at prefix itself
+func PackagePrefix(prefix string) Rule {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return RuleFunc(func(p *packages.Package) []Match {
+		var name string
+		switch {
+		case p.PkgPath == prefix:
+			name = p.Name
+		case strings.HasPrefix(p.PkgPath, prefix+"/"):
+			name = strings.TrimPrefix(p.PkgPath, prefix+"/")
+		default:
+			return nil
+		}
+		return []Match{{Name: name, Description: synopsis(packageDoc(p))}}
+	})
+}
+
+// NamingConvention maps the exported types whose name matches pattern to components, `(Service|Repository)$`
+// mapping OrderService and OrderRepository
+func NamingConvention(pattern *regexp.Regexp) Rule {
+	return RuleFunc(func(p *packages.Package) []Match {
+		var matches []Match
+		eachType(p, func(spec *ast.TypeSpec, comments *ast.CommentGroup) {
+			if spec.Name.IsExported() && pattern.MatchString(spec.Name.Name) {
+				matches = append(matches, Match{Name: spec.Name.Name, Description: synopsis(comments)})
+			}
+		})
+		return matches
+	})
+}
+
+// CommentDirective maps the packages and the types documented with the //structurizr:component directive
+// to components, named by the directive or after the package or type otherwise
+func CommentDirective() Rule {
+	return RuleFunc(func(p *packages.Package) []Match {
+		var matches []Match
+		if name, ok := directive(packageDoc(p)); ok {
+			if name == "" {
+				name = p.Name
+			}
+			matches = append(matches, Match{Name: name, Description: synopsis(packageDoc(p))})
+		}
+		eachType(p, func(spec *ast.TypeSpec, comments *ast.CommentGroup) {
+			if name, ok := directive(comments); ok {
+				if name == "" {
+					name = spec.Name.Name
+				}
+				matches = append(matches, Match{Name: name, Description: synopsis(comments)})
+			}
+		})
+		return matches
+	})
+}
+
+// directive returns the name given by the component directive of a doc comment, if there is one
+func directive(comments *ast.CommentGroup) (string, bool) {
+	if comments == nil {
+		return "", false
+	}
+	for _, c := range comments.List {
+		rest, ok := strings.CutPrefix(c.Text, Directive)
+		if !ok || (rest != "" && rest[0] != ' ' && rest[0] != '\t') {
+			continue
+		}
+		name := strings.TrimSpace(rest)
+		if unquoted, err := strconv.Unquote(name); err == nil {
+			name = unquoted
+		}
+		return name, true
+	}
+	return "", false
+}
+
+// packageDoc returns the doc comment of a package, from the first file having one
+func packageDoc(p *packages.Package) *ast.CommentGroup {
+	for _, f := range p.Syntax {
+		if f.Doc != nil {
+			return f.Doc
+		}
+	}
+	return nil
+}
+
+// eachType visits the types declared by a package with their doc comment
+func eachType(p *packages.Package, visit func(spec *ast.TypeSpec, comments *ast.CommentGroup)) {
+	for _, f := range p.Syntax {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				spec := spec.(*ast.TypeSpec)
+				comments := spec.Doc
+				// the doc comment of a single type declaration is the one of the declaration
+				if comments == nil && len(gen.Specs) == 1 {
+					comments = gen.Doc
+				}
+				visit(spec, comments)
+			}
+		}
+	}
+}
+
+// synopsis returns the first sentence of a doc comment, directives left out
+func synopsis(comments *ast.CommentGroup) string {
+	if comments == nil {
+		return ""
+	}
+	return new(doc.Package).Synopsis(comments.Text())
+}
@@ -0,0 +1,7 @@
+package main
+
+import "example.com/shop/orders"
+
+var _ orders.OrderService
+
+func main() {}
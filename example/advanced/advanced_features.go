@@ -182,10 +182,10 @@ func main() {
 		WithFontSize(24)
 	
 	// Advanced relationship styling
-	syncStyle := styles.AddAdvancedRelationshipStyle(tags.Synchronous)
+	syncStyle := styles.AddRelationshipStyle(tags.Synchronous)
 	syncStyle.WithDashed().
 		WithColor("#ff0000").
-		WithWidth(2).
+		WithThickness(2).
 		WithFontSize(12).
 		WithFontColor("#ff0000").
 		WithArrow()
@@ -110,38 +110,38 @@ func main() {
 
 	// Sync style
 	syncTag := tags.Tag("Sync")
-	styles.AddAdvancedRelationshipStyle(syncTag).
+	styles.AddRelationshipStyle(syncTag).
 		WithColor("#289CE1").
 		WithFontColor("#289CE1").
 		WithFontSize(12).
-		WithWidth(2).
+		WithThickness(2).
 		WithLineStyle(strukt.SolidLine).
 		WithDirectRouting()
 
 	// Async style
 	asyncTag := tags.Tag("Async")
-	styles.AddAdvancedRelationshipStyle(asyncTag).
+	styles.AddRelationshipStyle(asyncTag).
 		WithColor("#E62D2D").
 		WithFontColor("#E62D2D").
 		WithFontSize(12).
-		WithWidth(2).
+		WithThickness(2).
 		WithDashed().
 		WithCurvedRouting()
 
 	// Cache style
 	cacheTag := tags.Tag("Cache")
-	styles.AddAdvancedRelationshipStyle(cacheTag).
+	styles.AddRelationshipStyle(cacheTag).
 		WithColor("#D4A017").
 		WithFontColor("#D4A017").
-		WithWidth(2).
+		WithThickness(2).
 		WithDotted().
 		WithEndTerminator(strukt.Arrow)
 
 	// Database style
 	dbTag := tags.Tag("Database")
-	styles.AddAdvancedRelationshipStyle(dbTag).
+	styles.AddRelationshipStyle(dbTag).
 		WithColor("#1168BD").
-		WithWidth(2).
+		WithThickness(2).
 		WithOrthogonalRouting()
 
 	// Write the DSL to stdout
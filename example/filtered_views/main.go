@@ -20,7 +20,7 @@ func main() {
 	model := workspace.Model()
 
 	// Create enterprise boundary
-	model.SetEnterprise("Example Corp")
+	enterprise := model.SetEnterprise("Example Corp")
 
 	// Create users and systems
 	customerA := model.AddPerson("Customer A", "A premium customer")
@@ -41,20 +41,20 @@ func main() {
 
 	// Create internal systems
 	webApp := model.AddSoftwareSystem("Web Application", "The main web application")
-	webApp.Tags().Add("Internal")
+	enterprise.Add(webApp)
 	webApp.Tags().Add("WebApp")
 	
 	customerDB := model.AddSoftwareSystem("Customer Database", "Stores customer information")
-	customerDB.Tags().Add("Internal")
+	enterprise.Add(customerDB)
 	customerDB.Tags().Add("Database")
 	customerDB.Tags().Add("Critical")
 	
 	reportingSystem := model.AddSoftwareSystem("Reporting System", "Generates business reports")
-	reportingSystem.Tags().Add("Internal")
+	enterprise.Add(reportingSystem)
 	reportingSystem.Tags().Add("Reporting")
 	
 	adminPortal := model.AddSoftwareSystem("Admin Portal", "Admin management interface")
-	adminPortal.Tags().Add("Internal")
+	enterprise.Add(adminPortal)
 	adminPortal.Tags().Add("AdminTool")
 
 	// Create external systems
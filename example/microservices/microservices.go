@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/platelk/gostructurizr"
 	"github.com/platelk/gostructurizr/renderer"
-	"github.com/platelk/gostructurizr/routing"
 	"github.com/platelk/gostructurizr/shapes"
 	"github.com/platelk/gostructurizr/tags"
 	"strings"
@@ -88,7 +87,7 @@ func main() {
 	styles.AddElementStyle(messageBusTag).WithWidth(1600).WithShape(shapes.Pipe)
 	styles.AddElementStyle(microserviceTag).WithShape(shapes.Hexagon)
 	styles.AddElementStyle(datastoreTag).WithBackground("#f5da81").WithShape(shapes.Cylinder)
-	styles.AddRelationshipStyle(tags.RelationShip).WithRouting(routing.Orthogonal)
+	styles.AddRelationshipStyle(tags.RelationShip).WithOrthogonalRouting()
 
 	styles.AddRelationshipStyle(tags.Asynchronous).WithDash(true)
 	styles.AddRelationshipStyle(tags.Synchronous).WithDash(false)
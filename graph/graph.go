@@ -0,0 +1,307 @@
+// Package graph turns a model into a graph, for the queries hand-written loops over RelationShip() make tedious:
+// what depends on an element, transitively, how two elements are connected, and which elements form cycles.
+//
+// Relationships are the edges of the graph. Containment (software system -> container -> component,
+// deployment node -> child node, infrastructure node and container instance) is kept aside, and taken into
+// account by the impact queries: using a component is using its container, and what depends on a container
+// depends on its components.
+package graph
+
+import (
+	"sort"
+
+	"github.com/platelk/gostructurizr"
+)
+
+// Graph indexes the elements and relationships of a model. It is a snapshot: changes made to the
+// model after New aren't seen.
+type Graph struct {
+	elements []gostructurizr.Namer
+	order    map[gostructurizr.Namer]int
+	outgoing map[gostructurizr.Namer][]*gostructurizr.RelationShipNode
+	incoming map[gostructurizr.Namer][]*gostructurizr.RelationShipNode
+	parent   map[gostructurizr.Namer]gostructurizr.Namer
+	children map[gostructurizr.Namer][]gostructurizr.Namer
+}
+
+// New builds the graph of a model
+func New(m *gostructurizr.ModelNode) *Graph {
+	g := &Graph{
+		order:    map[gostructurizr.Namer]int{},
+		outgoing: map[gostructurizr.Namer][]*gostructurizr.RelationShipNode{},
+		incoming: map[gostructurizr.Namer][]*gostructurizr.RelationShipNode{},
+		parent:   map[gostructurizr.Namer]gostructurizr.Namer{},
+		children: map[gostructurizr.Namer][]gostructurizr.Namer{},
+	}
+	for _, p := range m.Persons() {
+		g.add(p, nil)
+	}
+	for _, s := range m.SoftwareSystems() {
+		g.add(s, nil)
+		for _, c := range s.Containers() {
+			g.add(c, s)
+			for _, component := range c.Components() {
+				g.add(component, c)
+			}
+		}
+	}
+	for _, d := range m.DeploymentNodes() {
+		g.addDeploymentNode(d, nil)
+	}
+	for _, r := range m.RelationShip() {
+		// relationships may reference elements outside of the model, they are still part of the graph
+		g.add(r.From(), nil)
+		g.add(r.To(), nil)
+		g.outgoing[r.From()] = append(g.outgoing[r.From()], r)
+		g.incoming[r.To()] = append(g.incoming[r.To()], r)
+	}
+	return g
+}
+
+func (g *Graph) add(n, parent gostructurizr.Namer) {
+	if _, ok := g.order[n]; ok {
+		return
+	}
+	g.order[n] = len(g.elements)
+	g.elements = append(g.elements, n)
+	if parent != nil {
+		g.parent[n] = parent
+		g.children[parent] = append(g.children[parent], n)
+	}
+}
+
+func (g *Graph) addDeploymentNode(d *gostructurizr.DeploymentNodeNode, parent gostructurizr.Namer) {
+	g.add(d, parent)
+	for _, child := range d.Children() {
+		g.addDeploymentNode(child, d)
+	}
+	for _, infra := range d.InfrastructureNodes() {
+		g.add(infra, d)
+	}
+	for _, instance := range d.ContainerInstances() {
+		g.add(instance, d)
+	}
+}
+
+// Elements returns the elements of the graph, in model order
+func (g *Graph) Elements() []gostructurizr.Namer {
+	return g.elements
+}
+
+// Outgoing returns the relationships n is the source of
+func (g *Graph) Outgoing(n gostructurizr.Namer) []*gostructurizr.RelationShipNode {
+	return g.outgoing[n]
+}
+
+// Incoming returns the relationships n is the destination of
+func (g *Graph) Incoming(n gostructurizr.Namer) []*gostructurizr.RelationShipNode {
+	return g.incoming[n]
+}
+
+// Parent returns the element containing n, nil for the top level elements
+func (g *Graph) Parent(n gostructurizr.Namer) gostructurizr.Namer {
+	return g.parent[n]
+}
+
+// Children returns the elements directly contained by n
+func (g *Graph) Children(n gostructurizr.Namer) []gostructurizr.Namer {
+	return g.children[n]
+}
+
+// Ancestors returns the elements containing n, closest first
+func (g *Graph) Ancestors(n gostructurizr.Namer) []gostructurizr.Namer {
+	var ancestors []gostructurizr.Namer
+	for p := g.parent[n]; p != nil; p = g.parent[p] {
+		ancestors = append(ancestors, p)
+	}
+	return ancestors
+}
+
+// Descendants returns the elements contained by n at any depth, in model order
+func (g *Graph) Descendants(n gostructurizr.Namer) []gostructurizr.Namer {
+	var descendants []gostructurizr.Namer
+	for _, child := range g.children[n] {
+		descendants = append(descendants, child)
+		descendants = append(descendants, g.Descendants(child)...)
+	}
+	return g.sorted(descendants)
+}
+
+// contains reports whether n is ancestor or is contained by it, at any depth
+func (g *Graph) contains(ancestor, n gostructurizr.Namer) bool {
+	for e := n; e != nil; e = g.parent[e] {
+		if e == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// Dependents returns the elements depending on n, transitively: the sources of the relationships to n
+// or to one of its descendants, the elements containing them, and so on. Elements containing n aren't
+// part of the result. Impact analysis starts here: these are the elements a change to n may break.
+func (g *Graph) Dependents(n gostructurizr.Namer) []gostructurizr.Namer {
+	return g.closure(n, g.Incoming, (*gostructurizr.RelationShipNode).From)
+}
+
+// Dependencies returns the elements n depends on, transitively: the destinations of the relationships from n
+// or from one of its descendants, the elements containing them, and so on. Elements containing n aren't
+// part of the result.
+func (g *Graph) Dependencies(n gostructurizr.Namer) []gostructurizr.Namer {
+	return g.closure(n, g.Outgoing, (*gostructurizr.RelationShipNode).To)
+}
+
+// closure walks the relationships returned by edges from start and its descendants, then from each element
+// reached, other being the end of a relationship which isn't the element walked from
+func (g *Graph) closure(start gostructurizr.Namer, edges func(gostructurizr.Namer) []*gostructurizr.RelationShipNode,
+	other func(*gostructurizr.RelationShipNode) gostructurizr.Namer) []gostructurizr.Namer {
+	visited := map[gostructurizr.Namer]bool{start: true}
+	var reached []gostructurizr.Namer
+	queue := []gostructurizr.Namer{start}
+	for len(queue) > 0 {
+		e := queue[0]
+		queue = queue[1:]
+		for _, walked := range append([]gostructurizr.Namer{e}, g.Descendants(e)...) {
+			for _, r := range edges(walked) {
+				next := other(r)
+				// elements reached are lifted to their ancestors, a container using an element through one of its components
+				for _, candidate := range append([]gostructurizr.Namer{next}, g.Ancestors(next)...) {
+					if visited[candidate] || g.contains(candidate, start) || g.contains(start, candidate) {
+						continue
+					}
+					visited[candidate] = true
+					reached = append(reached, candidate)
+					queue = append(queue, candidate)
+				}
+			}
+		}
+	}
+	return g.sorted(reached)
+}
+
+// ShortestPath returns the relationships leading from one element to the other with the fewest steps,
+// following relationships only. It returns false when to can't be reached from from.
+func (g *Graph) ShortestPath(from, to gostructurizr.Namer) ([]*gostructurizr.RelationShipNode, bool) {
+	if from == to {
+		return nil, true
+	}
+	via := map[gostructurizr.Namer]*gostructurizr.RelationShipNode{}
+	visited := map[gostructurizr.Namer]bool{from: true}
+	queue := []gostructurizr.Namer{from}
+	for len(queue) > 0 {
+		e := queue[0]
+		queue = queue[1:]
+		for _, r := range g.outgoing[e] {
+			if visited[r.To()] {
+				continue
+			}
+			visited[r.To()] = true
+			via[r.To()] = r
+			if r.To() == to {
+				var path []*gostructurizr.RelationShipNode
+				for n := to; n != from; n = via[n].From() {
+					path = append([]*gostructurizr.RelationShipNode{via[n]}, path...)
+				}
+				return path, true
+			}
+			queue = append(queue, r.To())
+		}
+	}
+	return nil, false
+}
+
+// StronglyConnectedComponents returns the groups of elements which can all reach each other following
+// relationships, each element being in exactly one group. Groups are sorted by their first element, in model order.
+func (g *Graph) StronglyConnectedComponents() [][]gostructurizr.Namer {
+	t := &tarjan{
+		graph:   g,
+		index:   map[gostructurizr.Namer]int{},
+		low:     map[gostructurizr.Namer]int{},
+		onStack: map[gostructurizr.Namer]bool{},
+	}
+	for _, e := range g.elements {
+		if _, ok := t.index[e]; !ok {
+			t.connect(e)
+		}
+	}
+	for i := range t.components {
+		t.components[i] = g.sorted(t.components[i])
+	}
+	sort.SliceStable(t.components, func(i, j int) bool {
+		return g.order[t.components[i][0]] < g.order[t.components[j][0]]
+	})
+	return t.components
+}
+
+// Cycles returns the strongly connected components forming a cycle: the ones with more than an element,
+// and the elements using themselves
+func (g *Graph) Cycles() [][]gostructurizr.Namer {
+	var cycles [][]gostructurizr.Namer
+	for _, c := range g.StronglyConnectedComponents() {
+		if len(c) > 1 || g.usesItself(c[0]) {
+			cycles = append(cycles, c)
+		}
+	}
+	return cycles
+}
+
+func (g *Graph) usesItself(n gostructurizr.Namer) bool {
+	for _, r := range g.outgoing[n] {
+		if r.To() == n {
+			return true
+		}
+	}
+	return false
+}
+
+// sorted puts elements in model order
+func (g *Graph) sorted(elements []gostructurizr.Namer) []gostructurizr.Namer {
+	sort.SliceStable(elements, func(i, j int) bool {
+		return g.order[elements[i]] < g.order[elements[j]]
+	})
+	return elements
+}
+
+// tarjan holds the state of Tarjan's strongly connected components algorithm
+type tarjan struct {
+	graph      *Graph
+	next       int
+	index      map[gostructurizr.Namer]int
+	low        map[gostructurizr.Namer]int
+	stack      []gostructurizr.Namer
+	onStack    map[gostructurizr.Namer]bool
+	components [][]gostructurizr.Namer
+}
+
+func (t *tarjan) connect(n gostructurizr.Namer) {
+	t.index[n] = t.next
+	t.low[n] = t.next
+	t.next++
+	t.stack = append(t.stack, n)
+	t.onStack[n] = true
+	for _, r := range t.graph.outgoing[n] {
+		to := r.To()
+		if _, ok := t.index[to]; !ok {
+			t.connect(to)
+			if t.low[to] < t.low[n] {
+				t.low[n] = t.low[to]
+			}
+		} else if t.onStack[to] && t.index[to] < t.low[n] {
+			t.low[n] = t.index[to]
+		}
+	}
+	if t.low[n] != t.index[n] {
+		return
+	}
+	var component []gostructurizr.Namer
+	for {
+		top := t.stack[len(t.stack)-1]
+		t.stack = t.stack[:len(t.stack)-1]
+		t.onStack[top] = false
+		component = append(component, top)
+		if top == n {
+			break
+		}
+	}
+	t.components = append(t.components, component)
+}
@@ -0,0 +1,62 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platelk/gostructurizr"
+)
+
+func names(elements []gostructurizr.Namer) []string {
+	var n []string
+	for _, e := range elements {
+		n = append(n, e.Name())
+	}
+	return n
+}
+
+func TestGraph(t *testing.T) {
+	m := gostructurizr.Model()
+	customer := m.AddPerson("Customer", "")
+	shop := m.AddSoftwareSystem("Shop", "")
+	web := shop.AddContainer("Web", "", "")
+	checkout := web.AddComponent("Checkout")
+	api := shop.AddContainer("API", "", "")
+	payments := m.AddSoftwareSystem("Payments", "")
+	gateway := payments.AddContainer("Gateway", "", "")
+	ledger := m.AddSoftwareSystem("Ledger", "")
+	server := m.AddProdNode("Server", "", "")
+	instance := server.AddContainerInstance(gateway)
+
+	visits := customer.Uses(web, "Visits")
+	calls := web.Uses(api, "Calls")
+	checkout.Uses(gateway, "Charges")
+	records := gateway.Uses(ledger, "Records")
+	ledger.Uses(gateway, "Reconciles")
+
+	g := New(m)
+	assert.Equal(t, []*gostructurizr.RelationShipNode{calls}, g.Outgoing(web))
+	assert.Equal(t, []*gostructurizr.RelationShipNode{visits}, g.Incoming(web))
+	assert.Equal(t, shop, g.Parent(checkout).(*gostructurizr.ContainerNode).Parent())
+	assert.Equal(t, []gostructurizr.Namer{web, api}, g.Children(shop))
+	assert.Equal(t, []gostructurizr.Namer{web, shop}, g.Ancestors(checkout))
+	assert.Equal(t, []gostructurizr.Namer{web, checkout, api}, g.Descendants(shop))
+	assert.Equal(t, []gostructurizr.Namer{instance}, g.Children(server))
+
+	// Checkout uses the Gateway of Payments: Web and Shop depend on Payments through it, and so does the Customer
+	assert.Equal(t, []string{"Customer", "Shop", "Web", "Checkout", "Ledger"}, names(g.Dependents(payments)))
+	assert.Equal(t, []string{"Payments", "Gateway", "Ledger"}, names(g.Dependencies(checkout)))
+
+	path, ok := g.ShortestPath(web, ledger)
+	assert.False(t, ok)
+	assert.Nil(t, path)
+	path, ok = g.ShortestPath(gateway, ledger)
+	require.True(t, ok)
+	assert.Equal(t, []*gostructurizr.RelationShipNode{records}, path)
+
+	require.Len(t, g.Cycles(), 1)
+	assert.Equal(t, []string{"Gateway", "Ledger"}, names(g.Cycles()[0]))
+	assert.Len(t, g.StronglyConnectedComponents(), len(g.Elements())-1)
+}
@@ -1,9 +1,126 @@
 package gostructurizr
 
-type GroupNode[K any] struct {
-	value K
+import "github.com/platelk/gostructurizr/tags"
+
+// GroupSeparator separates the names of nested groups in their full name, Sales/EMEA for the EMEA group of Sales.
+// It is the structurizr.groupSeparator property of the models having nested groups.
+const GroupSeparator = "/"
+
+// LandscapeElement is implemented by people and software systems, the elements the model groups
+type LandscapeElement interface {
+	Namer
+	landscapeElement()
+}
+
+// GroupNode is a named group of elements, drawn as a boundary around them. The model groups people and
+// software systems, software systems group their containers and containers their components.
+// An element belongs to a single group.
+type GroupNode[K Namer] struct {
+	name    string
+	parent  *GroupNode[K]
+	groups  []*GroupNode[K]
+	members []K
+	// top holds the top level groups of the owner of the group, nil for groups created on their own
+	top *[]*GroupNode[K]
+}
+
+func Group[K Namer](name string) *GroupNode[K] {
+	return &GroupNode[K]{name: name}
+}
+
+func (g *GroupNode[K]) Name() string {
+	return g.name
+}
+
+// FullName returns the names of the enclosing groups and of the group, separated by GroupSeparator
+func (g *GroupNode[K]) FullName() string {
+	if g.parent == nil {
+		return g.name
+	}
+	return g.parent.FullName() + GroupSeparator + g.name
+}
+
+// Tag returns the tag element styles target the group with, the Group tag targeting every group
+func (g *GroupNode[K]) Tag() tags.Tag {
+	return tags.Tag(tags.Group.String() + ":" + g.FullName())
+}
+
+// Parent returns the group enclosing this one, nil for top level groups
+func (g *GroupNode[K]) Parent() *GroupNode[K] {
+	return g.parent
+}
+
+// AddGroup adds a group nested in this one
+func (g *GroupNode[K]) AddGroup(name string) *GroupNode[K] {
+	child := Group[K](name)
+	child.parent = g
+	child.top = g.top
+	g.groups = append(g.groups, child)
+	return child
+}
+
+func (g *GroupNode[K]) Groups() []*GroupNode[K] {
+	return g.groups
+}
+
+// Add adds elements to the group, moving the ones already in another group of the same owner
+func (g *GroupNode[K]) Add(members ...K) *GroupNode[K] {
+	for _, member := range members {
+		if current := groupOf(g.siblings(), member); current != nil {
+			current.remove(member)
+		}
+		g.members = append(g.members, member)
+	}
+	return g
+}
+
+// siblings returns the top level groups of the owner of the group, or its outermost group when it has no owner
+func (g *GroupNode[K]) siblings() []*GroupNode[K] {
+	if g.top != nil {
+		return *g.top
+	}
+	root := g
+	for root.parent != nil {
+		root = root.parent
+	}
+	return []*GroupNode[K]{root}
+}
+
+func (g *GroupNode[K]) remove(member K) {
+	for i, m := range g.members {
+		if Namer(m) == Namer(member) {
+			g.members = append(g.members[:i:i], g.members[i+1:]...)
+			return
+		}
+	}
+}
+
+// Members returns the elements of the group, the ones of its nested groups aside
+func (g *GroupNode[K]) Members() []K {
+	return g.members
+}
+
+// groupOf returns the innermost group holding e, nil if e is in none of groups
+func groupOf[K Namer](groups []*GroupNode[K], e K) *GroupNode[K] {
+	for _, g := range groups {
+		for _, member := range g.members {
+			if Namer(member) == Namer(e) {
+				return g
+			}
+		}
+		if found := groupOf(g.groups, e); found != nil {
+			return found
+		}
+	}
+	return nil
 }
 
-func Group[K any]() *GroupNode[K] {
-	return &GroupNode[K]{}
+// hasNestedGroups reports whether a group of groups holds another one
+func hasNestedGroups[K Namer](groups []*GroupNode[K]) bool {
+	for _, g := range groups {
+		if len(g.groups) > 0 {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,68 @@
+package gostructurizr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModelNode_AddGroup(t *testing.T) {
+	m := Model()
+	customer := m.AddPerson("Customer", "")
+	crm := m.AddSoftwareSystem("CRM", "")
+	billing := m.AddSoftwareSystem("Billing", "")
+
+	sales := m.AddGroup("Sales").Add(customer, crm)
+	emea := sales.AddGroup("EMEA").Add(billing)
+
+	assert.Equal(t, []*GroupNode[LandscapeElement]{sales}, m.Groups())
+	assert.Equal(t, []LandscapeElement{customer, crm}, sales.Members())
+	assert.Equal(t, sales, m.GroupOf(crm))
+	assert.Equal(t, emea, m.GroupOf(billing))
+	assert.Nil(t, m.GroupOf(m.AddPerson("Admin", "")))
+	assert.Equal(t, "Sales/EMEA", emea.FullName())
+	assert.Equal(t, sales, emea.Parent())
+	assert.Equal(t, "Group:Sales/EMEA", emea.Tag().String())
+	assert.True(t, m.HasNestedGroups())
+}
+
+func TestSoftwareSystemNode_AddGroup(t *testing.T) {
+	m := Model()
+	shop := m.AddSoftwareSystem("Shop", "")
+	web := shop.AddContainer("Web", "", "")
+	api := shop.AddContainer("API", "", "")
+	orders := api.AddComponent("Orders")
+
+	frontend := shop.AddGroup("Frontend").Add(web)
+	domain := api.AddGroup("Domain").Add(orders)
+
+	assert.Equal(t, frontend, shop.GroupOf(web))
+	assert.Nil(t, shop.GroupOf(api))
+	assert.Equal(t, domain, api.GroupOf(orders))
+	assert.False(t, m.HasNestedGroups())
+
+	domain.AddGroup("Ordering")
+	assert.True(t, m.HasNestedGroups())
+}
+
+func TestGroupNode_Add_moves(t *testing.T) {
+	m := Model()
+	customer := m.AddPerson("Customer", "")
+	crm := m.AddSoftwareSystem("CRM", "")
+	sales := m.AddGroup("Sales").Add(customer, crm)
+	emea := sales.AddGroup("EMEA")
+	support := m.AddGroup("Support")
+
+	support.Add(customer)
+	emea.Add(crm)
+	sales.Add(crm)
+	assert.Empty(t, emea.Members())
+	assert.Equal(t, []LandscapeElement{crm}, sales.Members())
+	assert.Equal(t, support, m.GroupOf(customer))
+
+	shop := m.AddSoftwareSystem("Shop", "")
+	web := shop.AddContainer("Web", "", "")
+	frontend := shop.AddGroup("Frontend").Add(web)
+	shop.AddGroup("Edge").Add(web)
+	assert.Empty(t, frontend.Members())
+}
@@ -0,0 +1,191 @@
+package gostructurizr
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/iancoleman/strcase"
+)
+
+// IdentifiersNode assigns the identifiers the elements of a workspace are referenced by in the DSL.
+// Identifiers are generated from the names of the elements, unless one is pinned, and made unique
+// by suffixing a number, so "Order Service" and "Order-Service" don't collapse to the same identifier.
+type IdentifiersNode struct {
+	hierarchical bool
+	pinned       map[Namer]string
+}
+
+func identifiers() *IdentifiersNode {
+	return &IdentifiersNode{
+		pinned: map[Namer]string{},
+	}
+}
+
+// WithHierarchical switches to `!identifiers hierarchical`, where containers and components are
+// referenced through their parents (system.container.component) and only need to be unique among their siblings
+func (i *IdentifiersNode) WithHierarchical() *IdentifiersNode {
+	i.hierarchical = true
+	return i
+}
+
+func (i *IdentifiersNode) IsHierarchical() bool {
+	return i.hierarchical
+}
+
+// Pin sets the identifier of an element instead of generating it.
+// In hierarchical mode, it is the identifier of the element within its parent.
+func (i *IdentifiersNode) Pin(n Namer, identifier string) *IdentifiersNode {
+	i.pinned[n] = identifier
+	return i
+}
+
+// Pinned returns the identifier pinned for an element, if any
+func (i *IdentifiersNode) Pinned(n Namer) (string, bool) {
+	identifier, ok := i.pinned[n]
+	return identifier, ok
+}
+
+// Assign gives an identifier to every element of the model.
+// Elements are visited in model order, so the same model always gets the same identifiers.
+func (i *IdentifiersNode) Assign(m *ModelNode) *ElementIdentifiers {
+	a := &identifierAssignment{
+		identifiers: i,
+		assigned: &ElementIdentifiers{
+			local: map[Namer]string{},
+			full:  map[Namer]string{},
+		},
+		used: map[Namer]map[string]bool{},
+	}
+	// pinned identifiers are reserved first, generated ones making way for them
+	a.walk(m, func(n, parent Namer) {
+		if id, ok := i.pinned[n]; ok {
+			a.scope(parent)[id] = true
+		}
+	})
+	a.walk(m, a.assign)
+	return a.assigned
+}
+
+type identifierAssignment struct {
+	identifiers *IdentifiersNode
+	assigned    *ElementIdentifiers
+	// used holds the identifiers taken in each scope, keyed by the parent element, nil being the top level
+	used map[Namer]map[string]bool
+}
+
+// walk visits the elements of the model which can be given an identifier, parents first
+func (a *identifierAssignment) walk(m *ModelNode, visit func(n, parent Namer)) {
+	for _, p := range m.Persons() {
+		visit(p, nil)
+	}
+	for _, s := range m.SoftwareSystems() {
+		visit(s, nil)
+		for _, c := range s.Containers() {
+			visit(c, s)
+			for _, component := range c.Components() {
+				visit(component, c)
+			}
+		}
+	}
+	var walkNode func(d, parent *DeploymentNodeNode)
+	walkNode = func(d, parent *DeploymentNodeNode) {
+		if parent == nil {
+			visit(d, nil)
+		} else {
+			visit(d, parent)
+		}
+		for _, child := range d.Children() {
+			walkNode(child, d)
+		}
+		for _, infra := range d.InfrastructureNodes() {
+			visit(infra, d)
+		}
+		for _, instance := range d.ContainerInstances() {
+			visit(instance, d)
+		}
+	}
+	for _, d := range m.DeploymentNodes() {
+		walkNode(d, nil)
+	}
+}
+
+// scope returns the identifiers taken among the siblings of an element, which is the whole model in flat mode
+func (a *identifierAssignment) scope(parent Namer) map[string]bool {
+	if !a.identifiers.hierarchical {
+		parent = nil
+	}
+	used, ok := a.used[parent]
+	if !ok {
+		used = map[string]bool{}
+		a.used[parent] = used
+	}
+	return used
+}
+
+func (a *identifierAssignment) assign(n, parent Namer) {
+	local, ok := a.identifiers.pinned[n]
+	if !ok {
+		used := a.scope(parent)
+		base := ElementIdentifier(n)
+		local = base
+		for suffix := 2; used[local]; suffix++ {
+			local = base + strconv.Itoa(suffix)
+		}
+		used[local] = true
+	}
+	a.assigned.local[n] = local
+	a.assigned.full[n] = local
+	if a.identifiers.hierarchical && parent != nil {
+		a.assigned.full[n] = a.assigned.full[parent] + "." + local
+	}
+}
+
+// ElementIdentifiers holds the identifiers assigned to the elements of a model
+type ElementIdentifiers struct {
+	local, full map[Namer]string
+}
+
+// Of returns the identifier an element is referenced by. Elements outside of the model get one generated from their name.
+func (e *ElementIdentifiers) Of(n Namer) string {
+	if e != nil {
+		if id, ok := e.full[n]; ok {
+			return id
+		}
+	}
+	return ElementIdentifier(n)
+}
+
+// Local returns the identifier an element is declared with, which is relative to its parent in hierarchical mode
+func (e *ElementIdentifiers) Local(n Namer) string {
+	if e != nil {
+		if id, ok := e.local[n]; ok {
+			return id
+		}
+	}
+	return ElementIdentifier(n)
+}
+
+// ElementIdentifier generates the identifier of an element from its name.
+// Container instances are named after their container, they get an "Instance" suffix to keep a distinct identifier.
+func ElementIdentifier(n Namer) string {
+	if _, ok := n.(*ContainerInstanceNode); ok {
+		return GenerateIdentifier(n.Name() + " Instance")
+	}
+	return GenerateIdentifier(n.Name())
+}
+
+// GenerateIdentifier turns a name into a DSL identifier, in lower camel case and without the characters
+// the DSL doesn't accept
+func GenerateIdentifier(name string) string {
+	id := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return -1
+	}, strcase.ToLowerCamel(name))
+	if id == "" || unicode.IsDigit([]rune(id)[0]) {
+		id = "e" + id
+	}
+	return id
+}
@@ -0,0 +1,41 @@
+package gostructurizr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentifiers_Assign(t *testing.T) {
+	w := Workspace()
+	m := w.Model()
+	dash := m.AddSoftwareSystem("Order-Service", "")
+	space := m.AddSoftwareSystem("Order Service", "")
+	pinned := m.AddPerson("Customer", "")
+	shop := m.AddSoftwareSystem("Shop", "")
+	shopGateway := shop.AddContainer("API Gateway", "", "")
+	backOffice := m.AddSoftwareSystem("Back Office", "")
+	backOfficeGateway := backOffice.AddContainer("API Gateway", "", "")
+	w.Identifiers().Pin(pinned, "shop")
+	server := m.AddProdNode("Server", "", "")
+	instance := server.AddContainerInstance(shopGateway)
+
+	ids := w.Identifiers().Assign(m)
+	assert.Equal(t, "orderService", ids.Of(dash))
+	assert.Equal(t, "orderService2", ids.Of(space))
+	assert.Equal(t, "shop", ids.Of(pinned))
+	assert.Equal(t, "shop2", ids.Of(shop))
+	assert.Equal(t, "apiGateway", ids.Of(shopGateway))
+	assert.Equal(t, "apiGateway2", ids.Of(backOfficeGateway))
+	assert.Equal(t, ids.Of(space), ids.Local(space))
+	assert.Equal(t, "apiGatewayInstance", ids.Of(instance))
+
+	ids = w.Identifiers().WithHierarchical().Assign(m)
+	assert.Equal(t, "shop2.apiGateway", ids.Of(shopGateway))
+	assert.Equal(t, "backOffice.apiGateway", ids.Of(backOfficeGateway))
+	assert.Equal(t, "apiGateway", ids.Local(backOfficeGateway))
+	assert.Equal(t, "server.apiGatewayInstance", ids.Of(instance))
+
+	assert.Equal(t, "outsider", ids.Of(Person("Outsider", "")))
+	assert.Equal(t, "e3Ds", GenerateIdentifier("3-Ds"))
+}
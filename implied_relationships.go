@@ -0,0 +1,137 @@
+package gostructurizr
+
+// ImpliedRelationshipsStrategy decides which relationships are implied when one is added to the model.
+// A relationship from a component to a container is also a relationship from the container and the software
+// system of the component to that container: the strategy is asked, for each pair made of the source or one of
+// its parents and the destination or one of its parents, whether the relationship should be created.
+//
+// The strategy is applied when Uses is called, relationships added before it is set don't imply anything.
+type ImpliedRelationshipsStrategy interface {
+	// Implies reports whether r implies a relationship from source to destination
+	Implies(m *ModelNode, source, destination Namer, r *RelationShipNode) bool
+}
+
+type noImpliedRelationships struct{}
+
+func (noImpliedRelationships) Implies(*ModelNode, Namer, Namer, *RelationShipNode) bool {
+	return false
+}
+
+type impliedRelationshipsUnlessAnyExist struct{}
+
+func (impliedRelationshipsUnlessAnyExist) Implies(m *ModelNode, source, destination Namer, _ *RelationShipNode) bool {
+	for _, existing := range m.uses {
+		if existing.from == source && existing.to == destination {
+			return false
+		}
+	}
+	return true
+}
+
+type impliedRelationshipsUnlessSameDescription struct{}
+
+func (impliedRelationshipsUnlessSameDescription) Implies(m *ModelNode, source, destination Namer, r *RelationShipNode) bool {
+	for _, existing := range m.uses {
+		if existing.from == source && existing.to == destination && deref(existing.desc) == deref(r.desc) {
+			return false
+		}
+	}
+	return true
+}
+
+type alwaysImpliedRelationships struct{}
+
+func (alwaysImpliedRelationships) Implies(*ModelNode, Namer, Namer, *RelationShipNode) bool {
+	return true
+}
+
+var (
+	// NoImpliedRelationships never creates implied relationships, which is the default
+	NoImpliedRelationships ImpliedRelationshipsStrategy = noImpliedRelationships{}
+	// CreateImpliedRelationshipsUnlessAnyExist creates implied relationships between elements which aren't
+	// connected yet, whatever the description of the existing relationship
+	CreateImpliedRelationshipsUnlessAnyExist ImpliedRelationshipsStrategy = impliedRelationshipsUnlessAnyExist{}
+	// CreateImpliedRelationshipsUnlessSameDescription creates implied relationships unless the elements are
+	// already connected by a relationship with the same description
+	CreateImpliedRelationshipsUnlessSameDescription ImpliedRelationshipsStrategy = impliedRelationshipsUnlessSameDescription{}
+	// AlwaysCreateImpliedRelationships creates every implied relationship, even between elements already connected
+	AlwaysCreateImpliedRelationships ImpliedRelationshipsStrategy = alwaysImpliedRelationships{}
+)
+
+// WithImpliedRelationships sets the strategy applied to the relationships added from now on
+func (m *ModelNode) WithImpliedRelationships(strategy ImpliedRelationshipsStrategy) *ModelNode {
+	m.impliedRelationships = strategy
+	return m
+}
+
+// ImpliedRelationships returns the strategy applied to the relationships added to the model
+func (m *ModelNode) ImpliedRelationships() ImpliedRelationshipsStrategy {
+	if m.impliedRelationships == nil {
+		return NoImpliedRelationships
+	}
+	return m.impliedRelationships
+}
+
+// addImpliedRelationShips adds the relationships implied by r between the parents of its source and destination
+func (m *ModelNode) addImpliedRelationShips(r *RelationShipNode) {
+	strategy := m.ImpliedRelationships()
+	if strategy == NoImpliedRelationships {
+		return
+	}
+	for _, source := range withParents(r.from) {
+		for _, destination := range withParents(r.to) {
+			if source == r.from && destination == r.to {
+				continue
+			}
+			// an element doesn't use its own parents nor children
+			if isParentOf(source, destination) || isParentOf(destination, source) {
+				continue
+			}
+			if !strategy.Implies(m, source, destination, r) {
+				continue
+			}
+			implied := Uses(source, destination, deref(r.desc))
+			implied.impliedBy = r
+			m.uses = append(m.uses, implied)
+		}
+	}
+}
+
+// withParents returns n followed by the elements containing it, closest first
+func withParents(n Namer) []Namer {
+	elements := []Namer{n}
+	for {
+		switch e := n.(type) {
+		case *ContainerNode:
+			if e.sys == nil {
+				return elements
+			}
+			n = e.sys
+		case *ComponentNode:
+			if e.node == nil {
+				return elements
+			}
+			n = e.node
+		default:
+			return elements
+		}
+		elements = append(elements, n)
+	}
+}
+
+// isParentOf reports whether n is parent or contained by it, at any depth
+func isParentOf(parent, n Namer) bool {
+	for _, e := range withParents(n) {
+		if e == parent {
+			return true
+		}
+	}
+	return false
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,109 @@
+package gostructurizr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type relationshipPair struct{ from, to string }
+
+func pairs(m *ModelNode) []relationshipPair {
+	var all []relationshipPair
+	for _, r := range m.RelationShip() {
+		all = append(all, relationshipPair{r.From().Name(), r.To().Name()})
+	}
+	return all
+}
+
+func TestModelNode_WithImpliedRelationships(t *testing.T) {
+	newModel := func(strategy ImpliedRelationshipsStrategy) (*ModelNode, *ComponentNode, *ContainerNode) {
+		m := Model().WithImpliedRelationships(strategy)
+		shop := m.AddSoftwareSystem("Shop", "")
+		api := shop.AddContainer("API", "", "")
+		orders := api.AddComponent("Orders")
+		payment := m.AddSoftwareSystem("Payment", "")
+		gateway := payment.AddContainer("Gateway", "", "")
+		return m, orders, gateway
+	}
+
+	t.Run("none", func(t *testing.T) {
+		m, orders, gateway := newModel(NoImpliedRelationships)
+		orders.Uses(gateway, "Charges")
+		assert.Equal(t, []relationshipPair{{"Orders", "Gateway"}}, pairs(m))
+	})
+	t.Run("default is none", func(t *testing.T) {
+		m, orders, gateway := newModel(nil)
+		orders.Uses(gateway, "Charges")
+		assert.Equal(t, NoImpliedRelationships, m.ImpliedRelationships())
+		assert.Len(t, m.RelationShip(), 1)
+	})
+	t.Run("every parent pair", func(t *testing.T) {
+		m, orders, gateway := newModel(CreateImpliedRelationshipsUnlessAnyExist)
+		r := orders.Uses(gateway, "Charges").WithTechnology("HTTPS")
+		assert.Equal(t, []relationshipPair{
+			{"Orders", "Gateway"},
+			{"Orders", "Payment"},
+			{"API", "Gateway"},
+			{"API", "Payment"},
+			{"Shop", "Gateway"},
+			{"Shop", "Payment"},
+		}, pairs(m))
+		for _, implied := range m.RelationShip()[1:] {
+			assert.True(t, implied.IsImplied())
+			assert.Equal(t, r, implied.ImpliedBy())
+			assert.Equal(t, "Charges", *implied.Description())
+			assert.Equal(t, "HTTPS", *implied.Technology())
+		}
+		assert.False(t, r.IsImplied())
+	})
+	t.Run("unless any exist", func(t *testing.T) {
+		m, orders, gateway := newModel(CreateImpliedRelationshipsUnlessAnyExist)
+		orders.Uses(gateway, "Charges")
+		orders.Uses(gateway, "Refunds")
+		assert.Len(t, m.RelationShip(), 7)
+	})
+	t.Run("unless same description", func(t *testing.T) {
+		m, orders, gateway := newModel(CreateImpliedRelationshipsUnlessSameDescription)
+		orders.Uses(gateway, "Charges")
+		orders.Uses(gateway, "Charges")
+		orders.Uses(gateway, "Refunds")
+		assert.Len(t, m.RelationShip(), 2*6+1)
+	})
+	t.Run("always", func(t *testing.T) {
+		m, orders, gateway := newModel(AlwaysCreateImpliedRelationships)
+		orders.Uses(gateway, "Charges")
+		orders.Uses(gateway, "Charges")
+		assert.Len(t, m.RelationShip(), 12)
+	})
+	t.Run("not between parents and children", func(t *testing.T) {
+		m := Model().WithImpliedRelationships(AlwaysCreateImpliedRelationships)
+		shop := m.AddSoftwareSystem("Shop", "")
+		api := shop.AddContainer("API", "", "")
+		orders := api.AddComponent("Orders")
+		db := shop.AddContainer("Database", "", "")
+		orders.Uses(db, "Reads from")
+		assert.Equal(t, []relationshipPair{{"Orders", "Database"}, {"API", "Database"}}, pairs(m))
+	})
+}
+
+func TestViewContent_withoutImpliedRelationships(t *testing.T) {
+	w := Workspace()
+	m := w.Model().WithImpliedRelationships(CreateImpliedRelationshipsUnlessAnyExist)
+	customer := m.AddPerson("Customer", "")
+	shop := m.AddSoftwareSystem("Shop", "")
+	web := shop.AddContainer("Web", "", "")
+	customer.Uses(web, "Browses")
+
+	context := w.Views().CreateSystemContextView(shop).AddAllElements()
+	content := context.Content()
+	assert.Equal(t, []Namer{shop, customer}, content.Elements())
+	require.Len(t, content.RelationShips(), 1)
+	assert.True(t, content.RelationShips()[0].IsImplied())
+
+	context.WithoutImpliedRelationships()
+	content = context.Content()
+	assert.Equal(t, []Namer{shop}, content.Elements())
+	assert.Empty(t, content.RelationShips())
+}
@@ -0,0 +1,48 @@
+package gostructurizr
+
+// Position is a point on a diagram, in pixels from its top left corner
+type Position struct {
+	X, Y int
+}
+
+// LayoutNode holds the manual layout of a view, as done in Structurizr Lite or the cloud service:
+// the position of the elements and the vertices bending the relationships.
+type LayoutNode struct {
+	elements map[Namer]Position
+	vertices map[*RelationShipNode][]Position
+}
+
+func layout() *LayoutNode {
+	return &LayoutNode{
+		elements: map[Namer]Position{},
+		vertices: map[*RelationShipNode][]Position{},
+	}
+}
+
+// WithElementPosition places an element of the view
+func (l *LayoutNode) WithElementPosition(n Namer, x, y int) *LayoutNode {
+	l.elements[n] = Position{X: x, Y: y}
+	return l
+}
+
+// ElementPosition returns the position of an element, if it has been placed
+func (l *LayoutNode) ElementPosition(n Namer) (Position, bool) {
+	p, ok := l.elements[n]
+	return p, ok
+}
+
+// WithVertices sets the points a relationship of the view goes through
+func (l *LayoutNode) WithVertices(r *RelationShipNode, vertices ...Position) *LayoutNode {
+	l.vertices[r] = vertices
+	return l
+}
+
+// Vertices returns the points a relationship of the view goes through
+func (l *LayoutNode) Vertices(r *RelationShipNode) []Position {
+	return l.vertices[r]
+}
+
+// IsEmpty reports whether nothing has been placed manually
+func (l *LayoutNode) IsEmpty() bool {
+	return len(l.elements) == 0 && len(l.vertices) == 0
+}
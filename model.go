@@ -12,12 +12,14 @@ package gostructurizr
 // For more information on the C4 model concept: https://c4model.com/
 type ModelNode struct {
 	properties      Properties                       // Custom properties for this model
-	softwareGroups  []GroupNode[*SoftwareSystemNode] // Logical groupings of software systems
+	groups          []*GroupNode[LandscapeElement]   // Groups of people and software systems
 	softwareSystems []*SoftwareSystemNode            // All software systems in the model
 	persons         []*PersonNode                    // All people/actors in the model
 	uses            []*RelationShipNode              // All relationships between elements
 	enterprise      *EnterpriseNode                  // Optional enterprise boundary definition
 	deploymentNodes []*DeploymentNodeNode            // All deployment nodes for infrastructure
+	// Strategy deriving relationships between parents from the ones added
+	impliedRelationships ImpliedRelationshipsStrategy
 }
 
 // Model creates a new empty model to represent the software architecture.
@@ -60,6 +62,9 @@ func (m *ModelNode) AddPerson(name, desc string) *PersonNode {
 	p := Person(name, desc)
 	m.persons = append(m.persons, p)
 	p.model = m
+	if m.enterprise != nil {
+		p.WithLocation(ExternalLocation)
+	}
 	return p
 }
 
@@ -99,6 +104,9 @@ func (m *ModelNode) AddSoftwareSystem(name, desc string) *SoftwareSystemNode {
 	s := SoftwareSystem(name, desc)
 	m.softwareSystems = append(m.softwareSystems, s)
 	s.model = m
+	if m.enterprise != nil {
+		s.WithLocation(ExternalLocation)
+	}
 	return s
 }
 
@@ -119,6 +127,57 @@ func (m *ModelNode) SoftwareSystems() []*SoftwareSystemNode {
 	return m.softwareSystems
 }
 
+// AddGroup creates and adds a group of people and software systems to the model.
+// Groups organise the elements of large models, by business domain or team for instance,
+// and are drawn as a boundary around their elements.
+//
+// Parameters:
+//   - name: The name of the group (e.g., "Sales")
+//
+// Returns:
+//   - A new GroupNode elements and nested groups are added to
+//
+// Example:
+//
+//	sales := model.AddGroup("Sales")
+//	sales.Add(model.AddSoftwareSystem("CRM System", "Manages customer relationships"))
+//	sales.AddGroup("EMEA").Add(model.AddPerson("Sales Representative", ""))
+func (m *ModelNode) AddGroup(name string) *GroupNode[LandscapeElement] {
+	g := Group[LandscapeElement](name)
+	g.top = &m.groups
+	m.groups = append(m.groups, g)
+	return g
+}
+
+// Groups returns the top level groups of people and software systems
+func (m *ModelNode) Groups() []*GroupNode[LandscapeElement] {
+	return m.groups
+}
+
+// GroupOf returns the innermost group holding a person or software system, nil if it is in none
+func (m *ModelNode) GroupOf(e LandscapeElement) *GroupNode[LandscapeElement] {
+	return groupOf(m.groups, e)
+}
+
+// HasNestedGroups reports whether a group of the model, of a software system or of a container holds another group,
+// which Structurizr requires a group separator for
+func (m *ModelNode) HasNestedGroups() bool {
+	if hasNestedGroups(m.groups) {
+		return true
+	}
+	for _, s := range m.softwareSystems {
+		if hasNestedGroups(s.groups) {
+			return true
+		}
+		for _, c := range s.containers {
+			if hasNestedGroups(c.groups) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // RelationShip returns all relationships defined in this model.
 // Relationships represent the interactions and dependencies between
 // elements in the model (people, systems, containers, components).
@@ -138,11 +197,15 @@ func (m *ModelNode) RelationShip() []*RelationShipNode {
 //   - to: The target element that receives the relationship
 //   - desc: A description of how the source uses the target
 //
+// The relationships implied by the new one are added as well, according
+// to the implied relationships strategy of the model.
+//
 // Returns:
 //   - A new RelationShipNode representing the relationship
 func (m *ModelNode) addRelationShip(from, to Namer, desc string) *RelationShipNode {
 	r := Uses(from, to, desc)
 	m.uses = append(m.uses, r)
+	m.addImpliedRelationShips(r)
 	return r
 }
 
@@ -165,6 +228,17 @@ func (m *ModelNode) addRelationShip(from, to Namer, desc string) *RelationShipNo
 func (m *ModelNode) SetEnterprise(name string) *EnterpriseNode {
 	m.enterprise = Enterprise(name)
 	m.enterprise.model = m
+	// elements outside of the enterprise are external, until added to it
+	for _, p := range m.persons {
+		if p.Location() == "" {
+			p.WithLocation(ExternalLocation)
+		}
+	}
+	for _, s := range m.softwareSystems {
+		if s.Location() == "" {
+			s.WithLocation(ExternalLocation)
+		}
+	}
 	return m.enterprise
 }
 
@@ -185,6 +259,11 @@ func (m *ModelNode) Enterprise() *EnterpriseNode {
 	return m.enterprise
 }
 
+// Properties returns the custom properties of the model
+func (m *ModelNode) Properties() *Properties {
+	return &m.properties
+}
+
 // AddDeploymentEnvironment adds a deployment environment to the model.
 // A deployment environment represents a distinct context in which software systems
 // are deployed (e.g., Development, Test, Staging, Production).
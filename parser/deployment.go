@@ -0,0 +1,177 @@
+package parser
+
+import (
+	"strconv"
+
+	"github.com/platelk/gostructurizr"
+)
+
+// parseDeploymentEnvironment parses `deploymentEnvironment <name> {`, whose block holds the deployment nodes of the environment
+func (p *parser) parseDeploymentEnvironment(s *statement, identifier string) error {
+	args, err := s.args(1)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 || !s.block {
+		return errorAt(s.start, "expected: deploymentEnvironment <name> {")
+	}
+	environment := p.workspace.Model().AddDeploymentEnvironment(args[0])
+	if identifier != "" {
+		if _, ok := p.identifiers[identifier]; ok {
+			return errorAt(s.start, "identifier %q is already in use", identifier)
+		}
+		p.environments[identifier] = environment
+	}
+	return p.parseBlock(s.start, func(s *statement) error {
+		return p.parseDeploymentItem(s, nil, "", environment)
+	})
+}
+
+// parseDeploymentItem parses a statement found in a deployment environment or in the block of a deployment element.
+// parent is the element owning the block, nil at the environment level, and parentID its identifier.
+func (p *parser) parseDeploymentItem(s *statement, parent gostructurizr.Namer, parentID string, environment gostructurizr.DeploymentEnvironment) error {
+	identifier, s, err := assignment(s)
+	if err != nil {
+		return err
+	}
+	for _, t := range s.tokens {
+		if t.kind == tokenArrow {
+			return p.parseRelationship(s, parent)
+		}
+	}
+
+	switch s.keyword() {
+	case "deploymentnode":
+		args, err := s.args(1)
+		if err != nil {
+			return err
+		}
+		if len(args) < 1 || len(args) > 4 {
+			return errorAt(s.start, "expected: deploymentNode <name> [description] [technology] [tags]")
+		}
+		var node *gostructurizr.DeploymentNodeNode
+		switch e := parent.(type) {
+		case nil:
+			node = p.workspace.Model().AddDeploymentNode(args[0], argAt(args, 1), argAt(args, 2), environment)
+		case *gostructurizr.DeploymentNodeNode:
+			node = e.AddChildNode(args[0], argAt(args, 1), argAt(args, 2))
+		default:
+			return errorAt(s.start, "deploymentNode must be defined in a deployment environment or a deployment node")
+		}
+		addTags(node, argAt(args, 3))
+		return p.parseDeploymentBlock(s, identifier, parentID, node, environment)
+	case "infrastructurenode":
+		node, ok := parent.(*gostructurizr.DeploymentNodeNode)
+		if !ok {
+			return errorAt(s.start, "infrastructureNode must be defined inside a deployment node")
+		}
+		args, err := s.args(1)
+		if err != nil {
+			return err
+		}
+		if len(args) < 1 || len(args) > 4 {
+			return errorAt(s.start, "expected: infrastructureNode <name> [description] [technology] [tags]")
+		}
+		infra := node.AddInfrastructureNode(args[0], argAt(args, 1), argAt(args, 2))
+		addTags(infra, argAt(args, 3))
+		return p.parseDeploymentBlock(s, identifier, parentID, infra, environment)
+	case "containerinstance":
+		node, ok := parent.(*gostructurizr.DeploymentNodeNode)
+		if !ok {
+			return errorAt(s.start, "containerInstance must be defined inside a deployment node")
+		}
+		if len(s.tokens) < 2 {
+			return errorAt(s.start, "expected: containerInstance <identifier> [deploymentGroups] [tags]")
+		}
+		element, err := p.lookup(s.tokens[1], nil)
+		if err != nil {
+			return err
+		}
+		container, ok := element.(*gostructurizr.ContainerNode)
+		if !ok {
+			return errorAt(s.tokens[1], "%s is not a container", element.Name())
+		}
+		args, err := s.args(2)
+		if err != nil {
+			return err
+		}
+		if len(args) > 2 {
+			return errorAt(s.tokens[4], "too many arguments, expected: containerInstance <identifier> [deploymentGroups] [tags]")
+		}
+		// deployment groups aren't modelled, every instance sees the others
+		instance := node.AddContainerInstance(container)
+		instance.WithInstanceId(instanceCount(p.workspace.Model(), environment, container))
+		addTags(instance, argAt(args, 1))
+		return p.parseDeploymentBlock(s, identifier, parentID, instance, environment)
+	case "healthcheck":
+		instance, ok := parent.(*gostructurizr.ContainerInstanceNode)
+		if !ok {
+			return errorAt(s.start, "healthCheck must be defined inside a container instance")
+		}
+		return p.parseHealthCheck(s, instance)
+	}
+	if parent != nil && identifier == "" {
+		return p.parseElementAttribute(s, parent)
+	}
+	return errorAt(s.start, "unexpected %q in deployment environment", s.tokens[0].value)
+}
+
+// parseDeploymentBlock registers the identifier of a freshly created deployment element and parses its block if any
+func (p *parser) parseDeploymentBlock(s *statement, identifier, parentID string, element gostructurizr.Namer, environment gostructurizr.DeploymentEnvironment) error {
+	id, err := p.register(s, identifier, parentID, element)
+	if err != nil {
+		return err
+	}
+	if !s.block {
+		return nil
+	}
+	return p.parseBlock(s.start, func(s *statement) error {
+		return p.parseDeploymentItem(s, element, id, environment)
+	})
+}
+
+// parseHealthCheck parses `healthCheck <name> <url> [interval] [timeout]`, the interval being in seconds and the timeout in milliseconds
+func (p *parser) parseHealthCheck(s *statement, instance *gostructurizr.ContainerInstanceNode) error {
+	args, err := s.args(1)
+	if err != nil {
+		return err
+	}
+	if len(args) < 2 || len(args) > 4 {
+		return errorAt(s.start, "expected: healthCheck <name> <url> [interval] [timeout]")
+	}
+	healthCheck := instance.AddHealthCheck(args[0], args[1])
+	if len(args) > 2 {
+		interval, err := strconv.Atoi(args[2])
+		if err != nil {
+			return errorAt(s.tokens[3], "invalid interval %q, expected a number of seconds", args[2])
+		}
+		healthCheck.WithInterval(interval)
+	}
+	if len(args) > 3 {
+		timeout, err := strconv.Atoi(args[3])
+		if err != nil {
+			return errorAt(s.tokens[4], "invalid timeout %q, expected a number of milliseconds", args[3])
+		}
+		healthCheck.WithTimeout(timeout)
+	}
+	return noBlock(s)
+}
+
+// instanceCount counts the instances of a container within an environment.
+// Structurizr numbers the instances in the order they are defined, so the count is the id of the last one.
+func instanceCount(m *gostructurizr.ModelNode, environment gostructurizr.DeploymentEnvironment, container *gostructurizr.ContainerNode) int {
+	count := 0
+	var walk func(nodes []*gostructurizr.DeploymentNodeNode)
+	walk = func(nodes []*gostructurizr.DeploymentNodeNode) {
+		for _, node := range nodes {
+			for _, instance := range node.ContainerInstances() {
+				if instance.Container() == container {
+					count++
+				}
+			}
+			walk(node.Children())
+		}
+	}
+	walk(m.FindDeploymentNodesForEnvironment(environment))
+	return count
+}
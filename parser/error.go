@@ -0,0 +1,28 @@
+package parser
+
+import "fmt"
+
+// Error is returned when the DSL can't be parsed. It carries the position
+// of the offending token so it can be reported back to the author of the file.
+type Error struct {
+	Line   int
+	Column int
+	Msg    string
+}
+
+func newError(line, column int, format string, args ...interface{}) *Error {
+	return &Error{
+		Line:   line,
+		Column: column,
+		Msg:    fmt.Sprintf(format, args...),
+	}
+}
+
+func errorAt(t token, format string, args ...interface{}) *Error {
+	return newError(t.line, t.column, format, args...)
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Msg)
+}
@@ -0,0 +1,154 @@
+package parser
+
+import (
+	"strconv"
+
+	"github.com/platelk/gostructurizr"
+	"github.com/platelk/gostructurizr/schema"
+)
+
+// Identities of elements and relationships are used to match a workspace generated from code
+// with a previously exported one, whose identifiers may have been assigned differently.
+// An element is identified by its path, made of its name and the names of its parents;
+// deployment elements are prefixed by their environment.
+
+// relationshipIdentity identifies a relationship by the paths of its ends and its description
+type relationshipIdentity struct {
+	source, destination, description string
+}
+
+// storedIdentity maps the identifiers of a JSON document to identities
+type storedIdentity struct {
+	elements      map[string]string
+	relationships map[string]relationshipIdentity
+}
+
+// currentIdentity maps identities to the nodes of a workspace
+type currentIdentity struct {
+	elements      map[string]gostructurizr.Namer
+	paths         map[gostructurizr.Namer]string
+	relationships map[relationshipIdentity]*gostructurizr.RelationShipNode
+}
+
+func elementPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "/" + name
+}
+
+func deploymentPath(environment string) string {
+	return "deployment:" + environment
+}
+
+func containerInstancePath(parent, containerPath string, instanceID int) string {
+	return elementPath(parent, containerPath+"#"+strconv.Itoa(instanceID))
+}
+
+func storedIdentities(doc schema.Model) *storedIdentity {
+	s := &storedIdentity{
+		elements:      map[string]string{},
+		relationships: map[string]relationshipIdentity{},
+	}
+	var relationships []schema.Relationship
+	add := func(e schema.Element, path string) {
+		s.elements[e.ID] = path
+		relationships = append(relationships, e.Relationships...)
+	}
+	for _, p := range doc.People {
+		add(p.Element, elementPath("", p.Name))
+	}
+	for _, system := range doc.SoftwareSystems {
+		systemPath := elementPath("", system.Name)
+		add(system.Element, systemPath)
+		for _, container := range system.Containers {
+			containerPath := elementPath(systemPath, container.Name)
+			add(container.Element, containerPath)
+			for _, component := range container.Components {
+				add(component.Element, elementPath(containerPath, component.Name))
+			}
+		}
+	}
+	var addDeploymentNode func(d schema.DeploymentNode, parent string)
+	addDeploymentNode = func(d schema.DeploymentNode, parent string) {
+		path := elementPath(parent, d.Name)
+		add(d.Element, path)
+		for _, child := range d.Children {
+			addDeploymentNode(child, path)
+		}
+		for _, infra := range d.InfrastructureNodes {
+			add(infra.Element, elementPath(path, infra.Name))
+		}
+		for _, instance := range d.ContainerInstances {
+			add(instance.Element, containerInstancePath(path, s.elements[instance.ContainerID], instance.InstanceID))
+		}
+	}
+	for _, d := range doc.DeploymentNodes {
+		addDeploymentNode(d, deploymentPath(d.Environment))
+	}
+	for _, r := range relationships {
+		s.relationships[r.ID] = relationshipIdentity{
+			source:      s.elements[r.SourceID],
+			destination: s.elements[r.DestinationID],
+			description: r.Description,
+		}
+	}
+	return s
+}
+
+func currentIdentities(m *gostructurizr.ModelNode) *currentIdentity {
+	c := &currentIdentity{
+		elements:      map[string]gostructurizr.Namer{},
+		paths:         map[gostructurizr.Namer]string{},
+		relationships: map[relationshipIdentity]*gostructurizr.RelationShipNode{},
+	}
+	add := func(n gostructurizr.Namer, path string) {
+		c.elements[path] = n
+		c.paths[n] = path
+	}
+	for _, p := range m.Persons() {
+		add(p, elementPath("", p.Name()))
+	}
+	for _, system := range m.SoftwareSystems() {
+		systemPath := elementPath("", system.Name())
+		add(system, systemPath)
+		for _, container := range system.Containers() {
+			containerPath := elementPath(systemPath, container.Name())
+			add(container, containerPath)
+			for _, component := range container.Components() {
+				add(component, elementPath(containerPath, component.Name()))
+			}
+		}
+	}
+	var addDeploymentNode func(d *gostructurizr.DeploymentNodeNode, parent string)
+	addDeploymentNode = func(d *gostructurizr.DeploymentNodeNode, parent string) {
+		path := elementPath(parent, d.Name())
+		add(d, path)
+		for _, child := range d.Children() {
+			addDeploymentNode(child, path)
+		}
+		for _, infra := range d.InfrastructureNodes() {
+			add(infra, elementPath(path, infra.Name()))
+		}
+		for _, instance := range d.ContainerInstances() {
+			add(instance, containerInstancePath(path, c.paths[instance.Container()], instance.InstanceId()))
+		}
+	}
+	for _, d := range m.DeploymentNodes() {
+		addDeploymentNode(d, deploymentPath(string(d.Environment())))
+	}
+	for _, r := range m.RelationShip() {
+		identity := relationshipIdentity{
+			source:      c.paths[r.From()],
+			destination: c.paths[r.To()],
+		}
+		if r.Description() != nil {
+			identity.description = *r.Description()
+		}
+		// the first of several identical relationships wins
+		if _, ok := c.relationships[identity]; !ok {
+			c.relationships[identity] = r
+		}
+	}
+	return c
+}
@@ -0,0 +1,638 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/platelk/gostructurizr"
+	"github.com/platelk/gostructurizr/dsl"
+	"github.com/platelk/gostructurizr/renderer"
+	"github.com/platelk/gostructurizr/schema"
+	"github.com/platelk/gostructurizr/shapes"
+	"github.com/platelk/gostructurizr/tags"
+)
+
+// JSONParser reads a workspace stored in the Structurizr JSON workspace format,
+// such as the workspace.json file maintained by Structurizr Lite.
+type JSONParser struct {
+	reader io.Reader
+}
+
+func NewJSONParser(reader io.Reader) *JSONParser {
+	return &JSONParser{
+		reader: reader,
+	}
+}
+
+// Parse builds the workspace described by the JSON document, manual layout of the views included
+func (p *JSONParser) Parse() (*gostructurizr.WorkspaceNode, error) {
+	doc, err := p.decode()
+	if err != nil {
+		return nil, err
+	}
+	i := &jsonImporter{
+		elements:      map[string]gostructurizr.Namer{},
+		relationships: map[string]*gostructurizr.RelationShipNode{},
+		parents:       map[string]string{},
+		workspace:     gostructurizr.Workspace(),
+	}
+	if doc.Name != "" {
+		i.workspace.WithName(doc.Name)
+	}
+	if doc.Description != "" {
+		i.workspace.WithDesc(doc.Description)
+	}
+	if err := i.importModel(doc.Model); err != nil {
+		return nil, err
+	}
+	if err := i.importViews(doc.Views); err != nil {
+		return nil, err
+	}
+	return i.workspace, nil
+}
+
+// MergeLayout copies the manual layout stored in the JSON document into the views of w.
+// Views are matched on their key, elements on their name and the names of their parents,
+// and relationships on their source, destination and description, so the layout done on a
+// previous export survives the regeneration of the workspace from code.
+// Elements and relationships which don't exist anymore are ignored.
+func (p *JSONParser) MergeLayout(w *gostructurizr.WorkspaceNode) error {
+	doc, err := p.decode()
+	if err != nil {
+		return err
+	}
+	stored := storedIdentities(doc.Model)
+	current := currentIdentities(w.Model())
+	elements := map[string]gostructurizr.Namer{}
+	for id, path := range stored.elements {
+		if n, ok := current.elements[path]; ok {
+			elements[id] = n
+		}
+	}
+	relationships := map[string]*gostructurizr.RelationShipNode{}
+	for id, key := range stored.relationships {
+		if r, ok := current.relationships[key]; ok {
+			relationships[id] = r
+		}
+	}
+
+	// keys of views defined without one are generated by the JSON renderer
+	keys := renderer.ViewKeys(w.Views())
+	storedViews := viewsByKey(doc.Views)
+	views := w.Views()
+	for _, v := range views.SystemLandscapeViews() {
+		mergeViewLayout(v.Layout(), storedViews[keys[v]], elements, relationships)
+	}
+	for _, v := range views.SystemContextViews() {
+		mergeViewLayout(v.Layout(), storedViews[keys[v]], elements, relationships)
+	}
+	for _, v := range views.ContainerViews() {
+		mergeViewLayout(v.Layout(), storedViews[keys[v]], elements, relationships)
+	}
+	for _, v := range views.ComponentViews() {
+		mergeViewLayout(v.Layout(), storedViews[keys[v]], elements, relationships)
+	}
+	for _, v := range views.DeploymentViews() {
+		mergeViewLayout(v.Layout(), storedViews[keys[v]], elements, relationships)
+	}
+	for _, v := range views.DynamicViews() {
+		storedView := storedViews[keys[v]]
+		if storedView == nil {
+			continue
+		}
+		mergeElementPositions(v.Layout(), storedView, elements)
+		// steps are matched on their order, as long as they still link the same elements
+		steps := v.Content().RelationShips()
+		for _, r := range storedView.Relationships {
+			order, err := strconv.Atoi(r.Order)
+			if err != nil || order < 1 || order > len(steps) || len(r.Vertices) == 0 {
+				continue
+			}
+			step, identity := steps[order-1], stored.relationships[r.ID]
+			if identity.source != current.paths[step.From()] || identity.destination != current.paths[step.To()] {
+				continue
+			}
+			v.Layout().WithVertices(step, positions(r.Vertices)...)
+		}
+	}
+	return nil
+}
+
+func (p *JSONParser) decode() (*schema.Workspace, error) {
+	var doc schema.Workspace
+	if err := json.NewDecoder(p.reader).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("can't decode json workspace: %w", err)
+	}
+	return &doc, nil
+}
+
+// propertied is implemented by every element carrying properties
+type propertied interface {
+	Properties() *gostructurizr.Properties
+}
+
+// jsonImporter holds the state of a single JSON import
+type jsonImporter struct {
+	workspace     *gostructurizr.WorkspaceNode
+	elements      map[string]gostructurizr.Namer
+	relationships map[string]*gostructurizr.RelationShipNode
+	parents       map[string]string // deployment node id by child id
+	pending       []schema.Relationship
+	viewsByKey    map[string]gostructurizr.Viewable
+}
+
+// location converts a Structurizr location, Unspecified being the zero value of gostructurizr.Location
+func location(l string) gostructurizr.Location {
+	if l == "Unspecified" {
+		return ""
+	}
+	return gostructurizr.Location(l)
+}
+
+func (i *jsonImporter) importModel(doc schema.Model) error {
+	m := i.workspace.Model()
+	if doc.Enterprise != nil {
+		m.SetEnterprise(doc.Enterprise.Name)
+	}
+	for key, value := range doc.Properties {
+		m.Properties().Add(key, value)
+	}
+	separator := doc.Properties[dsl.GroupSeparator]
+	for _, p := range doc.People {
+		person := m.AddPerson(p.Name, p.Description).WithLocation(location(p.Location))
+		i.importElement(person, p.Element)
+		if g := importGroup(m.Groups(), m.AddGroup, p.Group, separator); g != nil {
+			g.Add(person)
+		}
+	}
+	for _, s := range doc.SoftwareSystems {
+		system := m.AddSoftwareSystem(s.Name, s.Description).WithLocation(location(s.Location))
+		i.importElement(system, s.Element)
+		if g := importGroup(m.Groups(), m.AddGroup, s.Group, separator); g != nil {
+			g.Add(system)
+		}
+		for _, c := range s.Containers {
+			container := system.AddContainer(c.Name, c.Description, c.Technology)
+			i.importElement(container, c.Element)
+			if g := importGroup(system.Groups(), system.AddGroup, c.Group, separator); g != nil {
+				g.Add(container)
+			}
+			for _, co := range c.Components {
+				component := container.AddComponent(co.Name).WithDesc(co.Description)
+				if co.Technology != "" {
+					component.WithTechnology(co.Technology)
+				}
+				i.importElement(component, co.Element)
+				if g := importGroup(container.Groups(), container.AddGroup, co.Group, separator); g != nil {
+					g.Add(component)
+				}
+			}
+		}
+	}
+	for _, d := range doc.DeploymentNodes {
+		node := m.AddDeploymentNode(d.Name, d.Description, d.Technology, m.AddDeploymentEnvironment(d.Environment))
+		if err := i.importDeploymentNode(node, d); err != nil {
+			return err
+		}
+	}
+	// relationships are nested in their source element, identifiers give back the order they were created in
+	sort.SliceStable(i.pending, func(a, b int) bool {
+		return numericID(i.pending[a].ID) < numericID(i.pending[b].ID)
+	})
+	for _, r := range i.pending {
+		if err := i.importRelationship(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importGroup returns the group an element belongs to from its full name, split on the group separator of the model
+// if any, nil when the element is in no group
+func importGroup[K gostructurizr.Namer](groups []*gostructurizr.GroupNode[K], add func(string) *gostructurizr.GroupNode[K], fullName, separator string) *gostructurizr.GroupNode[K] {
+	if fullName == "" {
+		return nil
+	}
+	names := []string{fullName}
+	if separator != "" {
+		names = strings.Split(fullName, separator)
+	}
+	var g *gostructurizr.GroupNode[K]
+	for _, name := range names {
+		g = subGroup(g, groups, add, name)
+	}
+	return g
+}
+
+// importElement registers an element and copies the fields every element has
+func (i *jsonImporter) importElement(n gostructurizr.Namer, doc schema.Element) {
+	i.elements[doc.ID] = n
+	i.pending = append(i.pending, doc.Relationships...)
+	addJSONTags(n, doc.Tags)
+	if p, ok := n.(propertied); ok {
+		for key, value := range doc.Properties {
+			p.Properties().Add(key, value)
+		}
+	}
+}
+
+func (i *jsonImporter) importDeploymentNode(node *gostructurizr.DeploymentNodeNode, doc schema.DeploymentNode) error {
+	i.importElement(node, doc.Element)
+	for _, c := range doc.Children {
+		child := node.AddDeploymentNode(c.Name, c.Description, c.Technology, node.Environment())
+		i.parents[c.ID] = doc.ID
+		if err := i.importDeploymentNode(child, c); err != nil {
+			return err
+		}
+	}
+	for _, in := range doc.InfrastructureNodes {
+		infra := node.AddInfrastructureNode(in.Name, in.Description, in.Technology)
+		i.parents[in.ID] = doc.ID
+		i.importElement(infra, in.Element)
+	}
+	for _, ci := range doc.ContainerInstances {
+		container, ok := i.elements[ci.ContainerID].(*gostructurizr.ContainerNode)
+		if !ok {
+			return fmt.Errorf("can't import container instance %s: unknown container %q", ci.ID, ci.ContainerID)
+		}
+		instance := node.AddContainerInstance(container).WithInstanceId(ci.InstanceID)
+		i.parents[ci.ID] = doc.ID
+		i.importElement(instance, ci.Element)
+		for _, h := range ci.HealthChecks {
+			check := instance.AddHealthCheck(h.Name, h.URL).WithInterval(h.Interval).WithTimeout(h.Timeout)
+			for key, value := range h.Properties {
+				check.Properties().Add(key, value)
+			}
+		}
+	}
+	return nil
+}
+
+func (i *jsonImporter) importRelationship(doc schema.Relationship) error {
+	source, ok := i.elements[doc.SourceID].(user)
+	if !ok {
+		return fmt.Errorf("can't import relationship %s: unknown source %q", doc.ID, doc.SourceID)
+	}
+	destination, ok := i.elements[doc.DestinationID]
+	if !ok {
+		return fmt.Errorf("can't import relationship %s: unknown destination %q", doc.ID, doc.DestinationID)
+	}
+	r := source.Uses(destination, doc.Description)
+	if doc.Technology != "" {
+		r.WithTechnology(doc.Technology)
+	}
+	if doc.InteractionStyle != "" {
+		r.WithInteractionStyle(gostructurizr.InteractionStyle(strings.ToLower(doc.InteractionStyle)))
+	}
+	addJSONTags(r, doc.Tags)
+	if doc.URL != "" {
+		r.WithURL(doc.URL)
+	}
+	for key, value := range doc.Properties {
+		r.Properties().Add(key, value)
+	}
+	for _, p := range doc.Perspectives {
+		r.WithPerspective(gostructurizr.Perspective{Name: p.Name, Description: p.Description, Value: p.Value})
+	}
+	// implied relationships are created after the one they are derived from, which is already imported
+	if linked, ok := i.relationships[doc.LinkedRelationshipID]; ok {
+		r.WithImpliedBy(linked)
+	}
+	i.relationships[doc.ID] = r
+	return nil
+}
+
+func (i *jsonImporter) importViews(doc schema.Views) error {
+	views := i.workspace.Views()
+	i.viewsByKey = map[string]gostructurizr.Viewable{}
+	for _, v := range doc.SystemLandscapeViews {
+		view := views.CreateSystemLandscapeView().WithKey(v.Key)
+		if v.Description != "" {
+			view.WithDescription(v.Description)
+		}
+		if v.AutomaticLayout != nil {
+			view.WithAutoLayout()
+		}
+		if v.EnterpriseBoundaryVisible != nil && !*v.EnterpriseBoundaryVisible {
+			view.WithoutEnterpriseBoundary()
+		}
+		for _, e := range i.viewElements(v.View) {
+			view.WithInclude(gostructurizr.On(e))
+		}
+		i.importLayout(view.Layout(), v.View)
+		i.viewsByKey[v.Key] = view
+	}
+	for _, v := range doc.SystemContextViews {
+		system, ok := i.elements[v.SoftwareSystemID].(*gostructurizr.SoftwareSystemNode)
+		if !ok {
+			return fmt.Errorf("can't import view %q: unknown software system %q", v.Key, v.SoftwareSystemID)
+		}
+		view := views.CreateSystemContextView(system).WithKey(v.Key)
+		if v.Description != "" {
+			view.WithDescription(v.Description)
+		}
+		if v.AutomaticLayout != nil {
+			view.WithAutoLayout()
+		}
+		if v.EnterpriseBoundaryVisible != nil && !*v.EnterpriseBoundaryVisible {
+			view.WithoutEnterpriseBoundary()
+		}
+		for _, e := range i.viewElements(v.View) {
+			if e != system {
+				view.WithInclude(gostructurizr.On(e))
+			}
+		}
+		i.importLayout(view.Layout(), v.View)
+		i.viewsByKey[v.Key] = view
+	}
+	for _, v := range doc.ContainerViews {
+		system, ok := i.elements[v.SoftwareSystemID].(*gostructurizr.SoftwareSystemNode)
+		if !ok {
+			return fmt.Errorf("can't import view %q: unknown software system %q", v.Key, v.SoftwareSystemID)
+		}
+		view := views.CreateContainerView(system).WithKey(v.Key)
+		if v.Description != "" {
+			view.WithDescription(v.Description)
+		}
+		if v.AutomaticLayout != nil {
+			view.WithAutoLayout()
+		}
+		for _, e := range i.viewElements(v.View) {
+			view.WithInclude(gostructurizr.On(e))
+		}
+		i.importLayout(view.Layout(), v.View)
+		i.viewsByKey[v.Key] = view
+	}
+	for _, v := range doc.ComponentViews {
+		container, ok := i.elements[v.ContainerID].(*gostructurizr.ContainerNode)
+		if !ok {
+			return fmt.Errorf("can't import view %q: unknown container %q", v.Key, v.ContainerID)
+		}
+		view := views.CreateComponentView(container).WithKey(v.Key)
+		if v.Description != "" {
+			view.WithDescription(v.Description)
+		}
+		if v.AutomaticLayout != nil {
+			view.WithAutoLayout()
+		}
+		for _, e := range i.viewElements(v.View) {
+			view.WithInclude(gostructurizr.On(e))
+		}
+		i.importLayout(view.Layout(), v.View)
+		i.viewsByKey[v.Key] = view
+	}
+	for _, v := range doc.DynamicViews {
+		if err := i.importDynamicView(v); err != nil {
+			return err
+		}
+	}
+	for _, v := range doc.DeploymentViews {
+		var system *gostructurizr.SoftwareSystemNode
+		if v.SoftwareSystemID != "" {
+			system, _ = i.elements[v.SoftwareSystemID].(*gostructurizr.SoftwareSystemNode)
+		}
+		view := views.CreateDeploymentView(system, i.workspace.Model().AddDeploymentEnvironment(v.Environment)).WithKey(v.Key)
+		if v.Description != "" {
+			view.WithDescription(v.Description)
+		}
+		if v.AutomaticLayout != nil {
+			view.WithAutoLayout()
+		}
+		// nested elements are displayed along with their top most deployment node
+		inView := map[string]bool{}
+		for _, e := range v.Elements {
+			inView[e.ID] = true
+		}
+		for _, e := range v.Elements {
+			node, ok := i.elements[e.ID].(*gostructurizr.DeploymentNodeNode)
+			if ok && !inView[i.parents[e.ID]] {
+				view.AddDeploymentNode(node)
+			}
+		}
+		i.importLayout(view.Layout(), v.View)
+	}
+	for _, v := range doc.FilteredViews {
+		base, ok := i.viewsByKey[v.BaseViewKey]
+		if !ok {
+			return fmt.Errorf("can't import filtered view %q: unknown base view %q", v.Key, v.BaseViewKey)
+		}
+		view := views.CreateFilteredView(base, v.Title).WithKey(v.Key).WithDescription(v.Description)
+		for _, tag := range v.Tags {
+			view.WithTagFilter(tag, gostructurizr.FilterMode(v.Mode))
+		}
+	}
+	importStyles(views.Configuration().Styles(), doc.Configuration.Styles)
+	return nil
+}
+
+func (i *jsonImporter) importDynamicView(v schema.DynamicView) error {
+	view := i.workspace.Views().CreateDynamicView(i.elements[v.ElementID]).WithKey(v.Key)
+	if v.ElementID != "" {
+		view.WithIdentifier(i.elements[v.ElementID])
+	}
+	if v.Description != "" {
+		view.WithDescription(v.Description)
+	}
+	steps := append([]schema.RelationshipView{}, v.Relationships...)
+	sort.SliceStable(steps, func(a, b int) bool {
+		return numericID(steps[a].Order) < numericID(steps[b].Order)
+	})
+	for _, step := range steps {
+		r, ok := i.relationships[step.ID]
+		if !ok {
+			return fmt.Errorf("can't import view %q: unknown relationship %q", v.Key, step.ID)
+		}
+		desc := step.Description
+		if desc == "" && r.Description() != nil {
+			desc = *r.Description()
+		}
+		view.Add(r.From(), r.To(), desc)
+	}
+	mergeElementPositions(view.Layout(), &v.View, i.elements)
+	for n, r := range view.Content().RelationShips() {
+		if len(steps[n].Vertices) > 0 {
+			view.Layout().WithVertices(r, positions(steps[n].Vertices)...)
+		}
+	}
+	i.viewsByKey[v.Key] = view
+	return nil
+}
+
+// viewElements returns the elements displayed by a view
+func (i *jsonImporter) viewElements(v schema.View) []gostructurizr.Namer {
+	var elements []gostructurizr.Namer
+	for _, e := range v.Elements {
+		if n, ok := i.elements[e.ID]; ok {
+			elements = append(elements, n)
+		}
+	}
+	return elements
+}
+
+func (i *jsonImporter) importLayout(layout *gostructurizr.LayoutNode, v schema.View) {
+	mergeViewLayout(layout, &v, i.elements, i.relationships)
+}
+
+func importStyles(styles *gostructurizr.StylesNode, doc schema.Styles) {
+	for _, e := range doc.Elements {
+		style := styles.AddElementStyle(tags.Tag(e.Tag))
+		if e.Width != nil {
+			style.WithWidth(*e.Width)
+		}
+		if e.Height != nil {
+			style.WithHeight(*e.Height)
+		}
+		if e.Background != "" {
+			style.WithBackground(e.Background)
+		}
+		if e.Stroke != "" {
+			style.WithStroke(e.Stroke)
+		}
+		if e.StrokeWidth != nil {
+			style.WithStrokeWidth(*e.StrokeWidth)
+		}
+		if e.Color != "" {
+			style.WithColor(e.Color)
+		}
+		if e.FontSize != nil {
+			style.WithFontSize(*e.FontSize)
+		}
+		if e.Shape != "" {
+			style.WithShape(shapes.Shape(e.Shape))
+		}
+		if e.Icon != "" {
+			style.WithIcon(e.Icon)
+		}
+		if e.Border != "" {
+			style.WithBorderStyle(gostructurizr.BorderStyle(e.Border))
+		}
+		if e.Opacity != nil {
+			style.WithOpacity(*e.Opacity)
+		}
+		if e.Metadata != nil {
+			style.WithMetadata(*e.Metadata)
+		}
+		if e.Description != nil {
+			style.WithDescription(*e.Description)
+		}
+	}
+	for _, r := range doc.Relationships {
+		style := styles.AddRelationshipStyle(tags.Tag(r.Tag))
+		if r.Thickness != nil {
+			style.WithThickness(*r.Thickness)
+		}
+		if r.Width != nil {
+			style.WithWidth(*r.Width)
+		}
+		if r.Color != "" {
+			style.WithColor(r.Color)
+		}
+		switch {
+		case r.Style != "":
+			style.WithLineStyle(gostructurizr.LineStyle(r.Style))
+		case r.Dashed != nil && *r.Dashed:
+			style.WithDashed()
+		}
+		if r.Routing != "" {
+			style.WithRouting(gostructurizr.RouteStyle(r.Routing))
+		}
+		if r.FontSize != nil {
+			style.WithFontSize(*r.FontSize)
+		}
+		if r.Position != nil {
+			style.WithPosition(*r.Position)
+		}
+		if r.Opacity != nil {
+			style.WithOpacity(*r.Opacity)
+		}
+	}
+}
+
+// addJSONTags adds the tags of a JSON element which are not set by default on the element
+func addJSONTags(n interface{}, value string) {
+	t, ok := n.(tagged)
+	if !ok {
+		return
+	}
+	defaults := map[string]bool{
+		tags.Element.String():        true,
+		tags.Person.String():         true,
+		tags.SoftwareSystem.String(): true,
+		tags.Container.String():      true,
+		tags.Component.String():      true,
+		tags.RelationShip.String():   true,
+	}
+	// tags the element already has, such as the one of its location, aren't added twice
+	for _, existing := range t.Tags().List() {
+		defaults[existing] = true
+	}
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" && !defaults[tag] {
+			t.Tags().Add(tag)
+		}
+	}
+}
+
+// mergeViewLayout copies the element positions and relationship vertices of a stored view
+func mergeViewLayout(layout *gostructurizr.LayoutNode, v *schema.View, elements map[string]gostructurizr.Namer, relationships map[string]*gostructurizr.RelationShipNode) {
+	if v == nil {
+		return
+	}
+	mergeElementPositions(layout, v, elements)
+	for _, r := range v.Relationships {
+		if rel, ok := relationships[r.ID]; ok && len(r.Vertices) > 0 {
+			layout.WithVertices(rel, positions(r.Vertices)...)
+		}
+	}
+}
+
+func mergeElementPositions(layout *gostructurizr.LayoutNode, v *schema.View, elements map[string]gostructurizr.Namer) {
+	for _, e := range v.Elements {
+		// elements never moved are stored without coordinates
+		if n, ok := elements[e.ID]; ok && (e.X != 0 || e.Y != 0) {
+			layout.WithElementPosition(n, e.X, e.Y)
+		}
+	}
+}
+
+// numericID returns the value of the numeric identifiers and orders used by Structurizr, 0 for any other
+func numericID(id string) int {
+	n, _ := strconv.Atoi(id)
+	return n
+}
+
+func positions(vertices []schema.Vertex) []gostructurizr.Position {
+	var p []gostructurizr.Position
+	for _, v := range vertices {
+		p = append(p, gostructurizr.Position{X: v.X, Y: v.Y})
+	}
+	return p
+}
+
+// viewsByKey indexes all the views of a JSON document, whatever their type
+func viewsByKey(doc schema.Views) map[string]*schema.View {
+	views := map[string]*schema.View{}
+	for n := range doc.SystemLandscapeViews {
+		views[doc.SystemLandscapeViews[n].Key] = &doc.SystemLandscapeViews[n].View
+	}
+	for n := range doc.SystemContextViews {
+		views[doc.SystemContextViews[n].Key] = &doc.SystemContextViews[n].View
+	}
+	for n := range doc.ContainerViews {
+		views[doc.ContainerViews[n].Key] = &doc.ContainerViews[n].View
+	}
+	for n := range doc.ComponentViews {
+		views[doc.ComponentViews[n].Key] = &doc.ComponentViews[n].View
+	}
+	for n := range doc.DynamicViews {
+		views[doc.DynamicViews[n].Key] = &doc.DynamicViews[n].View
+	}
+	for n := range doc.DeploymentViews {
+		views[doc.DeploymentViews[n].Key] = &doc.DeploymentViews[n].View
+	}
+	return views
+}
@@ -0,0 +1,228 @@
+package parser
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platelk/gostructurizr"
+	"github.com/platelk/gostructurizr/renderer"
+	"github.com/platelk/gostructurizr/shapes"
+	"github.com/platelk/gostructurizr/tags"
+)
+
+// layoutWorkspace builds the same workspace on every call, as a program regenerating its diagrams would
+func layoutWorkspace() *gostructurizr.WorkspaceNode {
+	w := gostructurizr.Workspace().WithName("Shop")
+	m := w.Model()
+	customer := m.AddPerson("Customer", "Buys things")
+	shop := m.AddSoftwareSystem("Shop", "Sells things")
+	payment := m.AddSoftwareSystem("Payment", "Takes the money").WithTag("External")
+	web := shop.AddContainer("Web", "Storefront", "Go")
+	db := shop.AddContainer("Database", "Stores orders", "PostgreSQL")
+	customer.Uses(web, "Browses").WithTechnology("HTTPS")
+	web.Uses(db, "Reads from and writes to")
+	web.Uses(payment, "Charges").WithInteractionStyle(gostructurizr.Asynchronous)
+	customer.Uses(shop, "Shops at")
+
+	prod := m.AddProdNode("Cloud", "Hosting", "AWS")
+	prod.AddContainerInstance(web)
+
+	views := w.Views()
+	views.CreateSystemContextView(shop).WithKey("Context").AddAllElements()
+	views.CreateContainerView(shop).AddAllElements()
+	views.CreateDeploymentView(shop, gostructurizr.ProductionEnvironment).AddDeploymentNode(prod)
+	views.CreateDynamicView(shop).WithKey("Checkout").Add(customer, web, "Checks out").Add(web, payment, "Charges")
+	views.Configuration().Styles().AddElementStyle(tags.Person).WithShape(shapes.Person)
+	return w
+}
+
+func renderJSON(t *testing.T, w *gostructurizr.WorkspaceNode) []byte {
+	buf := bytes.Buffer{}
+	require.NoError(t, renderer.NewJSONRenderer(&buf).Render(w))
+	return buf.Bytes()
+}
+
+func TestJSONParser_Parse(t *testing.T) {
+	original := layoutWorkspace()
+	context := original.Views().SystemContextViews()[0]
+	context.Layout().WithElementPosition(context.SoftwareSystem(), 400, 200)
+
+	w, err := NewJSONParser(bytes.NewReader(renderJSON(t, original))).Parse()
+	require.NoError(t, err)
+
+	assert.Equal(t, "Shop", *w.Name())
+	m := w.Model()
+	require.Len(t, m.Persons(), 1)
+	require.Len(t, m.SoftwareSystems(), 2)
+	shop, payment := m.SoftwareSystems()[0], m.SoftwareSystems()[1]
+	assert.Equal(t, []string{"External"}, payment.Tags().List())
+	require.Len(t, shop.Containers(), 2)
+	assert.Equal(t, "PostgreSQL", *shop.Containers()[1].Technology())
+
+	require.Len(t, m.RelationShip(), 4)
+	browses := m.RelationShip()[0]
+	assert.Equal(t, "Browses", *browses.Description())
+	assert.Equal(t, "HTTPS", *browses.Technology())
+	assert.Equal(t, gostructurizr.Asynchronous, *m.RelationShip()[2].InteractionStyle())
+
+	require.Len(t, m.DeploymentNodes(), 1)
+	require.Len(t, m.DeploymentNodes()[0].ContainerInstances(), 1)
+	assert.Equal(t, []string{tags.DeploymentNode.String()}, m.DeploymentNodes()[0].Tags().List())
+	assert.Equal(t, shop.Containers()[0], m.DeploymentNodes()[0].ContainerInstances()[0].Container())
+
+	views := w.Views()
+	require.Len(t, views.SystemContextViews(), 1)
+	imported := views.SystemContextViews()[0]
+	assert.Equal(t, "Context", *imported.Key())
+	assert.Len(t, imported.Content().Elements(), 2)
+	position, ok := imported.Layout().ElementPosition(shop)
+	require.True(t, ok)
+	assert.Equal(t, gostructurizr.Position{X: 400, Y: 200}, position)
+
+	require.Len(t, views.DynamicViews(), 1)
+	steps := views.DynamicViews()[0].Content().RelationShips()
+	require.Len(t, steps, 2)
+	assert.Equal(t, "Checks out", *steps[0].Description())
+	require.Len(t, views.DeploymentViews(), 1)
+	assert.Len(t, views.DeploymentViews()[0].Content().Elements(), 2)
+	require.Len(t, views.Configuration().Styles().ElementsStyle(), 1)
+}
+
+func TestJSONParser_MergeLayout(t *testing.T) {
+	laidOut := layoutWorkspace()
+	shop := laidOut.Model().SoftwareSystems()[0]
+	web := shop.Containers()[0]
+	browses := laidOut.Model().RelationShip()[0]
+	laidOut.Views().SystemContextViews()[0].Layout().WithElementPosition(shop, 100, 50)
+	laidOut.Views().ContainerViews()[0].Layout().
+		WithElementPosition(web, 300, 600).
+		WithVertices(browses, gostructurizr.Position{X: 150, Y: 400})
+	laidOut.Views().DeploymentViews()[0].Layout().WithElementPosition(laidOut.Model().DeploymentNodes()[0], 10, 20)
+	laidOut.Views().DynamicViews()[0].Layout().WithVertices(
+		laidOut.Views().DynamicViews()[0].Content().RelationShips()[1], gostructurizr.Position{X: 5, Y: 5})
+	stored := renderJSON(t, laidOut)
+
+	// the model evolved since the layout was done: a new element shifts all the identifiers
+	w := layoutWorkspace()
+	w.Model().AddPerson("Administrator", "Runs the shop")
+	require.NoError(t, NewJSONParser(bytes.NewReader(stored)).MergeLayout(w))
+
+	shop = w.Model().SoftwareSystems()[0]
+	web = shop.Containers()[0]
+	position, ok := w.Views().SystemContextViews()[0].Layout().ElementPosition(shop)
+	require.True(t, ok)
+	assert.Equal(t, gostructurizr.Position{X: 100, Y: 50}, position)
+
+	containers := w.Views().ContainerViews()[0].Layout()
+	position, ok = containers.ElementPosition(web)
+	require.True(t, ok)
+	assert.Equal(t, gostructurizr.Position{X: 300, Y: 600}, position)
+	assert.Equal(t, []gostructurizr.Position{{X: 150, Y: 400}}, containers.Vertices(w.Model().RelationShip()[0]))
+	_, ok = containers.ElementPosition(shop.Containers()[1])
+	assert.False(t, ok)
+
+	position, ok = w.Views().DeploymentViews()[0].Layout().ElementPosition(w.Model().DeploymentNodes()[0])
+	require.True(t, ok)
+	assert.Equal(t, gostructurizr.Position{X: 10, Y: 20}, position)
+
+	dynamic := w.Views().DynamicViews()[0]
+	assert.Equal(t, []gostructurizr.Position{{X: 5, Y: 5}}, dynamic.Layout().Vertices(dynamic.Content().RelationShips()[1]))
+	assert.Empty(t, dynamic.Layout().Vertices(dynamic.Content().RelationShips()[0]))
+
+	// the merged layout is written back on the next export
+	assert.Contains(t, string(renderJSON(t, w)), `"x": 300`)
+}
+
+func TestJSONParser_Parse_invalid(t *testing.T) {
+	_, err := NewJSONParser(bytes.NewReader([]byte(`{"model": [}`))).Parse()
+	assert.Error(t, err)
+
+	_, err = NewJSONParser(bytes.NewReader([]byte(`{"model": {"people": [{"id": "1", "name": "A", "relationships": [{"id": "2", "sourceId": "1", "destinationId": "3"}]}]}}`))).Parse()
+	assert.EqualError(t, err, `can't import relationship 2: unknown destination "3"`)
+}
+
+func TestJSONParser_Parse_impliedRelationships(t *testing.T) {
+	w := gostructurizr.Workspace()
+	w.Model().WithImpliedRelationships(gostructurizr.CreateImpliedRelationshipsUnlessAnyExist)
+	customer := w.Model().AddPerson("Customer", "")
+	customer.Uses(w.Model().AddSoftwareSystem("Shop", "").AddContainer("Web", "", ""), "Browses")
+	doc := bytes.Buffer{}
+	require.NoError(t, renderer.NewJSONRenderer(&doc).Render(w))
+
+	imported, err := NewJSONParser(&doc).Parse()
+	require.NoError(t, err)
+	require.Len(t, imported.Model().RelationShip(), 2)
+	assert.False(t, imported.Model().RelationShip()[0].IsImplied())
+	assert.Equal(t, imported.Model().RelationShip()[0], imported.Model().RelationShip()[1].ImpliedBy())
+}
+
+func TestJSONParser_Parse_relationships(t *testing.T) {
+	w := gostructurizr.Workspace()
+	customer := w.Model().AddPerson("Customer", "")
+	customer.Uses(w.Model().AddSoftwareSystem("Shop", ""), "Buys from").
+		WithInteractionStyle(gostructurizr.Asynchronous).
+		WithTag("Critical").
+		WithURL("https://wiki.example.com/shop").
+		WithPerspective(gostructurizr.Perspective{Name: "Security", Description: "Authenticated customers"}).
+		Properties().Add("owner", "Team Shop")
+	doc := bytes.Buffer{}
+	require.NoError(t, renderer.NewJSONRenderer(&doc).Render(w))
+	assert.Contains(t, doc.String(), `"tags": "relationship,asynchronous,Critical"`)
+
+	imported, err := NewJSONParser(&doc).Parse()
+	require.NoError(t, err)
+	r := imported.Model().RelationShip()[0]
+	assert.Equal(t, []string{"asynchronous", "Critical"}, r.Tags().List())
+	assert.Equal(t, "https://wiki.example.com/shop", *r.URL())
+	assert.Equal(t, "Team Shop", r.Properties().Get("owner"))
+	assert.Equal(t, []gostructurizr.Perspective{{Name: "Security", Description: "Authenticated customers"}}, r.Perspectives())
+}
+
+func TestJSONParser_Parse_groups(t *testing.T) {
+	w := gostructurizr.Workspace()
+	m := w.Model()
+	customer := m.AddPerson("Customer", "")
+	shop := m.AddSoftwareSystem("Shop", "")
+	web := shop.AddContainer("Web", "", "")
+	m.AddGroup("Sales").AddGroup("EMEA").Add(customer)
+	shop.AddGroup("Frontend").Add(web)
+	doc := bytes.Buffer{}
+	require.NoError(t, renderer.NewJSONRenderer(&doc).Render(w))
+	assert.Contains(t, doc.String(), `"group": "Sales/EMEA"`)
+
+	imported, err := NewJSONParser(&doc).Parse()
+	require.NoError(t, err)
+	im := imported.Model()
+	require.Len(t, im.Groups(), 1)
+	assert.Equal(t, "Sales/EMEA", im.GroupOf(im.Persons()[0]).FullName())
+	assert.Nil(t, im.GroupOf(im.SoftwareSystems()[0]))
+	importedShop := im.SoftwareSystems()[0]
+	assert.Equal(t, "Frontend", importedShop.GroupOf(importedShop.Containers()[0]).FullName())
+}
+
+func TestJSONParser_Parse_enterprise(t *testing.T) {
+	w := gostructurizr.Workspace()
+	m := w.Model()
+	enterprise := m.SetEnterprise("ACME")
+	shop := m.AddSoftwareSystem("Shop", "")
+	m.AddSoftwareSystem("Payment", "").WithTag("Partner")
+	enterprise.Add(shop)
+	w.Views().CreateSystemLandscapeView().WithKey("Landscape").AddAllElements().WithoutEnterpriseBoundary()
+	w.Views().CreateSystemContextView(shop).WithKey("Context").AddAllElements()
+	doc := bytes.Buffer{}
+	require.NoError(t, renderer.NewJSONRenderer(&doc).Render(w))
+	assert.Contains(t, doc.String(), `"tags": "Element,Software system,Internal"`)
+	assert.Contains(t, doc.String(), `"tags": "Element,Software system,External,Partner"`)
+	assert.Contains(t, doc.String(), `"enterpriseBoundaryVisible": false`)
+
+	imported, err := NewJSONParser(&doc).Parse()
+	require.NoError(t, err)
+	systems := imported.Model().SoftwareSystems()
+	assert.True(t, imported.Model().Enterprise().Contains(systems[0]))
+	assert.Equal(t, []string{"External", "Partner"}, systems[1].Tags().List())
+	assert.False(t, imported.Views().SystemLandscapeViews()[0].IsEnterpriseBoundaryVisible())
+	assert.True(t, imported.Views().SystemContextViews()[0].IsEnterpriseBoundaryVisible())
+}
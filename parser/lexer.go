@@ -0,0 +1,170 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenOpenBracket
+	tokenCloseBracket
+	tokenArrow
+	tokenEqual
+	tokenNewLine
+	tokenEOF
+)
+
+// token is a single lexical unit of the DSL together with its position in the source.
+// adjacent is true when no whitespace separates the token from the previous one,
+// which is how `->x` (an expression) is told apart from `a -> b` (a relationship).
+type token struct {
+	kind         tokenKind
+	value        string
+	line, column int
+	adjacent     bool
+}
+
+type lexer struct {
+	src          []rune
+	pos          int
+	line, column int
+	tokens       []token
+	lineStart    bool
+	adjacent     bool
+}
+
+// lex splits the DSL source into tokens. Comments and line continuations are
+// dropped, newlines are kept because DSL statements are line based.
+func lex(src string) ([]token, error) {
+	l := &lexer{
+		src:       []rune(src),
+		line:      1,
+		column:    1,
+		lineStart: true,
+	}
+	for l.pos < len(l.src) {
+		if err := l.scan(); err != nil {
+			return nil, err
+		}
+	}
+	l.emit(tokenEOF, "", l.line, l.column)
+	return l.tokens, nil
+}
+
+func (l *lexer) peek(offset int) rune {
+	if l.pos+offset >= len(l.src) {
+		return 0
+	}
+	return l.src[l.pos+offset]
+}
+
+func (l *lexer) advance() rune {
+	r := l.src[l.pos]
+	l.pos++
+	if r == '\n' {
+		l.line++
+		l.column = 1
+	} else {
+		l.column++
+	}
+	return r
+}
+
+func (l *lexer) emit(kind tokenKind, value string, line, column int) {
+	l.tokens = append(l.tokens, token{kind: kind, value: value, line: line, column: column, adjacent: l.adjacent})
+	l.lineStart = kind == tokenNewLine
+	l.adjacent = true
+}
+
+func (l *lexer) scan() error {
+	line, column := l.line, l.column
+	r := l.peek(0)
+	switch {
+	case r == '\n':
+		l.advance()
+		l.emit(tokenNewLine, "", line, column)
+		l.adjacent = false
+	case r == '\\' && (l.peek(1) == '\n' || (l.peek(1) == '\r' && l.peek(2) == '\n')):
+		// line continuation
+		for l.peek(0) != '\n' {
+			l.advance()
+		}
+		l.advance()
+		l.adjacent = false
+	case unicode.IsSpace(r):
+		l.advance()
+		l.adjacent = false
+	case r == '/' && l.peek(1) == '/', r == '#' && l.lineStart:
+		for l.pos < len(l.src) && l.peek(0) != '\n' {
+			l.advance()
+		}
+	case r == '/' && l.peek(1) == '*':
+		l.advance()
+		l.advance()
+		for !(l.peek(0) == '*' && l.peek(1) == '/') {
+			if l.pos >= len(l.src) {
+				return newError(line, column, "unterminated comment")
+			}
+			l.advance()
+		}
+		l.advance()
+		l.advance()
+		l.adjacent = false
+	case r == '{':
+		l.advance()
+		l.emit(tokenOpenBracket, "{", line, column)
+	case r == '}':
+		l.advance()
+		l.emit(tokenCloseBracket, "}", line, column)
+	case r == '=':
+		l.advance()
+		l.emit(tokenEqual, "=", line, column)
+	case r == '-' && l.peek(1) == '>':
+		l.advance()
+		l.advance()
+		l.emit(tokenArrow, "->", line, column)
+	case r == '"':
+		return l.scanString()
+	default:
+		l.scanWord()
+	}
+	return nil
+}
+
+func (l *lexer) scanString() error {
+	line, column := l.line, l.column
+	l.advance()
+	var b strings.Builder
+	for {
+		if l.pos >= len(l.src) || l.peek(0) == '\n' {
+			return newError(line, column, "unterminated string")
+		}
+		r := l.advance()
+		if r == '"' {
+			break
+		}
+		if r == '\\' && (l.peek(0) == '"' || l.peek(0) == '\\') {
+			r = l.advance()
+		}
+		b.WriteRune(r)
+	}
+	l.emit(tokenString, b.String(), line, column)
+	return nil
+}
+
+func (l *lexer) scanWord() {
+	line, column := l.line, l.column
+	var b strings.Builder
+	for l.pos < len(l.src) {
+		r := l.peek(0)
+		if unicode.IsSpace(r) || r == '{' || r == '}' || r == '"' || r == '=' || (r == '-' && l.peek(1) == '>') {
+			break
+		}
+		b.WriteRune(l.advance())
+	}
+	l.emit(tokenWord, b.String(), line, column)
+}
@@ -0,0 +1,482 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/platelk/gostructurizr"
+)
+
+// user is implemented by every element that can be the source of a relationship
+type user interface {
+	gostructurizr.Namer
+	Uses(to gostructurizr.Namer, desc string) *gostructurizr.RelationShipNode
+}
+
+// tagged is implemented by every element carrying tags
+type tagged interface {
+	Tags() *gostructurizr.TagsNode
+}
+
+func (p *parser) parseModel(s *statement) error {
+	if !s.block {
+		return errorAt(s.start, "expected %q after model", "{")
+	}
+	return p.parseBlock(s.start, func(s *statement) error {
+		return p.parseModelItem(s, nil, "")
+	})
+}
+
+// parseModelItem parses a statement found in the model or in the block of an element.
+// parent is the element owning the block, nil at the model level, and parentID its identifier.
+func (p *parser) parseModelItem(s *statement, parent gostructurizr.Namer, parentID string) error {
+	identifier, s, err := assignment(s)
+	if err != nil {
+		return err
+	}
+	for _, t := range s.tokens {
+		if t.kind == tokenArrow {
+			return p.parseRelationship(s, parent)
+		}
+	}
+
+	switch s.keyword() {
+	case "person", "softwaresystem":
+		if parent != nil {
+			return errorAt(s.start, "%s must be defined at the model level", s.tokens[0].value)
+		}
+		args, err := s.args(1)
+		if err != nil {
+			return err
+		}
+		if len(args) < 1 || len(args) > 3 {
+			return errorAt(s.start, "expected: %s <name> [description] [tags]", s.tokens[0].value)
+		}
+		var element gostructurizr.LandscapeElement
+		if s.keyword() == "person" {
+			element = p.workspace.Model().AddPerson(args[0], argAt(args, 1))
+		} else {
+			element = p.workspace.Model().AddSoftwareSystem(args[0], argAt(args, 1))
+		}
+		addTags(element, argAt(args, 2))
+		if enterprise := p.workspace.Model().Enterprise(); p.inEnterprise && enterprise != nil {
+			enterprise.Add(element)
+		}
+		if p.modelGroup != nil {
+			p.modelGroup.Add(element)
+		}
+		return p.parseElementBlock(s, identifier, parentID, element)
+	case "container":
+		system, ok := parent.(*gostructurizr.SoftwareSystemNode)
+		if !ok {
+			return errorAt(s.start, "container must be defined inside a software system")
+		}
+		args, err := s.args(1)
+		if err != nil {
+			return err
+		}
+		if len(args) < 1 || len(args) > 4 {
+			return errorAt(s.start, "expected: container <name> [description] [technology] [tags]")
+		}
+		container := system.AddContainer(args[0], argAt(args, 1), argAt(args, 2))
+		addTags(container, argAt(args, 3))
+		if p.containerGroup != nil {
+			p.containerGroup.Add(container)
+		}
+		return p.parseElementBlock(s, identifier, parentID, container)
+	case "component":
+		container, ok := parent.(*gostructurizr.ContainerNode)
+		if !ok {
+			return errorAt(s.start, "component must be defined inside a container")
+		}
+		args, err := s.args(1)
+		if err != nil {
+			return err
+		}
+		if len(args) < 1 || len(args) > 4 {
+			return errorAt(s.start, "expected: component <name> [description] [technology] [tags]")
+		}
+		component := container.AddComponent(args[0])
+		if len(args) > 1 {
+			component.WithDesc(args[1])
+		}
+		if len(args) > 2 {
+			component.WithTechnology(args[2])
+		}
+		addTags(component, argAt(args, 3))
+		if p.componentGroup != nil {
+			p.componentGroup.Add(component)
+		}
+		return p.parseElementBlock(s, identifier, parentID, component)
+	case "enterprise":
+		if parent != nil {
+			return errorAt(s.start, "enterprise must be defined at the model level")
+		}
+		args, err := s.args(1)
+		if err != nil {
+			return err
+		}
+		if len(args) != 1 || !s.block {
+			return errorAt(s.start, "expected: enterprise <name> {")
+		}
+		p.workspace.Model().SetEnterprise(args[0])
+		p.inEnterprise = true
+		defer func() { p.inEnterprise = false }()
+		return p.parseBlock(s.start, func(s *statement) error {
+			return p.parseModelItem(s, nil, "")
+		})
+	case "group":
+		return p.parseGroup(s, parent, parentID)
+	case "deploymentenvironment":
+		if parent != nil {
+			return errorAt(s.start, "deploymentEnvironment must be defined at the model level")
+		}
+		return p.parseDeploymentEnvironment(s, identifier)
+	case "properties":
+		if parent != nil {
+			break
+		}
+		return p.parseProperties(s, p.workspace.Model().Properties())
+	case "!impliedrelationships":
+		return p.parseImpliedRelationships(s)
+	case "!docs", "!adrs":
+		return p.skip(s)
+	}
+	if parent != nil && identifier == "" {
+		return p.parseElementAttribute(s, parent)
+	}
+	return errorAt(s.start, "unexpected %q", s.tokens[0].value)
+}
+
+// assignment splits `<identifier> = <statement>`, returning an empty identifier when the statement assigns none
+func assignment(s *statement) (string, *statement, error) {
+	if len(s.tokens) <= 2 || s.tokens[1].kind != tokenEqual {
+		return "", s, nil
+	}
+	if s.tokens[0].kind != tokenWord {
+		return "", nil, errorAt(s.tokens[0], "invalid identifier %q", s.tokens[0].value)
+	}
+	return s.tokens[0].value, &statement{tokens: s.tokens[2:], start: s.tokens[2], block: s.block}, nil
+}
+
+// parseGroup parses a group of the model, of a software system or of a container, nested in the group being parsed if any.
+// Elements defined in its block join the group of their level.
+func (p *parser) parseGroup(s *statement, parent gostructurizr.Namer, parentID string) error {
+	args, err := s.args(1)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 || !s.block {
+		return errorAt(s.start, "expected: group <name> {")
+	}
+	switch e := parent.(type) {
+	case nil:
+		enclosing := p.modelGroup
+		p.modelGroup = subGroup(enclosing, p.workspace.Model().Groups(), p.workspace.Model().AddGroup, args[0])
+		defer func() { p.modelGroup = enclosing }()
+	case *gostructurizr.SoftwareSystemNode:
+		enclosing := p.containerGroup
+		p.containerGroup = subGroup(enclosing, e.Groups(), e.AddGroup, args[0])
+		defer func() { p.containerGroup = enclosing }()
+	case *gostructurizr.ContainerNode:
+		enclosing := p.componentGroup
+		p.componentGroup = subGroup(enclosing, e.Groups(), e.AddGroup, args[0])
+		defer func() { p.componentGroup = enclosing }()
+	default:
+		return errorAt(s.start, "group must be defined in the model, a software system or a container")
+	}
+	return p.parseBlock(s.start, func(s *statement) error {
+		return p.parseModelItem(s, parent, parentID)
+	})
+}
+
+// subGroup returns the group named name in enclosing, or among the top level groups when enclosing is nil.
+// Groups sharing a name are the same group, the missing one is created with add.
+func subGroup[K gostructurizr.Namer](enclosing *gostructurizr.GroupNode[K], groups []*gostructurizr.GroupNode[K],
+	add func(string) *gostructurizr.GroupNode[K], name string) *gostructurizr.GroupNode[K] {
+	if enclosing != nil {
+		groups, add = enclosing.Groups(), enclosing.AddGroup
+	}
+	for _, g := range groups {
+		if g.Name() == name {
+			return g
+		}
+	}
+	return add(name)
+}
+
+// parseElementBlock registers the identifier of a freshly created element and parses its block if any
+func (p *parser) parseElementBlock(s *statement, identifier, parentID string, element gostructurizr.Namer) error {
+	id, err := p.register(s, identifier, parentID, element)
+	if err != nil {
+		return err
+	}
+	if !s.block {
+		return nil
+	}
+	return p.parseBlock(s.start, func(s *statement) error {
+		return p.parseModelItem(s, element, id)
+	})
+}
+
+// register makes element reachable through its identifier and returns the full identifier,
+// which is prefixed by the identifier of the parent in hierarchical mode.
+// The identifier is pinned on the workspace, so the element keeps it when rendered back.
+func (p *parser) register(s *statement, identifier, parentID string, element gostructurizr.Namer) (string, error) {
+	if identifier == "" {
+		return "", nil
+	}
+	p.workspace.Identifiers().Pin(element, identifier)
+	if p.hierarchical && parentID != "" {
+		identifier = parentID + "." + identifier
+	}
+	if _, ok := p.identifiers[identifier]; ok || identifier == "this" {
+		return "", errorAt(s.start, "identifier %q is already in use", identifier)
+	}
+	p.identifiers[identifier] = element
+	return identifier, nil
+}
+
+// lookup resolves an identifier, current being the element referenced by `this`
+func (p *parser) lookup(t token, current gostructurizr.Namer) (gostructurizr.Namer, error) {
+	if t.kind != tokenWord {
+		return nil, errorAt(t, "expected an identifier, got %q", t.value)
+	}
+	if t.value == "this" && current != nil {
+		return current, nil
+	}
+	element, ok := p.identifiers[t.value]
+	if !ok {
+		return nil, errorAt(t, "unknown identifier %q", t.value)
+	}
+	return element, nil
+}
+
+func (p *parser) parseElementAttribute(s *statement, element gostructurizr.Namer) error {
+	switch s.keyword() {
+	case "description":
+		args, err := s.args(1)
+		if err != nil {
+			return err
+		}
+		if len(args) != 1 {
+			return errorAt(s.start, "expected: description <description>")
+		}
+		switch e := element.(type) {
+		case *gostructurizr.PersonNode:
+			e.WithDesc(args[0])
+		case *gostructurizr.SoftwareSystemNode:
+			e.WithDesc(args[0])
+		case *gostructurizr.ContainerNode:
+			e.WithDesc(args[0])
+		case *gostructurizr.ComponentNode:
+			e.WithDesc(args[0])
+		case *gostructurizr.DeploymentNodeNode:
+			e.WithDesc(args[0])
+		case *gostructurizr.InfrastructureNodeNode:
+			e.WithDesc(args[0])
+		}
+		return noBlock(s)
+	case "technology":
+		args, err := s.args(1)
+		if err != nil {
+			return err
+		}
+		if len(args) != 1 {
+			return errorAt(s.start, "expected: technology <technology>")
+		}
+		switch e := element.(type) {
+		case *gostructurizr.ContainerNode:
+			e.WithTechnology(args[0])
+		case *gostructurizr.ComponentNode:
+			e.WithTechnology(args[0])
+		case *gostructurizr.DeploymentNodeNode:
+			e.WithTechnology(args[0])
+		case *gostructurizr.InfrastructureNodeNode:
+			e.WithTechnology(args[0])
+		default:
+			return errorAt(s.start, "technology is not supported on %s", element.Name())
+		}
+		return noBlock(s)
+	case "tags":
+		args, err := s.args(1)
+		if err != nil {
+			return err
+		}
+		addTags(element, args...)
+		return noBlock(s)
+	case "properties":
+		if e, ok := element.(propertied); ok {
+			return p.parseProperties(s, e.Properties())
+		}
+		return p.skip(s)
+	// elements carry no url nor perspectives, they are read over
+	case "url", "perspectives", "!docs", "!adrs":
+		return p.skip(s)
+	}
+	return errorAt(s.start, "unexpected %q", s.tokens[0].value)
+}
+
+func (p *parser) parseRelationship(s *statement, parent gostructurizr.Namer) error {
+	tokens := s.tokens
+	var from gostructurizr.Namer
+	i := 0
+	if tokens[0].kind == tokenArrow {
+		if parent == nil {
+			return errorAt(tokens[0], "relationship without source outside of an element")
+		}
+		from = parent
+		i = 1
+	} else {
+		source, err := p.lookup(tokens[0], parent)
+		if err != nil {
+			return err
+		}
+		if len(tokens) < 2 || tokens[1].kind != tokenArrow {
+			return errorAt(tokens[0], "expected: <identifier> -> <identifier> [description] [technology] [tags]")
+		}
+		from = source
+		i = 2
+	}
+	if i >= len(tokens) {
+		return errorAt(tokens[i-1], "missing relationship destination")
+	}
+	to, err := p.lookup(tokens[i], parent)
+	if err != nil {
+		return err
+	}
+	args, err := s.args(i + 1)
+	if err != nil {
+		return err
+	}
+	if len(args) > 3 {
+		return errorAt(tokens[i+4], "too many arguments, expected: [description] [technology] [tags]")
+	}
+	source, ok := from.(user)
+	if !ok {
+		return errorAt(tokens[0], "%s can't be the source of a relationship", from.Name())
+	}
+	r := source.Uses(to, argAt(args, 0))
+	if len(args) > 1 && args[1] != "" {
+		r.WithTechnology(args[1])
+	}
+	if len(args) > 2 {
+		addRelationshipTags(r, args[2])
+	}
+	if !s.block {
+		return nil
+	}
+	return p.parseBlock(s.start, func(s *statement) error {
+		switch s.keyword() {
+		case "tags":
+			args, err := s.args(1)
+			if err != nil {
+				return err
+			}
+			addRelationshipTags(r, args...)
+			return noBlock(s)
+		case "url":
+			args, err := s.args(1)
+			if err != nil {
+				return err
+			}
+			if len(args) != 1 {
+				return errorAt(s.start, "expected: url <url>")
+			}
+			r.WithURL(args[0])
+			return noBlock(s)
+		case "properties":
+			return p.parseProperties(s, r.Properties())
+		case "perspectives":
+			return p.parsePerspectives(s, r)
+		case "technology":
+			args, err := s.args(1)
+			if err != nil {
+				return err
+			}
+			if len(args) != 1 {
+				return errorAt(s.start, "expected: technology <technology>")
+			}
+			r.WithTechnology(args[0])
+			return noBlock(s)
+		case "description":
+			args, err := s.args(1)
+			if err != nil {
+				return err
+			}
+			if len(args) != 1 {
+				return errorAt(s.start, "expected: description <description>")
+			}
+			r.WithDescription(args[0])
+			return noBlock(s)
+		}
+		return errorAt(s.start, "unexpected %q in relationship", s.tokens[0].value)
+	})
+}
+
+// addRelationshipTags adds tags to a relationship, the tag of an interaction style setting it
+func addRelationshipTags(r *gostructurizr.RelationShipNode, values ...string) {
+	addTags(r, values...)
+	for _, style := range []gostructurizr.InteractionStyle{gostructurizr.Synchronous, gostructurizr.Asynchronous} {
+		if r.Tags().Contains(style.Tag().String()) {
+			r.WithInteractionStyle(style)
+		}
+	}
+}
+
+// parseProperties reads a properties block, one name and value per line
+func (p *parser) parseProperties(s *statement, properties *gostructurizr.Properties) error {
+	if !s.block {
+		return errorAt(s.start, "expected: properties {")
+	}
+	return p.parseBlock(s.start, func(s *statement) error {
+		args, err := s.args(0)
+		if err != nil {
+			return err
+		}
+		if len(args) != 2 {
+			return errorAt(s.start, "expected: <name> <value>")
+		}
+		properties.Add(args[0], args[1])
+		return noBlock(s)
+	})
+}
+
+// parsePerspectives reads the perspectives block of a relationship, one name, description and optional value per line
+func (p *parser) parsePerspectives(s *statement, r *gostructurizr.RelationShipNode) error {
+	if !s.block {
+		return errorAt(s.start, "expected: perspectives {")
+	}
+	return p.parseBlock(s.start, func(s *statement) error {
+		args, err := s.args(0)
+		if err != nil {
+			return err
+		}
+		if len(args) < 2 || len(args) > 3 {
+			return errorAt(s.start, "expected: <name> <description> [value]")
+		}
+		r.WithPerspective(gostructurizr.Perspective{Name: args[0], Description: args[1], Value: argAt(args, 2)})
+		return noBlock(s)
+	})
+}
+
+// addTags adds comma separated tags to an element or a relationship
+func addTags(element interface{}, values ...string) {
+	t, ok := element.(tagged)
+	if !ok {
+		return
+	}
+	for _, value := range values {
+		for _, tag := range strings.Split(value, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				t.Tags().Add(tag)
+			}
+		}
+	}
+}
+
+func argAt(args []string, i int) string {
+	if i < len(args) {
+		return args[i]
+	}
+	return ""
+}
@@ -0,0 +1,275 @@
+// Package parser reads Structurizr DSL and builds the corresponding gostructurizr workspace.
+package parser
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/platelk/gostructurizr"
+	"github.com/platelk/gostructurizr/dsl"
+)
+
+type DSLParser struct {
+	reader io.Reader
+}
+
+func NewDSLParser(reader io.Reader) *DSLParser {
+	return &DSLParser{
+		reader: reader,
+	}
+}
+
+// Parse reads the whole DSL document and returns the workspace it describes.
+// Syntax errors and references to unknown identifiers are reported as *Error.
+func (p *DSLParser) Parse() (*gostructurizr.WorkspaceNode, error) {
+	src, err := io.ReadAll(p.reader)
+	if err != nil {
+		return nil, fmt.Errorf("can't read dsl: %w", err)
+	}
+	tokens, err := lex(string(src))
+	if err != nil {
+		return nil, err
+	}
+	state := &parser{
+		tokens:       tokens,
+		identifiers:  map[string]gostructurizr.Namer{},
+		environments: map[string]gostructurizr.DeploymentEnvironment{},
+		viewsByKey:   map[string]gostructurizr.Viewable{},
+	}
+	return state.parseDocument()
+}
+
+// parser holds the state of a single parse
+type parser struct {
+	tokens       []token
+	pos          int
+	workspace    *gostructurizr.WorkspaceNode
+	identifiers  map[string]gostructurizr.Namer
+	hierarchical bool
+	inEnterprise bool
+	viewsByKey   map[string]gostructurizr.Viewable
+	// environments are referenced by their identifier or their name in deployment views
+	environments map[string]gostructurizr.DeploymentEnvironment
+	// groups being parsed, new elements joining the one of their level
+	modelGroup     *gostructurizr.GroupNode[gostructurizr.LandscapeElement]
+	containerGroup *gostructurizr.GroupNode[*gostructurizr.ContainerNode]
+	componentGroup *gostructurizr.GroupNode[*gostructurizr.ComponentNode]
+}
+
+// statement is one line of DSL. block is true when the line ends with an opening bracket
+// and close is true when the line is a closing bracket.
+type statement struct {
+	tokens []token
+	start  token
+	block  bool
+	close  bool
+}
+
+// keyword returns the lower-cased first token, DSL keywords being case-insensitive
+func (s *statement) keyword() string {
+	if len(s.tokens) == 0 {
+		return ""
+	}
+	return strings.ToLower(s.tokens[0].value)
+}
+
+// args returns the values of the tokens following the keyword, which must all be words or strings
+func (s *statement) args(from int) ([]string, error) {
+	var values []string
+	for _, t := range s.tokens[from:] {
+		if t.kind != tokenWord && t.kind != tokenString {
+			return nil, errorAt(t, "unexpected %q", t.value)
+		}
+		values = append(values, t.value)
+	}
+	return values, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) nextStatement() *statement {
+	for p.peek().kind == tokenNewLine {
+		p.pos++
+	}
+	start := p.peek()
+	if start.kind == tokenEOF {
+		return nil
+	}
+	if start.kind == tokenCloseBracket {
+		p.pos++
+		return &statement{start: start, close: true}
+	}
+	s := &statement{start: start}
+	for {
+		t := p.peek()
+		switch t.kind {
+		case tokenNewLine, tokenEOF, tokenCloseBracket:
+			return s
+		case tokenOpenBracket:
+			p.pos++
+			s.block = true
+			return s
+		}
+		s.tokens = append(s.tokens, t)
+		p.pos++
+	}
+}
+
+// parseBlock calls handle for every statement until the closing bracket of the current block
+func (p *parser) parseBlock(open token, handle func(s *statement) error) error {
+	for {
+		s := p.nextStatement()
+		if s == nil {
+			return errorAt(open, "missing %q for block opened here", "}")
+		}
+		if s.close {
+			return nil
+		}
+		if len(s.tokens) == 0 {
+			return errorAt(s.start, "unexpected %q", s.start.value)
+		}
+		if err := handle(s); err != nil {
+			return err
+		}
+	}
+}
+
+// skip ignores a statement and, when it opens one, its whole block
+func (p *parser) skip(s *statement) error {
+	if !s.block {
+		return nil
+	}
+	return p.parseBlock(s.start, p.skip)
+}
+
+// noBlock reports an error when a statement that doesn't accept a block opens one
+func noBlock(s *statement) error {
+	if s.block {
+		return errorAt(s.start, "%s doesn't accept a block", s.tokens[0].value)
+	}
+	return nil
+}
+
+func (p *parser) parseDocument() (*gostructurizr.WorkspaceNode, error) {
+	s := p.nextStatement()
+	if s == nil || s.close || s.keyword() != "workspace" {
+		t := p.peek()
+		if s != nil {
+			t = s.start
+		}
+		return nil, errorAt(t, "expected workspace")
+	}
+	if err := p.parseWorkspace(s); err != nil {
+		return nil, err
+	}
+	if s := p.nextStatement(); s != nil {
+		return nil, errorAt(s.start, "unexpected %q after workspace", s.start.value)
+	}
+	return p.workspace, nil
+}
+
+func (p *parser) parseWorkspace(s *statement) error {
+	p.workspace = gostructurizr.Workspace()
+	// Structurizr creates implied relationships unless !impliedRelationships says otherwise
+	p.workspace.Model().WithImpliedRelationships(gostructurizr.CreateImpliedRelationshipsUnlessAnyExist)
+	args, err := s.args(1)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 && strings.ToLower(args[0]) == "extends" {
+		if len(args) != 2 {
+			return errorAt(s.start, "expected: workspace extends <file|url>")
+		}
+		p.workspace.WithExtend(args[1])
+	} else {
+		if len(args) > 2 {
+			return errorAt(s.tokens[3], "too many arguments, expected: workspace [name] [description]")
+		}
+		if len(args) > 0 && args[0] != "" {
+			p.workspace.WithName(args[0])
+		}
+		if len(args) > 1 {
+			p.workspace.WithDesc(args[1])
+		}
+	}
+	if !s.block {
+		return errorAt(s.start, "expected %q after workspace", "{")
+	}
+	return p.parseBlock(s.start, func(s *statement) error {
+		switch s.keyword() {
+		case "name", "description":
+			args, err := s.args(1)
+			if err != nil {
+				return err
+			}
+			if len(args) != 1 {
+				return errorAt(s.start, "expected: %s <value>", s.keyword())
+			}
+			if s.keyword() == "name" {
+				p.workspace.WithName(args[0])
+			} else {
+				p.workspace.WithDesc(args[0])
+			}
+			return noBlock(s)
+		case "model":
+			return p.parseModel(s)
+		case "views":
+			return p.parseViews(s)
+		case "!identifiers":
+			return p.parseIdentifiers(s)
+		case "!impliedrelationships":
+			return p.parseImpliedRelationships(s)
+		case "!docs", "!adrs", "!constant", "!const", "!var", "configuration", "properties":
+			return p.skip(s)
+		}
+		return errorAt(s.start, "unexpected %q in workspace", s.tokens[0].value)
+	})
+}
+
+// parseImpliedRelationships sets the strategy applied to the relationships parsed afterwards,
+// from true, false or the name of a Structurizr strategy
+func (p *parser) parseImpliedRelationships(s *statement) error {
+	args, err := s.args(1)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return errorAt(s.start, "expected: !impliedRelationships <true|false|strategy>")
+	}
+	var strategy gostructurizr.ImpliedRelationshipsStrategy
+	switch args[0] {
+	case "false":
+		strategy = gostructurizr.NoImpliedRelationships
+	case "true", dsl.ImpliedRelationshipsUnlessAnyExist:
+		strategy = gostructurizr.CreateImpliedRelationshipsUnlessAnyExist
+	case dsl.ImpliedRelationshipsUnlessSameExist, dsl.ImpliedRelationshipsDefault:
+		strategy = gostructurizr.CreateImpliedRelationshipsUnlessSameDescription
+	default:
+		return errorAt(s.tokens[1], "unknown implied relationships strategy %q", args[0])
+	}
+	p.workspace.Model().WithImpliedRelationships(strategy)
+	return noBlock(s)
+}
+
+func (p *parser) parseIdentifiers(s *statement) error {
+	args, err := s.args(1)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return errorAt(s.start, "expected: !identifiers <flat|hierarchical>")
+	}
+	switch strings.ToLower(args[0]) {
+	case "flat":
+		p.hierarchical = false
+	case "hierarchical":
+		p.hierarchical = true
+		p.workspace.Identifiers().WithHierarchical()
+	default:
+		return errorAt(s.tokens[1], "unknown identifier mode %q", args[0])
+	}
+	return noBlock(s)
+}
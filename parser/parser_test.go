@@ -0,0 +1,707 @@
+package parser
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platelk/gostructurizr"
+	"github.com/platelk/gostructurizr/renderer"
+)
+
+const bigBankDSL = `workspace "Big Bank plc" "An example workspace" {
+    /* the model
+       of the bank */
+    model {
+        customer = person "Personal Banking Customer" "A customer of the bank." "Customer"
+        internetBanking = softwareSystem "Internet Banking System" {
+            description "Allows customers to view their accounts."
+            web = container "Web Application" "Delivers content" "Java and Spring MVC"
+            api = container "API Application" "Provides functionality via API" {
+                technology "Java and Spring Boot"
+                signin = component "Sign In Controller" "Allows users to sign in" "Spring MVC Controller"
+                -> web "Redirects to"
+            }
+        }
+        // external systems
+        mainframe = softwareSystem "Mainframe Banking System" "Stores all of the core banking information." "Existing System, External"
+
+        customer -> web "Visits" "HTTPS"
+        api -> mainframe "Makes API calls to" "XML/HTTPS" {
+            properties {
+                "timeout" "5s"
+            }
+        }
+    }
+
+    views {
+        systemLandscape "Landscape" {
+            include *
+            exclude mainframe
+            autoLayout
+        }
+        systemContext internetBanking "SystemContext" "The system context diagram" {
+            include *
+            autoLayout lr
+        }
+        container internetBanking "Containers" {
+            include ->web api->
+            autoLayout
+        }
+        component api "Components" {
+            include *
+            include customer
+            exclude ->web
+        }
+        dynamic internetBanking "SignIn" "Signing in" {
+            customer -> web "Signs in using" "HTTPS"
+            {
+                {
+                    api -> web "Redirects to"
+                }
+                {
+                    api -> mainframe "Checks the credentials with"
+                }
+            }
+            customer -> web "Views the dashboard"
+            autoLayout
+        }
+        filtered "Containers" include "Customer,Existing System" "Filtered"
+        theme default
+        styles {
+            element "Person" {
+                shape Person
+                background #08427b
+                colour #ffffff
+                fontSize 22
+            }
+            element "Existing System" {
+                border dashed
+                opacity 50
+            }
+        }
+    }
+}
+`
+
+func TestDSLParser_Parse(t *testing.T) {
+	w, err := NewDSLParser(strings.NewReader(bigBankDSL)).Parse()
+	require.NoError(t, err)
+
+	assert.Equal(t, "Big Bank plc", *w.Name())
+	assert.Equal(t, "An example workspace", *w.Desc())
+
+	model := w.Model()
+	require.Len(t, model.Persons(), 1)
+	customer := model.Persons()[0]
+	assert.Equal(t, "Personal Banking Customer", customer.Name())
+	assert.Equal(t, []string{"Customer"}, customer.Tags().List())
+
+	require.Len(t, model.SoftwareSystems(), 2)
+	banking := model.SoftwareSystems()[0]
+	assert.Equal(t, "Allows customers to view their accounts.", *banking.Description())
+	require.Len(t, banking.Containers(), 2)
+	web, api := banking.Containers()[0], banking.Containers()[1]
+	assert.Equal(t, "Java and Spring MVC", *web.Technology())
+	assert.Equal(t, "Java and Spring Boot", *api.Technology())
+	require.Len(t, api.Components(), 1)
+	assert.Equal(t, "Spring MVC Controller", *api.Components()[0].Technology())
+
+	mainframe := model.SoftwareSystems()[1]
+	assert.Equal(t, []string{"Existing System", "External"}, mainframe.Tags().List())
+
+	relationships := explicitRelationships(model)
+	require.Len(t, relationships, 3)
+	assert.Equal(t, api, relationships[0].From())
+	assert.Equal(t, web, relationships[0].To())
+	assert.Equal(t, customer, relationships[1].From())
+	assert.Equal(t, "HTTPS", *relationships[1].Technology())
+	assert.Equal(t, "Makes API calls to", *relationships[2].Description())
+
+	views := w.Views()
+	require.Len(t, views.SystemLandscapeViews(), 1)
+	landscape := views.SystemLandscapeViews()[0]
+	assert.Equal(t, "Landscape", *landscape.Key())
+	assert.True(t, landscape.AutoLayout())
+	assert.Equal(t, []gostructurizr.Namer{customer, banking}, landscape.Content().Elements())
+
+	require.Len(t, views.SystemContextViews(), 1)
+	context := views.SystemContextViews()[0]
+	assert.Equal(t, banking, context.SoftwareSystem())
+	assert.Equal(t, "SystemContext", *context.Key())
+	assert.True(t, context.IsAllElements())
+	assert.True(t, context.AutoLayout())
+
+	require.Len(t, views.ContainerViews(), 1)
+	includes := views.ContainerViews()[0].Includes()
+	require.Len(t, includes, 2)
+	assert.Equal(t, web, includes[0].On())
+	assert.True(t, includes[0].Afferent())
+	assert.False(t, includes[0].Efferent())
+	assert.Equal(t, api, includes[1].On())
+	assert.True(t, includes[1].Efferent())
+
+	require.Len(t, views.ComponentViews(), 1)
+	components := views.ComponentViews()[0]
+	assert.True(t, components.IsAllElements())
+	assert.False(t, components.IsAllPeople())
+	require.Len(t, components.Includes(), 1)
+	assert.Equal(t, customer, components.Includes()[0].On())
+	require.Len(t, components.Excludes(), 1)
+	assert.True(t, components.Excludes()[0].Afferent())
+
+	require.Len(t, views.DynamicViews(), 1)
+	dynamic := views.DynamicViews()[0]
+	assert.Equal(t, banking, dynamic.Identifier())
+	assert.Equal(t, "Signing in", *dynamic.Description())
+	assert.True(t, dynamic.AutoLayout())
+	steps := dynamic.Steps()
+	require.Len(t, steps, 4)
+	assert.Equal(t, "HTTPS", *steps[0].RelationShip.Technology())
+	assert.Equal(t, []int{1, 2, 2, 3}, []int{steps[0].Order, steps[1].Order, steps[2].Order, steps[3].Order})
+	assert.Equal(t, steps[1].Group, steps[2].Group)
+	assert.NotEqual(t, steps[1].Sequence, steps[2].Sequence)
+
+	require.Len(t, views.FilteredViews(), 1)
+	filtered := views.FilteredViews()[0]
+	assert.Equal(t, "Filtered", filtered.Key())
+	require.Len(t, filtered.FilterCriteria(), 2)
+	assert.Equal(t, "Existing System", filtered.FilterCriteria()[1].Value)
+
+	styles := views.Configuration().Styles()
+	require.Len(t, styles.ElementsStyle(), 2)
+	assert.Equal(t, "#ffffff", *styles.ElementsStyle()[0].Color())
+	assert.Equal(t, 22, *styles.ElementsStyle()[0].FontSize())
+	assert.Equal(t, gostructurizr.Dashed, *styles.ElementsStyle()[1].BorderStyle())
+}
+
+func TestDSLParser_Parse_relationshipStyle(t *testing.T) {
+	dsl := `workspace {
+    views {
+        styles {
+            relationship "Relationship" {
+                thickness 2
+                dashed true
+                routing Orthogonal
+            }
+        }
+    }
+}`
+	w, err := NewDSLParser(strings.NewReader(dsl)).Parse()
+	require.NoError(t, err)
+	styles := w.Views().Configuration().Styles()
+	require.Len(t, styles.RelationshipStyles(), 1)
+	style := styles.RelationshipStyles()[0]
+	assert.Equal(t, "Relationship", style.Tag().String())
+	assert.Equal(t, 2, *style.Thickness())
+	assert.Equal(t, gostructurizr.DashedLine, *style.LineStyle())
+	assert.Equal(t, gostructurizr.Orthogonal, *style.Routing())
+}
+
+func TestDSLParser_Parse_hierarchicalIdentifiers(t *testing.T) {
+	dsl := `workspace {
+    !identifiers hierarchical
+    model {
+        s = softwareSystem "System" {
+            web = container "Web"
+            db = container "Database"
+        }
+        s.web -> s.db "Reads from"
+    }
+}`
+	w, err := NewDSLParser(strings.NewReader(dsl)).Parse()
+	require.NoError(t, err)
+	require.Len(t, w.Model().RelationShip(), 1)
+	assert.Equal(t, "Web", w.Model().RelationShip()[0].From().Name())
+	assert.Equal(t, "Database", w.Model().RelationShip()[0].To().Name())
+}
+
+func TestDSLParser_Parse_enterprise(t *testing.T) {
+	dsl := `workspace {
+    model {
+        enterprise "ACME" {
+            staff = person "Staff" "" "Internal"
+            shop = softwareSystem "Shop"
+        }
+        payment = softwareSystem "Payment" {
+            tags "External, Partner"
+        }
+    }
+}`
+	w, err := NewDSLParser(strings.NewReader(dsl)).Parse()
+	require.NoError(t, err)
+	require.NotNil(t, w.Model().Enterprise())
+	assert.Equal(t, gostructurizr.InternalLocation, w.Model().Persons()[0].Location())
+	assert.Equal(t, gostructurizr.InternalLocation, w.Model().SoftwareSystems()[0].Location())
+	assert.Equal(t, gostructurizr.ExternalLocation, w.Model().SoftwareSystems()[1].Location())
+	// location tags rendered back aren't duplicated
+	assert.Equal(t, []string{"Internal"}, w.Model().Persons()[0].Tags().List())
+	assert.Equal(t, []string{"External", "Partner"}, w.Model().SoftwareSystems()[1].Tags().List())
+}
+
+func TestDSLParser_Parse_errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		dsl          string
+		line, column int
+		msg          string
+	}{
+		{
+			name:   "missing workspace",
+			dsl:    "model {\n}",
+			line:   1,
+			column: 1,
+			msg:    "expected workspace",
+		},
+		{
+			name:   "unknown identifier",
+			dsl:    "workspace {\n  model {\n    u = person \"User\"\n    u -> system \"Uses\"\n  }\n}",
+			line:   4,
+			column: 10,
+			msg:    `unknown identifier "system"`,
+		},
+		{
+			name:   "unterminated string",
+			dsl:    "workspace {\n  model {\n    person \"User\n  }\n}",
+			line:   3,
+			column: 12,
+			msg:    "unterminated string",
+		},
+		{
+			name:   "missing closing bracket",
+			dsl:    "workspace {\n  model {\n",
+			line:   2,
+			column: 3,
+			msg:    `missing "}" for block opened here`,
+		},
+		{
+			name:   "container outside of a software system",
+			dsl:    "workspace {\n  model {\n    container \"Web\"\n  }\n}",
+			line:   3,
+			column: 5,
+			msg:    "container must be defined inside a software system",
+		},
+		{
+			name:   "duplicated identifier",
+			dsl:    "workspace {\n  model {\n    a = person \"A\"\n    a = person \"B\"\n  }\n}",
+			line:   4,
+			column: 9,
+			msg:    `identifier "a" is already in use`,
+		},
+		{
+			name:   "container instance outside of a deployment node",
+			dsl:    "workspace {\n  model {\n    s = softwareSystem \"S\" {\n      web = container \"Web\"\n    }\n    deploymentEnvironment \"Live\" {\n      containerInstance web\n    }\n  }\n}",
+			line:   7,
+			column: 7,
+			msg:    "containerInstance must be defined inside a deployment node",
+		},
+		{
+			name:   "unknown auto layout direction",
+			dsl:    "workspace {\n  model {\n    a = person \"A\"\n  }\n  views {\n    systemLandscape {\n      autoLayout up\n    }\n  }\n}",
+			line:   7,
+			column: 18,
+			msg:    `unknown direction "up", expected tb, bt, lr or rl`,
+		},
+		{
+			name:   "nested parallel sequences",
+			dsl:    "workspace {\n  model {\n    a = person \"A\"\n    b = person \"B\"\n  }\n  views {\n    dynamic * {\n      {\n        a -> b\n        {\n        }\n      }\n    }\n  }\n}",
+			line:   10,
+			column: 9,
+			msg:    "nested parallel sequences are not supported",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewDSLParser(strings.NewReader(tt.dsl)).Parse()
+			var parseErr *Error
+			require.True(t, errors.As(err, &parseErr), "expected a parse error, got %v", err)
+			assert.Equal(t, tt.line, parseErr.Line)
+			assert.Equal(t, tt.column, parseErr.Column)
+			assert.Equal(t, tt.msg, parseErr.Msg)
+		})
+	}
+}
+
+func TestDSLParser_Parse_roundTrip(t *testing.T) {
+	w, err := NewDSLParser(strings.NewReader(bigBankDSL)).Parse()
+	require.NoError(t, err)
+
+	first := bytes.Buffer{}
+	require.NoError(t, renderer.NewDSLRenderer(&first).Render(w))
+
+	reparsed, err := NewDSLParser(bytes.NewReader(first.Bytes())).Parse()
+	require.NoError(t, err)
+
+	second := bytes.Buffer{}
+	require.NoError(t, renderer.NewDSLRenderer(&second).Render(reparsed))
+	assert.Equal(t, first.String(), second.String())
+}
+
+func TestDSLParser_Parse_roundTripGolden(t *testing.T) {
+	tests := []struct {
+		file   string
+		render func(w *gostructurizr.WorkspaceNode, out io.Writer) error
+	}{
+		{"workspace.dsl.golden", func(w *gostructurizr.WorkspaceNode, out io.Writer) error {
+			return renderer.NewDSLRenderer(out).Render(w)
+		}},
+		{"workspace_canonical.dsl.golden", func(w *gostructurizr.WorkspaceNode, out io.Writer) error {
+			return renderer.NewDSLRenderer(out).WithCanonicalOrder().Render(w)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			golden, err := os.ReadFile(filepath.Join("..", "renderer", "testdata", tt.file))
+			require.NoError(t, err)
+			w, err := NewDSLParser(bytes.NewReader(golden)).Parse()
+			require.NoError(t, err)
+
+			out := bytes.Buffer{}
+			require.NoError(t, tt.render(w, &out))
+			assert.Equal(t, string(golden), out.String())
+		})
+	}
+}
+
+func TestDSLParser_Parse_roundTripIdentifiers(t *testing.T) {
+	dsl := `workspace {
+    !identifiers hierarchical
+    model {
+        shop = softwareSystem "Shop" {
+            gw = container "API Gateway"
+        }
+        office = softwareSystem "Back Office" {
+            gw = container "API Gateway"
+        }
+        shop.gw -> office.gw "Forwards to"
+    }
+}`
+	w, err := NewDSLParser(strings.NewReader(dsl)).Parse()
+	require.NoError(t, err)
+	assert.True(t, w.Identifiers().IsHierarchical())
+
+	rendered := bytes.Buffer{}
+	require.NoError(t, renderer.NewDSLRenderer(&rendered).Render(w))
+	assert.Contains(t, rendered.String(), `shop.gw -> office.gw "Forwards to"`)
+
+	reparsed, err := NewDSLParser(bytes.NewReader(rendered.Bytes())).Parse()
+	require.NoError(t, err)
+	require.Len(t, explicitRelationships(reparsed.Model()), 1)
+	assert.Equal(t, "Back Office", explicitRelationships(reparsed.Model())[0].To().(*gostructurizr.ContainerNode).Parent().Name())
+}
+
+func TestDSLParser_Parse_impliedRelationships(t *testing.T) {
+	dsl := `workspace {
+    model {
+        !impliedRelationships true
+        customer = person "Customer"
+        shop = softwareSystem "Shop" {
+            web = container "Web"
+        }
+        customer -> web "Browses"
+    }
+}`
+	w, err := NewDSLParser(strings.NewReader(dsl)).Parse()
+	require.NoError(t, err)
+	assert.Equal(t, gostructurizr.CreateImpliedRelationshipsUnlessAnyExist, w.Model().ImpliedRelationships())
+	require.Len(t, w.Model().RelationShip(), 2)
+	implied := w.Model().RelationShip()[1]
+	assert.True(t, implied.IsImplied())
+	assert.Equal(t, "Shop", implied.To().Name())
+
+	rendered := bytes.Buffer{}
+	require.NoError(t, renderer.NewDSLRenderer(&rendered).Render(w))
+	reparsed, err := NewDSLParser(bytes.NewReader(rendered.Bytes())).Parse()
+	require.NoError(t, err)
+	assert.Len(t, reparsed.Model().RelationShip(), 2)
+
+	_, err = NewDSLParser(strings.NewReader(`workspace {
+    model {
+        !impliedRelationships "com.example.Unknown"
+    }
+}`)).Parse()
+	assert.ErrorContains(t, err, "unknown implied relationships strategy")
+}
+
+func TestDSLParser_Parse_excludeImpliedRelationships(t *testing.T) {
+	dsl := `workspace {
+    model {
+        !impliedRelationships true
+        customer = person "Customer"
+        shop = softwareSystem "Shop" {
+            web = container "Web"
+        }
+        customer -> web "Browses"
+    }
+    views {
+        systemLandscape "Landscape" {
+            include *
+            exclude customer -> shop
+        }
+        container shop "Containers" {
+            include *
+            exclude customer -> web
+        }
+    }
+}`
+	w, err := NewDSLParser(strings.NewReader(dsl)).Parse()
+	require.NoError(t, err)
+	landscape := w.Views().SystemLandscapeViews()[0]
+	assert.True(t, landscape.IsWithoutImpliedRelationships())
+	assert.Empty(t, landscape.Content().RelationShips())
+	// an explicit relationship is excluded on its own
+	containers := w.Views().ContainerViews()[0]
+	assert.False(t, containers.IsWithoutImpliedRelationships())
+	assert.Equal(t, explicitRelationships(w.Model()), containers.ExcludedRelationShips())
+	assert.Empty(t, containers.Content().RelationShips())
+}
+
+func TestDSLParser_Parse_roundTripImpliedRelationships(t *testing.T) {
+	w, err := NewDSLParser(strings.NewReader(`workspace {
+    model {
+        customer = person "Customer"
+        shop = softwareSystem "Shop" {
+            web = container "Web"
+        }
+        customer -> web "Browses"
+    }
+    views {
+        systemContext shop "Context" {
+            include *
+        }
+    }
+}`)).Parse()
+	require.NoError(t, err)
+	// Structurizr implies relationships when the model doesn't say otherwise
+	assert.Equal(t, gostructurizr.CreateImpliedRelationshipsUnlessAnyExist, w.Model().ImpliedRelationships())
+	require.Len(t, w.Views().SystemContextViews()[0].Content().RelationShips(), 1)
+
+	w.Views().SystemContextViews()[0].WithoutImpliedRelationships()
+	for name, newRenderer := range map[string]func(io.Writer) *renderer.DSLRenderer{
+		"strategy": renderer.NewDSLRenderer,
+		"explicit": func(w io.Writer) *renderer.DSLRenderer {
+			return renderer.NewDSLRenderer(w).WithExplicitImpliedRelationships()
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rendered := bytes.Buffer{}
+			require.NoError(t, newRenderer(&rendered).Render(w))
+			reparsed, err := NewDSLParser(bytes.NewReader(rendered.Bytes())).Parse()
+			require.NoError(t, err, rendered.String())
+			context := reparsed.Views().SystemContextViews()[0].Content()
+			assert.Len(t, context.Elements(), 2)
+			assert.Empty(t, context.RelationShips())
+		})
+	}
+}
+
+func TestDSLParser_Parse_relationshipDescription(t *testing.T) {
+	w, err := NewDSLParser(strings.NewReader(`workspace {
+    model {
+        customer = person "Customer"
+        shop = softwareSystem "Shop"
+        customer -> shop {
+            description "Buys from"
+        }
+    }
+}`)).Parse()
+	require.NoError(t, err)
+	require.Len(t, w.Model().RelationShip(), 1)
+	assert.Equal(t, "Buys from", *w.Model().RelationShip()[0].Description())
+}
+
+func TestDSLParser_Parse_elementProperties(t *testing.T) {
+	w, err := NewDSLParser(strings.NewReader(`workspace {
+    model {
+        shop = softwareSystem "Shop" {
+            url "https://wiki.example.com/shop"
+            properties {
+                owner "Team Shop"
+            }
+            api = container "API" {
+                properties {
+                    openapi "Shop API 1.0.0"
+                    "team name" "Checkout"
+                }
+                perspectives {
+                    "Security" "Authenticated customers"
+                }
+            }
+        }
+    }
+}`)).Parse()
+	require.NoError(t, err)
+	api := w.Model().SoftwareSystems()[0].Containers()[0]
+	assert.Equal(t, "Shop API 1.0.0", api.Properties().Get("openapi"))
+	assert.Equal(t, "Checkout", api.Properties().Get("team name"))
+}
+
+func TestDSLParser_Parse_relationships(t *testing.T) {
+	w, err := NewDSLParser(strings.NewReader(`workspace {
+    model {
+        customer = person "Customer"
+        shop = softwareSystem "Shop"
+        customer -> shop "Buys from" "HTTPS" "Critical, asynchronous" {
+            tags "Web"
+            url "https://wiki.example.com/shop"
+            properties {
+                owner "Team Shop"
+            }
+            perspectives {
+                "Security" "Authenticated customers"
+                "Cost" "Per order" "0.01"
+            }
+        }
+    }
+}`)).Parse()
+	require.NoError(t, err)
+	require.Len(t, w.Model().RelationShip(), 1)
+	r := w.Model().RelationShip()[0]
+	assert.Equal(t, "HTTPS", *r.Technology())
+	assert.Equal(t, []string{"Critical", "asynchronous", "Web"}, r.Tags().List())
+	assert.Equal(t, gostructurizr.Asynchronous, *r.InteractionStyle())
+	assert.Equal(t, "https://wiki.example.com/shop", *r.URL())
+	assert.Equal(t, "Team Shop", r.Properties().Get("owner"))
+	assert.Equal(t, []gostructurizr.Perspective{
+		{Name: "Security", Description: "Authenticated customers"},
+		{Name: "Cost", Description: "Per order", Value: "0.01"},
+	}, r.Perspectives())
+
+	rendered := bytes.Buffer{}
+	require.NoError(t, renderer.NewDSLRenderer(&rendered).Render(w))
+	reparsed, err := NewDSLParser(bytes.NewReader(rendered.Bytes())).Parse()
+	require.NoError(t, err)
+	assert.Equal(t, r.Tags().List(), reparsed.Model().RelationShip()[0].Tags().List())
+	assert.Equal(t, r.Perspectives(), reparsed.Model().RelationShip()[0].Perspectives())
+}
+
+func TestDSLParser_Parse_groups(t *testing.T) {
+	w, err := NewDSLParser(strings.NewReader(`workspace {
+    model {
+        properties {
+            "structurizr.groupSeparator" "/"
+        }
+        group "Sales" {
+            customer = person "Customer"
+            group "Finance" {
+                billing = softwareSystem "Billing"
+            }
+        }
+        shop = softwareSystem "Shop" {
+            group "Frontend" {
+                web = container "Web" {
+                    group "Pages" {
+                        home = component "Home"
+                    }
+                }
+            }
+        }
+        group "Sales" {
+            crm = softwareSystem "CRM"
+        }
+    }
+}`)).Parse()
+	require.NoError(t, err)
+	m := w.Model()
+	require.Len(t, m.Groups(), 1)
+	sales := m.Groups()[0]
+	assert.Equal(t, "Sales", sales.Name())
+	assert.Len(t, sales.Members(), 2)
+	assert.Equal(t, "Sales/Finance", m.GroupOf(m.SoftwareSystems()[0]).FullName())
+	assert.Nil(t, m.GroupOf(m.SoftwareSystems()[1]))
+	assert.Equal(t, sales, m.GroupOf(m.SoftwareSystems()[2]))
+	shop := m.SoftwareSystems()[1]
+	web := shop.Containers()[0]
+	assert.Equal(t, "Frontend", shop.GroupOf(web).Name())
+	assert.Equal(t, "Pages", web.GroupOf(web.Components()[0]).Name())
+	assert.Equal(t, "/", m.Properties().Get("structurizr.groupSeparator"))
+
+	rendered := bytes.Buffer{}
+	require.NoError(t, renderer.NewDSLRenderer(&rendered).Render(w))
+	reparsed, err := NewDSLParser(bytes.NewReader(rendered.Bytes())).Parse()
+	require.NoError(t, err)
+	// grouped elements are written after the other ones
+	billing := reparsed.Model().SoftwareSystems()[2]
+	assert.Equal(t, "Billing", billing.Name())
+	assert.Equal(t, "Sales/Finance", reparsed.Model().GroupOf(billing).FullName())
+}
+
+func TestDSLParser_Parse_deployment(t *testing.T) {
+	dsl := `workspace {
+    model {
+        shop = softwareSystem "Shop" {
+            web = container "Web"
+        }
+        live = deploymentEnvironment "Live" {
+            aws = deploymentNode "AWS" "" "Cloud" "Provider" {
+                region = deploymentNode "eu-west-1" {
+                    technology "Region"
+                    lb = infrastructureNode "Load Balancer" {
+                        description "Routes the traffic"
+                    }
+                    a = containerInstance web {
+                        healthCheck "Ready" "https://shop/ready" 30 500
+                    }
+                    b = containerInstance web "" "Canary"
+                    lb -> a "Forwards to"
+                }
+            }
+        }
+    }
+    views {
+        deployment shop live "Live" {
+            include *
+            include lb -> a
+            autoLayout lr 300 100
+        }
+    }
+}`
+	w, err := NewDSLParser(strings.NewReader(dsl)).Parse()
+	require.NoError(t, err)
+
+	nodes := w.Model().FindDeploymentNodesForEnvironment("Live")
+	require.Len(t, nodes, 1)
+	assert.True(t, nodes[0].Tags().Contains("Provider"))
+	require.Len(t, nodes[0].Children(), 1)
+	region := nodes[0].Children()[0]
+	assert.Equal(t, "Region", region.Technology())
+	require.Len(t, region.InfrastructureNodes(), 1)
+	assert.Equal(t, "Routes the traffic", region.InfrastructureNodes()[0].Description())
+	instances := region.ContainerInstances()
+	require.Len(t, instances, 2)
+	assert.Equal(t, 1, instances[0].InstanceId())
+	assert.Equal(t, 2, instances[1].InstanceId())
+	assert.True(t, instances[1].Tags().Contains("Canary"))
+	require.Len(t, instances[0].HealthChecks(), 1)
+	assert.Equal(t, 30, instances[0].HealthChecks()[0].Interval())
+	assert.Equal(t, 500, instances[0].HealthChecks()[0].Timeout())
+	require.Len(t, w.Model().RelationShip(), 1)
+	assert.Equal(t, instances[0], w.Model().RelationShip()[0].To())
+
+	require.Len(t, w.Views().DeploymentViews(), 1)
+	view := w.Views().DeploymentViews()[0]
+	assert.Equal(t, w.Model().SoftwareSystems()[0], view.SoftwareSystem())
+	assert.Equal(t, gostructurizr.DeploymentEnvironment("Live"), view.Environment())
+	assert.Equal(t, "Live", view.GetKey())
+	assert.Equal(t, []gostructurizr.Namer{nodes[0]}, view.Elements())
+	assert.Equal(t, w.Model().RelationShip(), view.RelationShips())
+	assert.True(t, view.IsAutoLayout())
+}
+
+// explicitRelationships returns the relationships of the model which aren't implied by another one
+func explicitRelationships(m *gostructurizr.ModelNode) []*gostructurizr.RelationShipNode {
+	var explicit []*gostructurizr.RelationShipNode
+	for _, r := range m.RelationShip() {
+		if !r.IsImplied() {
+			explicit = append(explicit, r)
+		}
+	}
+	return explicit
+}
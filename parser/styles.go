@@ -0,0 +1,284 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/platelk/gostructurizr"
+	"github.com/platelk/gostructurizr/shapes"
+	"github.com/platelk/gostructurizr/tags"
+)
+
+func (p *parser) parseStyles(s *statement) error {
+	if !s.block {
+		return errorAt(s.start, "expected %q after styles", "{")
+	}
+	styles := p.workspace.Views().Configuration().Styles()
+	return p.parseBlock(s.start, func(s *statement) error {
+		keyword := s.keyword()
+		if keyword != "element" && keyword != "relationship" {
+			return errorAt(s.start, "unexpected %q in styles", s.tokens[0].value)
+		}
+		args, err := s.args(1)
+		if err != nil {
+			return err
+		}
+		if len(args) != 1 || !s.block {
+			return errorAt(s.start, "expected: %s <tag> {", s.tokens[0].value)
+		}
+		if keyword == "element" {
+			style := styles.AddElementStyle(tags.Tag(args[0]))
+			return p.parseBlock(s.start, func(s *statement) error {
+				if s.keyword() == "properties" {
+					return p.skip(s)
+				}
+				return parseElementStyleProperty(s, style)
+			})
+		}
+		style := styles.AddRelationshipStyle(tags.Tag(args[0]))
+		return p.parseBlock(s.start, func(s *statement) error {
+			if s.keyword() == "properties" {
+				return p.skip(s)
+			}
+			return parseRelationshipStyleProperty(s, style)
+		})
+	})
+}
+
+// styleValue returns the single value of a style property
+func styleValue(s *statement) (string, error) {
+	args, err := s.args(1)
+	if err != nil {
+		return "", err
+	}
+	if len(args) != 1 {
+		return "", errorAt(s.start, "expected: %s <value>", s.tokens[0].value)
+	}
+	return args[0], noBlock(s)
+}
+
+func intValue(s *statement) (int, error) {
+	value, err := styleValue(s)
+	if err != nil {
+		return 0, err
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errorAt(s.tokens[1], "expected an integer, got %q", value)
+	}
+	return i, nil
+}
+
+func boolValue(s *statement) (bool, error) {
+	value, err := styleValue(s)
+	if err != nil {
+		return false, err
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, errorAt(s.tokens[1], "expected true or false, got %q", value)
+	}
+	return b, nil
+}
+
+func parseElementStyleProperty(s *statement, style *gostructurizr.ElementStyleNode) error {
+	var err error
+	switch s.keyword() {
+	case "shape":
+		var value string
+		if value, err = styleValue(s); err == nil {
+			style.WithShape(shapes.Shape(value))
+		}
+	case "icon":
+		var value string
+		if value, err = styleValue(s); err == nil {
+			style.WithIcon(value)
+		}
+	case "background":
+		var value string
+		if value, err = styleValue(s); err == nil {
+			style.WithBackground(value)
+		}
+	case "color", "colour":
+		var value string
+		if value, err = styleValue(s); err == nil {
+			style.WithColor(value)
+		}
+	case "stroke":
+		var value string
+		if value, err = styleValue(s); err == nil {
+			style.WithStroke(value)
+		}
+	case "width", "height", "strokewidth", "fontsize", "opacity", "zindex", "rotation":
+		var value int
+		if value, err = intValue(s); err == nil {
+			setElementStyleInt(s.keyword(), value, style)
+		}
+	case "metadata", "description", "shadow":
+		var value bool
+		if value, err = boolValue(s); err == nil {
+			switch s.keyword() {
+			case "metadata":
+				style.WithMetadata(value)
+			case "description":
+				style.WithDescription(value)
+			default:
+				style.WithShadow(value)
+			}
+		}
+	case "border", "borderstyle":
+		var value string
+		if value, err = styleValue(s); err != nil {
+			return err
+		}
+		if width, convErr := strconv.Atoi(value); convErr == nil {
+			style.WithBorder(width)
+			return nil
+		}
+		switch strings.ToLower(value) {
+		case "solid":
+			style.WithBorderStyle(gostructurizr.Solid)
+		case "dashed":
+			style.WithBorderStyle(gostructurizr.Dashed)
+		case "dotted":
+			style.WithBorderStyle(gostructurizr.Dotted)
+		default:
+			return errorAt(s.tokens[1], "unknown border style %q", value)
+		}
+	case "fontfamily":
+		var value string
+		if value, err = styleValue(s); err == nil {
+			style.WithFontFamily(gostructurizr.FontType(value))
+		}
+	case "fontstyle":
+		var value string
+		if value, err = styleValue(s); err == nil {
+			style.WithFontStyle(value)
+		}
+	case "position":
+		var value string
+		if value, err = styleValue(s); err != nil {
+			return err
+		}
+		x, y, ok := strings.Cut(value, ",")
+		xi, xErr := strconv.Atoi(strings.TrimSpace(x))
+		yi, yErr := strconv.Atoi(strings.TrimSpace(y))
+		if !ok || xErr != nil || yErr != nil {
+			return errorAt(s.tokens[1], "expected a position as x,y, got %q", value)
+		}
+		style.WithPosition(xi, yi)
+	case "icons":
+		return nil
+	default:
+		return errorAt(s.start, "unknown element style property %q", s.tokens[0].value)
+	}
+	return err
+}
+
+func setElementStyleInt(keyword string, value int, style *gostructurizr.ElementStyleNode) {
+	switch keyword {
+	case "width":
+		style.WithWidth(value)
+	case "height":
+		style.WithHeight(value)
+	case "strokewidth":
+		style.WithStrokeWidth(value)
+	case "fontsize":
+		style.WithFontSize(value)
+	case "opacity":
+		style.WithOpacity(value)
+	case "zindex":
+		style.WithZIndex(value)
+	case "rotation":
+		style.WithRotation(value)
+	}
+}
+
+func parseRelationshipStyleProperty(s *statement, style *gostructurizr.RelationShipStyleNode) error {
+	var err error
+	switch s.keyword() {
+	case "thickness", "width", "fontsize", "position", "opacity":
+		var value int
+		if value, err = intValue(s); err == nil {
+			switch s.keyword() {
+			case "thickness":
+				style.WithThickness(value)
+			case "width":
+				style.WithWidth(value)
+			case "fontsize":
+				style.WithFontSize(value)
+			case "position":
+				style.WithPosition(value)
+			default:
+				style.WithOpacity(value)
+			}
+		}
+	case "color", "colour":
+		var value string
+		if value, err = styleValue(s); err == nil {
+			style.WithColor(value)
+		}
+	case "dashed":
+		var value bool
+		if value, err = boolValue(s); err == nil {
+			style.WithDash(value)
+		}
+	case "style":
+		var value string
+		if value, err = styleValue(s); err != nil {
+			return err
+		}
+		switch strings.ToLower(value) {
+		case "solid":
+			style.WithLineStyle(gostructurizr.SolidLine)
+		case "dashed":
+			style.WithLineStyle(gostructurizr.DashedLine)
+		case "dotted":
+			style.WithLineStyle(gostructurizr.DottedLine)
+		default:
+			return errorAt(s.tokens[1], "unknown line style %q", value)
+		}
+	case "routing":
+		var value string
+		if value, err = styleValue(s); err != nil {
+			return err
+		}
+		switch strings.ToLower(value) {
+		case "direct":
+			style.WithRouting(gostructurizr.Direct)
+		case "orthogonal":
+			style.WithRouting(gostructurizr.Orthogonal)
+		case "curved":
+			style.WithRouting(gostructurizr.Curved)
+		default:
+			return errorAt(s.tokens[1], "unknown routing %q", value)
+		}
+	case "fontcolor":
+		var value string
+		if value, err = styleValue(s); err == nil {
+			style.WithFontColor(value)
+		}
+	case "fontfamily":
+		var value string
+		if value, err = styleValue(s); err == nil {
+			style.WithFontFamily(gostructurizr.FontType(value))
+		}
+	case "fontstyle":
+		var value string
+		if value, err = styleValue(s); err == nil {
+			style.WithFontStyle(value)
+		}
+	case "sourceterminator", "destinationterminator":
+		var value string
+		if value, err = styleValue(s); err == nil {
+			if s.keyword() == "sourceterminator" {
+				style.WithStartTerminator(gostructurizr.TerminatorStyle(value))
+			} else {
+				style.WithEndTerminator(gostructurizr.TerminatorStyle(value))
+			}
+		}
+	default:
+		return errorAt(s.start, "unknown relationship style property %q", s.tokens[0].value)
+	}
+	return err
+}
@@ -0,0 +1,638 @@
+package parser
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/platelk/gostructurizr"
+)
+
+// viewBody receives the statements found in the block of a view
+type viewBody struct {
+	includeAll func()
+	include    func(e *gostructurizr.ExpressionViewNode) error
+	exclude    func(e *gostructurizr.ExpressionViewNode) error
+	autoLayout func()
+	// withoutImplied hides the implied relationships, which the DSL excludes one by one
+	withoutImplied func()
+	// excludeRelationShip hides a relationship added explicitly
+	excludeRelationShip func(r *gostructurizr.RelationShipNode)
+	// includeRelationShip adds a relationship to a view listing its relationships
+	includeRelationShip func(r *gostructurizr.RelationShipNode)
+}
+
+func (p *parser) parseViews(s *statement) error {
+	if !s.block {
+		return errorAt(s.start, "expected %q after views", "{")
+	}
+	return p.parseBlock(s.start, func(s *statement) error {
+		switch s.keyword() {
+		case "systemlandscape":
+			return p.parseSystemLandscapeView(s)
+		case "systemcontext":
+			return p.parseSystemContextView(s)
+		case "container":
+			return p.parseContainerView(s)
+		case "component":
+			return p.parseComponentView(s)
+		case "dynamic":
+			return p.parseDynamicView(s)
+		case "deployment":
+			return p.parseDeploymentView(s)
+		case "filtered":
+			return p.parseFilteredView(s)
+		case "filteredview":
+			return p.parseFilteredViewBlock(s)
+		case "styles":
+			return p.parseStyles(s)
+		case "theme", "themes", "branding", "terminology", "properties", "configuration":
+			return p.skip(s)
+		}
+		return errorAt(s.start, "unexpected %q in views", s.tokens[0].value)
+	})
+}
+
+// viewHeader parses `<type> <identifier> [key] [description]` and returns the scope element, key and description
+func (p *parser) viewHeader(s *statement) (gostructurizr.Namer, string, string, error) {
+	if len(s.tokens) < 2 {
+		return nil, "", "", errorAt(s.start, "expected: %s <identifier> [key] [description]", s.tokens[0].value)
+	}
+	scope, err := p.lookup(s.tokens[1], nil)
+	if err != nil {
+		return nil, "", "", err
+	}
+	args, err := s.args(2)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if len(args) > 2 {
+		return nil, "", "", errorAt(s.tokens[4], "too many arguments, expected: %s <identifier> [key] [description]", s.tokens[0].value)
+	}
+	return scope, argAt(args, 0), argAt(args, 1), nil
+}
+
+// parseSystemLandscapeView parses `systemLandscape [key] [description]`
+func (p *parser) parseSystemLandscapeView(s *statement) error {
+	args, err := s.args(1)
+	if err != nil {
+		return err
+	}
+	if len(args) > 2 {
+		return errorAt(s.tokens[3], "too many arguments, expected: systemLandscape [key] [description]")
+	}
+	view := p.workspace.Views().CreateSystemLandscapeView()
+	if key := argAt(args, 0); key != "" {
+		view.WithKey(key)
+		p.viewsByKey[key] = view
+	}
+	if desc := argAt(args, 1); desc != "" {
+		view.WithDescription(desc)
+	}
+	return p.parseViewBody(s, viewBody{
+		includeAll: func() { view.AddAllElements() },
+		include: func(e *gostructurizr.ExpressionViewNode) error {
+			view.WithInclude(e)
+			return nil
+		},
+		exclude: func(e *gostructurizr.ExpressionViewNode) error {
+			view.WithExclude(e)
+			return nil
+		},
+		autoLayout:          func() { view.WithAutoLayout() },
+		withoutImplied:      func() { view.WithoutImpliedRelationships() },
+		excludeRelationShip: func(r *gostructurizr.RelationShipNode) { view.ExcludeRelationShip(r) },
+	})
+}
+
+func (p *parser) parseSystemContextView(s *statement) error {
+	scope, key, desc, err := p.viewHeader(s)
+	if err != nil {
+		return err
+	}
+	system, ok := scope.(*gostructurizr.SoftwareSystemNode)
+	if !ok {
+		return errorAt(s.tokens[1], "%q is not a software system", s.tokens[1].value)
+	}
+	view := p.workspace.Views().CreateSystemContextView(system)
+	if key != "" {
+		view.WithKey(key)
+		p.viewsByKey[key] = view
+	}
+	if desc != "" {
+		view.WithDescription(desc)
+	}
+	return p.parseViewBody(s, viewBody{
+		includeAll: func() { view.AddAllElements().AddAllPeople() },
+		include: func(e *gostructurizr.ExpressionViewNode) error {
+			view.WithInclude(e)
+			return nil
+		},
+		autoLayout:          func() { view.WithAutoLayout() },
+		withoutImplied:      func() { view.WithoutImpliedRelationships() },
+		excludeRelationShip: func(r *gostructurizr.RelationShipNode) { view.ExcludeRelationShip(r) },
+	})
+}
+
+func (p *parser) parseContainerView(s *statement) error {
+	scope, key, desc, err := p.viewHeader(s)
+	if err != nil {
+		return err
+	}
+	system, ok := scope.(*gostructurizr.SoftwareSystemNode)
+	if !ok {
+		return errorAt(s.tokens[1], "%q is not a software system", s.tokens[1].value)
+	}
+	view := p.workspace.Views().CreateContainerView(system)
+	if key != "" {
+		view.WithKey(key)
+		p.viewsByKey[key] = view
+	}
+	if desc != "" {
+		view.WithDescription(desc)
+	}
+	return p.parseViewBody(s, viewBody{
+		includeAll: func() { view.AddAllElements() },
+		include: func(e *gostructurizr.ExpressionViewNode) error {
+			view.WithInclude(e)
+			return nil
+		},
+		exclude: func(e *gostructurizr.ExpressionViewNode) error {
+			view.WithExclude(e)
+			return nil
+		},
+		autoLayout:          func() { view.WithAutoLayout() },
+		withoutImplied:      func() { view.WithoutImpliedRelationships() },
+		excludeRelationShip: func(r *gostructurizr.RelationShipNode) { view.ExcludeRelationShip(r) },
+	})
+}
+
+func (p *parser) parseComponentView(s *statement) error {
+	scope, key, desc, err := p.viewHeader(s)
+	if err != nil {
+		return err
+	}
+	container, ok := scope.(*gostructurizr.ContainerNode)
+	if !ok {
+		return errorAt(s.tokens[1], "%q is not a container", s.tokens[1].value)
+	}
+	view := p.workspace.Views().CreateComponentView(container)
+	if key != "" {
+		view.WithKey(key)
+		p.viewsByKey[key] = view
+	}
+	if desc != "" {
+		view.WithDescription(desc)
+	}
+	return p.parseViewBody(s, viewBody{
+		includeAll: func() { view.AddAllElements() },
+		include: func(e *gostructurizr.ExpressionViewNode) error {
+			view.WithInclude(e)
+			return nil
+		},
+		exclude: func(e *gostructurizr.ExpressionViewNode) error {
+			view.WithExclude(e)
+			return nil
+		},
+		autoLayout:          func() { view.WithAutoLayout() },
+		withoutImplied:      func() { view.WithoutImpliedRelationships() },
+		excludeRelationShip: func(r *gostructurizr.RelationShipNode) { view.ExcludeRelationShip(r) },
+	})
+}
+
+// parseDynamicView parses `dynamic <*|identifier> [key] [description]` and its steps
+func (p *parser) parseDynamicView(s *statement) error {
+	var scope gostructurizr.Namer
+	var key, desc string
+	if len(s.tokens) > 1 && s.tokens[1].value == "*" {
+		args, err := s.args(2)
+		if err != nil {
+			return err
+		}
+		if len(args) > 2 {
+			return errorAt(s.tokens[4], "too many arguments, expected: dynamic * [key] [description]")
+		}
+		key, desc = argAt(args, 0), argAt(args, 1)
+	} else {
+		element, k, d, err := p.viewHeader(s)
+		if err != nil {
+			return err
+		}
+		switch element.(type) {
+		case *gostructurizr.SoftwareSystemNode, *gostructurizr.ContainerNode:
+		default:
+			return errorAt(s.tokens[1], "%q is not a software system nor a container", s.tokens[1].value)
+		}
+		scope, key, desc = element, k, d
+	}
+	view := p.workspace.Views().CreateDynamicView(scope)
+	if key != "" {
+		view.WithKey(key)
+		p.viewsByKey[key] = view
+	}
+	if desc != "" {
+		view.WithDescription(desc)
+	}
+	if !s.block {
+		return nil
+	}
+	return p.parseDynamicBlock(s.start, view, 0)
+}
+
+// parseDynamicBlock parses the steps of a dynamic view. Nested blocks are parallel sequences when they
+// hold steps, and groups of parallel sequences when they hold blocks.
+func (p *parser) parseDynamicBlock(open token, view *gostructurizr.DynamicViewNode, depth int) error {
+	sequence := false
+	for {
+		s := p.nextStatement()
+		if s == nil {
+			return errorAt(open, "missing %q for block opened here", "}")
+		}
+		if s.close {
+			if sequence {
+				view.EndParallelSequence()
+			}
+			return nil
+		}
+		if len(s.tokens) == 0 {
+			if sequence {
+				return errorAt(s.start, "nested parallel sequences are not supported")
+			}
+			if err := p.parseDynamicBlock(s.start, view, depth+1); err != nil {
+				return err
+			}
+			continue
+		}
+		isStep := false
+		for _, t := range s.tokens {
+			isStep = isStep || t.kind == tokenArrow
+		}
+		if isStep {
+			if depth > 0 && !sequence {
+				view.StartParallelSequence()
+				sequence = true
+			}
+			if err := p.parseDynamicStep(s, view); err != nil {
+				return err
+			}
+			continue
+		}
+		if depth > 0 {
+			return errorAt(s.start, "unexpected %q in parallel sequence", s.tokens[0].value)
+		}
+		switch s.keyword() {
+		case "autolayout":
+			if err := autoLayout(s); err != nil {
+				return err
+			}
+			view.WithAutoLayout()
+		case "title", "description", "properties", "animation", "default":
+			if err := p.skip(s); err != nil {
+				return err
+			}
+		default:
+			return errorAt(s.start, "unexpected %q in view", s.tokens[0].value)
+		}
+	}
+}
+
+// parseDynamicStep parses `<identifier> -> <identifier> [description] [technology]`
+func (p *parser) parseDynamicStep(s *statement, view *gostructurizr.DynamicViewNode) error {
+	if len(s.tokens) < 3 || s.tokens[1].kind != tokenArrow {
+		return errorAt(s.start, "expected: <identifier> -> <identifier> [description] [technology]")
+	}
+	from, err := p.lookup(s.tokens[0], nil)
+	if err != nil {
+		return err
+	}
+	to, err := p.lookup(s.tokens[2], nil)
+	if err != nil {
+		return err
+	}
+	args, err := s.args(3)
+	if err != nil {
+		return err
+	}
+	if len(args) > 2 {
+		return errorAt(s.tokens[5], "too many arguments, expected: [description] [technology]")
+	}
+	view.Add(from, to, args...)
+	return noBlock(s)
+}
+
+// parseDeploymentView parses `deployment <*|identifier> <environment> [key] [description]`,
+// the environment being given by its name or its identifier
+func (p *parser) parseDeploymentView(s *statement) error {
+	if len(s.tokens) < 3 {
+		return errorAt(s.start, "expected: deployment <*|identifier> <environment> [key] [description]")
+	}
+	var system *gostructurizr.SoftwareSystemNode
+	if s.tokens[1].value != "*" {
+		scope, err := p.lookup(s.tokens[1], nil)
+		if err != nil {
+			return err
+		}
+		var ok bool
+		if system, ok = scope.(*gostructurizr.SoftwareSystemNode); !ok {
+			return errorAt(s.tokens[1], "%q is not a software system", s.tokens[1].value)
+		}
+	}
+	args, err := s.args(2)
+	if err != nil {
+		return err
+	}
+	if len(args) > 3 {
+		return errorAt(s.tokens[5], "too many arguments, expected: deployment <*|identifier> <environment> [key] [description]")
+	}
+	environment, ok := p.environments[args[0]]
+	if !ok {
+		environment = p.workspace.Model().AddDeploymentEnvironment(args[0])
+	}
+	view := p.workspace.Views().CreateDeploymentView(system, environment)
+	if key := argAt(args, 1); key != "" {
+		view.WithKey(key)
+	}
+	if desc := argAt(args, 2); desc != "" {
+		view.WithDescription(desc)
+	}
+	return p.parseViewBody(s, viewBody{
+		includeAll: func() {
+			for _, node := range p.workspace.Model().FindDeploymentNodesForEnvironment(environment) {
+				view.AddDeploymentNode(node)
+			}
+		},
+		include: func(e *gostructurizr.ExpressionViewNode) error {
+			node, ok := e.On().(*gostructurizr.DeploymentNodeNode)
+			if !ok || e.Afferent() || e.Efferent() {
+				return errors.New("only deployment nodes can be included in a deployment view")
+			}
+			view.AddDeploymentNode(node)
+			return nil
+		},
+		autoLayout:          func() { view.WithAutoLayout() },
+		includeRelationShip: func(r *gostructurizr.RelationShipNode) { view.AddRelationship(r) },
+	})
+}
+
+// parseFilteredView parses `filtered <baseKey> <include|exclude> <tags> [key] [description]`
+func (p *parser) parseFilteredView(s *statement) error {
+	args, err := s.args(1)
+	if err != nil {
+		return err
+	}
+	if len(args) < 3 || len(args) > 5 {
+		return errorAt(s.start, "expected: filtered <baseKey> <include|exclude> <tags> [key] [description]")
+	}
+	base, ok := p.viewsByKey[args[0]]
+	if !ok {
+		return errorAt(s.tokens[1], "unknown view %q", args[0])
+	}
+	view := p.workspace.Views().CreateFilteredView(base, "")
+	for _, tag := range strings.Split(args[2], ",") {
+		tag = strings.TrimSpace(tag)
+		switch strings.ToLower(args[1]) {
+		case "include":
+			view.Include(tag)
+		case "exclude":
+			view.Exclude(tag)
+		default:
+			return errorAt(s.tokens[2], "expected include or exclude, got %q", args[1])
+		}
+	}
+	if key := argAt(args, 3); key != "" {
+		view.WithKey(key)
+	}
+	if desc := argAt(args, 4); desc != "" {
+		view.WithDescription(desc)
+	}
+	return p.skip(s)
+}
+
+// parseFilteredViewBlock parses the block form of filtered views written by renderer.DSLRenderer
+func (p *parser) parseFilteredViewBlock(s *statement) error {
+	if !s.block {
+		return errorAt(s.start, "expected %q after filteredView", "{")
+	}
+	var base gostructurizr.Viewable
+	var fields []*statement
+	err := p.parseBlock(s.start, func(s *statement) error {
+		if s.keyword() != "baseview" {
+			fields = append(fields, s)
+			return nil
+		}
+		value, err := styleValue(s)
+		if err != nil {
+			return err
+		}
+		view, ok := p.viewsByKey[value]
+		if !ok {
+			return errorAt(s.tokens[1], "unknown view %q", value)
+		}
+		base = view
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if base == nil {
+		return errorAt(s.start, "filtered view without base view")
+	}
+	view := p.workspace.Views().CreateFilteredView(base, "")
+	for _, s := range fields {
+		if s.keyword() == "autolayout" {
+			if err := autoLayout(s); err != nil {
+				return err
+			}
+			view.WithAutoLayout()
+			continue
+		}
+		if s.keyword() == "include" || s.keyword() == "exclude" {
+			args, err := s.args(1)
+			if err != nil {
+				return err
+			}
+			if len(args) != 2 || gostructurizr.FilterType(args[0]) != gostructurizr.TagFilter {
+				return errorAt(s.start, "expected: %s Tag <tag>", s.tokens[0].value)
+			}
+			if s.keyword() == "include" {
+				view.Include(args[1])
+			} else {
+				view.Exclude(args[1])
+			}
+			continue
+		}
+		value, err := styleValue(s)
+		if err != nil {
+			return err
+		}
+		switch s.keyword() {
+		case "title":
+			view.WithTitle(value)
+		case "key":
+			view.WithKey(value)
+		case "description":
+			view.WithDescription(value)
+		default:
+			return errorAt(s.start, "unexpected %q in filtered view", s.tokens[0].value)
+		}
+	}
+	return nil
+}
+
+func (p *parser) parseViewBody(s *statement, body viewBody) error {
+	if !s.block {
+		return nil
+	}
+	return p.parseBlock(s.start, func(s *statement) error {
+		switch s.keyword() {
+		case "include":
+			if err := noBlock(s); err != nil {
+				return err
+			}
+			if len(s.tokens) == 4 && s.tokens[2].kind == tokenArrow && !s.tokens[2].adjacent && body.includeRelationShip != nil {
+				relationShips, err := p.relationShipsBetween(s)
+				if err != nil {
+					return err
+				}
+				for _, r := range relationShips {
+					body.includeRelationShip(r)
+				}
+				return nil
+			}
+			return p.parseInclude(s, body)
+		case "exclude":
+			if err := noBlock(s); err != nil {
+				return err
+			}
+			if len(s.tokens) == 4 && s.tokens[2].kind == tokenArrow && !s.tokens[2].adjacent {
+				return p.parseRelationShipExclude(s, body)
+			}
+			if body.exclude == nil {
+				return errorAt(s.start, "exclude is not supported in this view")
+			}
+			return p.parseInclude(s, viewBody{include: body.exclude})
+		case "autolayout":
+			if err := autoLayout(s); err != nil {
+				return err
+			}
+			body.autoLayout()
+			return nil
+		case "title", "description", "properties", "animation", "default":
+			// presentation only statements, they have no counterpart on the views
+			return p.skip(s)
+		}
+		return errorAt(s.start, "unexpected %q in view", s.tokens[0].value)
+	})
+}
+
+// parseRelationShipExclude parses `exclude <source> -> <destination>`, which hides the relationships from source
+// to destination. Implied relationships can't be hidden one by one, excluding one hides them all from the view.
+func (p *parser) parseRelationShipExclude(s *statement, body viewBody) error {
+	if body.withoutImplied == nil {
+		return errorAt(s.tokens[2], "relationship expressions are not supported")
+	}
+	relationShips, err := p.relationShipsBetween(s)
+	if err != nil {
+		return err
+	}
+	for _, r := range relationShips {
+		if r.IsImplied() {
+			body.withoutImplied()
+		} else {
+			body.excludeRelationShip(r)
+		}
+	}
+	return nil
+}
+
+// relationShipsBetween returns the relationships of the model matching `<include|exclude> <source> -> <destination>`
+func (p *parser) relationShipsBetween(s *statement) ([]*gostructurizr.RelationShipNode, error) {
+	source, err := p.lookup(s.tokens[1], nil)
+	if err != nil {
+		return nil, err
+	}
+	destination, err := p.lookup(s.tokens[3], nil)
+	if err != nil {
+		return nil, err
+	}
+	var relationShips []*gostructurizr.RelationShipNode
+	for _, r := range p.workspace.Model().RelationShip() {
+		if r.From() == source && r.To() == destination {
+			relationShips = append(relationShips, r)
+		}
+	}
+	return relationShips, nil
+}
+
+// autoLayout checks `autoLayout [tb|bt|lr|rl] [rankSeparation] [nodeSeparation]`.
+// Views only record that they are laid out automatically, the direction and separations are read over.
+func autoLayout(s *statement) error {
+	args, err := s.args(1)
+	if err != nil {
+		return err
+	}
+	if len(args) > 3 {
+		return errorAt(s.tokens[4], "too many arguments, expected: autoLayout [tb|bt|lr|rl] [rankSeparation] [nodeSeparation]")
+	}
+	if len(args) > 0 {
+		switch strings.ToLower(args[0]) {
+		case "tb", "bt", "lr", "rl":
+		default:
+			return errorAt(s.tokens[1], "unknown direction %q, expected tb, bt, lr or rl", args[0])
+		}
+	}
+	for i := 1; i < len(args); i++ {
+		if _, err := strconv.Atoi(args[i]); err != nil {
+			return errorAt(s.tokens[i+1], "invalid separation %q, expected a number of pixels", args[i])
+		}
+	}
+	return noBlock(s)
+}
+
+// parseInclude parses the expressions of an include statement: `*`, `x`, `->x`, `x->` and `->x->`
+func (p *parser) parseInclude(s *statement, body viewBody) error {
+	tokens := s.tokens
+	if len(tokens) < 2 {
+		return errorAt(s.start, "expected: include <*|identifier|expression>...")
+	}
+	for i := 1; i < len(tokens); {
+		t := tokens[i]
+		if t.kind == tokenWord && t.value == "*" {
+			if body.includeAll == nil {
+				return errorAt(t, "unexpected %q", t.value)
+			}
+			body.includeAll()
+			i++
+			continue
+		}
+		if body.include == nil {
+			return errorAt(t, "only `include *` is supported in this view")
+		}
+		afferent := false
+		if t.kind == tokenArrow {
+			if i+1 >= len(tokens) || !tokens[i+1].adjacent {
+				return errorAt(t, "unsupported include expression")
+			}
+			afferent = true
+			i++
+			t = tokens[i]
+		}
+		element, err := p.lookup(t, nil)
+		if err != nil {
+			return err
+		}
+		i++
+		efferent := false
+		if i < len(tokens) && tokens[i].kind == tokenArrow {
+			if !tokens[i].adjacent {
+				return errorAt(tokens[i], "relationship expressions are not supported")
+			}
+			efferent = true
+			i++
+		}
+		if err := body.include(gostructurizr.On(element).WithAfferent(afferent).WithEfferent(efferent)); err != nil {
+			return errorAt(t, "%v", err)
+		}
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ type PersonNode struct {
 	name        string
 	description *string
 	tags        *TagsNode
+	location    Location
 	model       *ModelNode
 }
 
@@ -19,10 +20,29 @@ func (p *PersonNode) Name() string {
 	return p.name
 }
 
+func (p *PersonNode) landscapeElement() {}
+
+func (p *PersonNode) WithDesc(desc string) *PersonNode {
+	p.description = &desc
+	return p
+}
+
 func (p *PersonNode) Description() *string {
 	return p.description
 }
 
+// WithLocation sets whether the person is inside or outside of the enterprise, tagging it Internal or External
+func (p *PersonNode) WithLocation(location Location) *PersonNode {
+	relocate(p.tags, p.location, location)
+	p.location = location
+	return p
+}
+
+// Location returns the location of the person, empty when unspecified
+func (p *PersonNode) Location() Location {
+	return p.location
+}
+
 func (p *PersonNode) Tags() *TagsNode {
 	return p.tags
 }
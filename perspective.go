@@ -0,0 +1,8 @@
+package gostructurizr
+
+// Perspective describes an element or a relationship from a point of view, security or ownership for instance
+type Perspective struct {
+	Name        string
+	Description string
+	Value       string
+}
@@ -1,5 +1,7 @@
 package gostructurizr
 
+import "github.com/platelk/gostructurizr/tags"
+
 type Namer interface {
 	Name() string
 }
@@ -11,18 +13,33 @@ const (
 	Synchronous  InteractionStyle = "synchronous"
 )
 
+// Tag returns the tag given to the relationships of the interaction style
+func (i InteractionStyle) Tag() tags.Tag {
+	if i == Asynchronous {
+		return tags.Asynchronous
+	}
+	return tags.Synchronous
+}
+
 type RelationShipNode struct {
 	from, to         Namer
 	desc             *string
 	tech             *string
 	interactionStyle *InteractionStyle
+	impliedBy        *RelationShipNode
+	tags             *TagsNode
+	properties       Properties
+	url              *string
+	perspectives     []Perspective
 }
 
 func Uses(from, to Namer, desc string) *RelationShipNode {
 	return &RelationShipNode{
-		from: from,
-		to:   to,
-		desc: &desc,
+		from:       from,
+		to:         to,
+		desc:       &desc,
+		tags:       &TagsNode{Tags: []string{}},
+		properties: NewProperties(),
 	}
 }
 
@@ -38,16 +55,91 @@ func (r *RelationShipNode) Description() *string {
 	return r.desc
 }
 
+// WithDescription sets the description of the relationship
+func (r *RelationShipNode) WithDescription(desc string) *RelationShipNode {
+	r.desc = &desc
+	return r
+}
+
 func (r *RelationShipNode) WithTechnology(tech string) *RelationShipNode {
 	r.tech = &tech
 	return r
 }
 
+// Technology returns the technology of the relationship, the one of the relationship implying it if not set
 func (r *RelationShipNode) Technology() *string {
+	if r.tech == nil && r.impliedBy != nil {
+		return r.impliedBy.Technology()
+	}
 	return r.tech
 }
 
+// WithInteractionStyle sets the interaction style of the relationship, tagging it with the style so
+// relationship styles can target it
 func (r *RelationShipNode) WithInteractionStyle(i InteractionStyle) *RelationShipNode {
+	if r.interactionStyle != nil && *r.interactionStyle != i {
+		r.tags.Remove(r.interactionStyle.Tag().String())
+	}
 	r.interactionStyle = &i
+	if !r.tags.Contains(i.Tag().String()) {
+		r.tags.Add(i.Tag().String())
+	}
 	return r
 }
+
+// InteractionStyle returns the interaction style of the relationship, the one of the relationship implying it if not set
+func (r *RelationShipNode) InteractionStyle() *InteractionStyle {
+	if r.interactionStyle == nil && r.impliedBy != nil {
+		return r.impliedBy.InteractionStyle()
+	}
+	return r.interactionStyle
+}
+
+// WithImpliedBy marks the relationship as implied by another one
+func (r *RelationShipNode) WithImpliedBy(by *RelationShipNode) *RelationShipNode {
+	r.impliedBy = by
+	return r
+}
+
+// ImpliedBy returns the relationship this one is implied by, nil for the relationships added explicitly
+func (r *RelationShipNode) ImpliedBy() *RelationShipNode {
+	return r.impliedBy
+}
+
+// IsImplied reports whether the relationship was derived from one between the children of its source or destination
+func (r *RelationShipNode) IsImplied() bool {
+	return r.impliedBy != nil
+}
+
+func (r *RelationShipNode) WithTag(t string) *RelationShipNode {
+	r.tags.Add(t)
+	return r
+}
+
+func (r *RelationShipNode) Tags() *TagsNode {
+	return r.tags
+}
+
+// Properties returns the properties of the relationship
+func (r *RelationShipNode) Properties() *Properties {
+	return &r.properties
+}
+
+func (r *RelationShipNode) WithURL(url string) *RelationShipNode {
+	r.url = &url
+	return r
+}
+
+func (r *RelationShipNode) URL() *string {
+	return r.url
+}
+
+func (r *RelationShipNode) WithPerspective(p Perspective) *RelationShipNode {
+	r.perspectives = append(r.perspectives, p)
+	return r
+}
+
+// Perspectives returns the perspectives of the relationship, in the order they were added
+func (r *RelationShipNode) Perspectives() []Perspective {
+	return r.perspectives
+}
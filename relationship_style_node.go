@@ -1,24 +1,219 @@
 package gostructurizr
 
-import "github.com/platelk/gostructurizr/routing"
+import "github.com/platelk/gostructurizr/tags"
 
+// RelationShipStyleNode styles the relationships having a tag
 type RelationShipStyleNode struct {
-	routing *routing.Routing
-	dashed  bool
+	tag       tags.Tag
+	thickness *int
+	color     *string
+	opacity   *int
+	width     *int
+
+	// Advanced styling options
+	lineStyle       *LineStyle
+	fontSize        *int
+	fontColor       *string
+	fontFamily      *FontType
+	fontStyle       *string
+	routing         *RouteStyle
+	position        *int
+	startTerminator *TerminatorStyle
+	endTerminator   *TerminatorStyle
+}
+
+// RelationshipStyle creates a new RelationShipStyleNode
+func RelationshipStyle(tag tags.Tag) *RelationShipStyleNode {
+	return &RelationShipStyleNode{tag: tag}
+}
+
+// Tag returns the tag of the relationship style
+func (r *RelationShipStyleNode) Tag() tags.Tag {
+	return r.tag
+}
+
+// WithThickness sets the thickness of the relationship line
+func (r *RelationShipStyleNode) WithThickness(t int) *RelationShipStyleNode {
+	r.thickness = &t
+	return r
+}
+
+// Thickness returns the thickness of the relationship line
+func (r *RelationShipStyleNode) Thickness() *int {
+	return r.thickness
+}
+
+// WithColor sets the color of the relationship
+func (r *RelationShipStyleNode) WithColor(c string) *RelationShipStyleNode {
+	r.color = &c
+	return r
+}
+
+// Color returns the color of the relationship
+func (r *RelationShipStyleNode) Color() *string {
+	return r.color
+}
+
+// WithOpacity sets the opacity of the relationship
+func (r *RelationShipStyleNode) WithOpacity(o int) *RelationShipStyleNode {
+	r.opacity = &o
+	return r
+}
+
+// Opacity returns the opacity of the relationship
+func (r *RelationShipStyleNode) Opacity() *int {
+	return r.opacity
+}
+
+// WithWidth sets the width of the relationship label
+func (r *RelationShipStyleNode) WithWidth(w int) *RelationShipStyleNode {
+	r.width = &w
+	return r
+}
+
+// Width returns the width of the relationship label
+func (r *RelationShipStyleNode) Width() *int {
+	return r.width
+}
+
+// LineStyle returns the line style of the relationship
+func (r *RelationShipStyleNode) LineStyle() *LineStyle {
+	return r.lineStyle
+}
+
+// WithLineStyle sets the line style of the relationship
+func (r *RelationShipStyleNode) WithLineStyle(style LineStyle) *RelationShipStyleNode {
+	r.lineStyle = &style
+	return r
+}
+
+// FontSize returns the font size of the relationship label
+func (r *RelationShipStyleNode) FontSize() *int {
+	return r.fontSize
+}
+
+// WithFontSize sets the font size of the relationship label
+func (r *RelationShipStyleNode) WithFontSize(size int) *RelationShipStyleNode {
+	r.fontSize = &size
+	return r
+}
+
+// FontColor returns the font color of the relationship label
+func (r *RelationShipStyleNode) FontColor() *string {
+	return r.fontColor
+}
+
+// WithFontColor sets the font color of the relationship label
+func (r *RelationShipStyleNode) WithFontColor(color string) *RelationShipStyleNode {
+	r.fontColor = &color
+	return r
+}
+
+// FontFamily returns the font family of the relationship label
+func (r *RelationShipStyleNode) FontFamily() *FontType {
+	return r.fontFamily
+}
+
+// WithFontFamily sets the font family of the relationship label
+func (r *RelationShipStyleNode) WithFontFamily(family FontType) *RelationShipStyleNode {
+	r.fontFamily = &family
+	return r
+}
+
+// FontStyle returns the font style of the relationship label
+func (r *RelationShipStyleNode) FontStyle() *string {
+	return r.fontStyle
+}
+
+// WithFontStyle sets the font style of the relationship label
+func (r *RelationShipStyleNode) WithFontStyle(style string) *RelationShipStyleNode {
+	r.fontStyle = &style
+	return r
+}
+
+// Routing returns the routing style of the relationship
+func (r *RelationShipStyleNode) Routing() *RouteStyle {
+	return r.routing
+}
+
+// WithRouting sets the routing style of the relationship
+func (r *RelationShipStyleNode) WithRouting(routing RouteStyle) *RelationShipStyleNode {
+	r.routing = &routing
+	return r
+}
+
+// Position returns the position percentage of the relationship label
+func (r *RelationShipStyleNode) Position() *int {
+	return r.position
+}
+
+// WithPosition sets the position percentage of the relationship label (0-100)
+func (r *RelationShipStyleNode) WithPosition(position int) *RelationShipStyleNode {
+	r.position = &position
+	return r
+}
+
+// StartTerminator returns the start terminator style of the relationship
+func (r *RelationShipStyleNode) StartTerminator() *TerminatorStyle {
+	return r.startTerminator
+}
+
+// WithStartTerminator sets the start terminator style of the relationship
+func (r *RelationShipStyleNode) WithStartTerminator(terminator TerminatorStyle) *RelationShipStyleNode {
+	r.startTerminator = &terminator
+	return r
+}
+
+// EndTerminator returns the end terminator style of the relationship
+func (r *RelationShipStyleNode) EndTerminator() *TerminatorStyle {
+	return r.endTerminator
+}
+
+// WithEndTerminator sets the end terminator style of the relationship
+func (r *RelationShipStyleNode) WithEndTerminator(terminator TerminatorStyle) *RelationShipStyleNode {
+	r.endTerminator = &terminator
+	return r
+}
+
+// WithArrow sets the end terminator style to Arrow
+func (r *RelationShipStyleNode) WithArrow() *RelationShipStyleNode {
+	return r.WithEndTerminator(Arrow)
+}
+
+// WithDashed sets the line style to dashed
+func (r *RelationShipStyleNode) WithDashed() *RelationShipStyleNode {
+	return r.WithLineStyle(DashedLine)
+}
+
+// WithDash sets the line style to dashed or solid
+func (r *RelationShipStyleNode) WithDash(dashed bool) *RelationShipStyleNode {
+	if dashed {
+		return r.WithLineStyle(DashedLine)
+	}
+	return r.WithLineStyle(SolidLine)
+}
+
+// Dash reports whether the line is dashed
+func (r *RelationShipStyleNode) Dash() bool {
+	return r.lineStyle != nil && *r.lineStyle == DashedLine
 }
 
-func (n *RelationShipStyleNode) WithRouting(r routing.Routing) {
-	n.routing = &r
+// WithDotted sets the line style to dotted
+func (r *RelationShipStyleNode) WithDotted() *RelationShipStyleNode {
+	return r.WithLineStyle(DottedLine)
 }
 
-func (n *RelationShipStyleNode) Routing() *routing.Routing {
-	return n.routing
+// WithOrthogonalRouting sets the routing style to orthogonal
+func (r *RelationShipStyleNode) WithOrthogonalRouting() *RelationShipStyleNode {
+	return r.WithRouting(Orthogonal)
 }
 
-func (n *RelationShipStyleNode) WithDash(b bool) {
-	n.dashed = b
+// WithCurvedRouting sets the routing style to curved
+func (r *RelationShipStyleNode) WithCurvedRouting() *RelationShipStyleNode {
+	return r.WithRouting(Curved)
 }
 
-func (n *RelationShipStyleNode) Dash() bool {
-	return n.dashed
+// WithDirectRouting sets the routing style to direct
+func (r *RelationShipStyleNode) WithDirectRouting() *RelationShipStyleNode {
+	return r.WithRouting(Direct)
 }
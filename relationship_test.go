@@ -0,0 +1,21 @@
+package gostructurizr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/platelk/gostructurizr/tags"
+)
+
+func TestRelationShipNode_WithInteractionStyle(t *testing.T) {
+	m := Model()
+	r := m.AddPerson("Customer", "").Uses(m.AddSoftwareSystem("Shop", ""), "Buys from").WithTag("Critical")
+
+	r.WithInteractionStyle(Synchronous)
+	assert.Equal(t, []string{"Critical", tags.Synchronous.String()}, r.Tags().List())
+
+	// changing the style replaces its tag
+	r.WithInteractionStyle(Asynchronous).WithInteractionStyle(Asynchronous)
+	assert.Equal(t, []string{"Critical", tags.Asynchronous.String()}, r.Tags().List())
+}
@@ -0,0 +1,51 @@
+package renderer
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/platelk/gostructurizr"
+)
+
+// Aliases gives the elements of a diagram identifiers derived from their name, unique within the diagram.
+// It is used by the renderers of diagram languages which reference elements by identifier.
+type Aliases struct {
+	aliases map[interface{}]string
+	used    map[string]bool
+}
+
+func NewAliases() *Aliases {
+	return &Aliases{
+		aliases: map[interface{}]string{},
+		used:    map[string]bool{},
+	}
+}
+
+// Alias returns the identifier of key, generating it from name on the first call
+func (a *Aliases) Alias(key interface{}, name string) string {
+	if alias, ok := a.aliases[key]; ok {
+		return alias
+	}
+	base := gostructurizr.GenerateIdentifier(name)
+	alias := base
+	for n := 2; a.used[alias]; n++ {
+		alias = base + strconv.Itoa(n)
+	}
+	a.used[alias] = true
+	a.aliases[key] = alias
+	return alias
+}
+
+// Lookup returns the identifier of key, if one was generated
+func (a *Aliases) Lookup(key interface{}) (string, bool) {
+	alias, ok := a.aliases[key]
+	return alias, ok
+}
+
+// ViewTitle follows the naming of the diagrams exported by Structurizr, unless the view has a description
+func ViewTitle(viewType string, desc *string, scope string) string {
+	if desc != nil && *desc != "" {
+		return *desc
+	}
+	return fmt.Sprintf("[%s] %s", viewType, scope)
+}
@@ -3,6 +3,7 @@ package renderer
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"github.com/platelk/gostructurizr"
@@ -13,6 +14,7 @@ import (
 type BaseRenderer struct {
 	w     io.Writer
 	level int
+	ctx   *dslContext
 }
 
 // WriteLine writes a line with proper indentation
@@ -35,14 +37,38 @@ func renderProperties(w io.Writer, properties *gostructurizr.Properties, level i
 	indent := strings.Repeat("    ", level)
 	fmt.Fprintf(w, "%s%s %s\n", indent, dsl.Properties, dsl.OpenBracket)
 	
-	for key, value := range properties.Properties {
+	// properties are sorted by key, a map having no stable order
+	keys := make([]string, 0, len(properties.Properties))
+	for key := range properties.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
 		indentInner := strings.Repeat("    ", level+1)
-		fmt.Fprintf(w, "%s%s %q\n", indentInner, key, value)
+		fmt.Fprintf(w, "%s%q %q\n", indentInner, key, properties.Properties[key])
 	}
 	
 	fmt.Fprintf(w, "%s%s\n", indent, dsl.CloseBracket)
 }
 
+// renderPerspectives renders the perspectives of an element or a relationship
+func renderPerspectives(w io.Writer, perspectives []gostructurizr.Perspective, level int) {
+	if len(perspectives) == 0 {
+		return
+	}
+
+	indent := strings.Repeat("    ", level)
+	fmt.Fprintf(w, "%s%s %s\n", indent, dsl.Perspectives, dsl.OpenBracket)
+	for _, p := range perspectives {
+		fmt.Fprintf(w, "%s    %q %q", indent, p.Name, p.Description)
+		if p.Value != "" {
+			fmt.Fprintf(w, " %q", p.Value)
+		}
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintf(w, "%s%s\n", indent, dsl.CloseBracket)
+}
+
 // RenderTags renders tags of an element
 func renderTags(w io.Writer, tags *gostructurizr.TagsNode, level int) {
 	if tags == nil || len(tags.Tags) == 0 {
@@ -0,0 +1,97 @@
+package renderer
+
+import (
+	"github.com/platelk/gostructurizr"
+	"github.com/platelk/gostructurizr/tags"
+)
+
+// Boundary is a box a diagram draws around elements of a view, either for the parent of containers or components
+// when the parent isn't displayed itself, for the enterprise, or for a group
+type Boundary struct {
+	// Parent is the element or the enterprise the boundary is drawn for, nil for groups
+	Parent gostructurizr.Namer
+	// Group is the full name of the group the boundary is drawn for, empty for parents
+	Group string
+	Name  string
+	// Tags are the tags element styles target groups with, none for parents
+	Tags       []string
+	Elements   []gostructurizr.Namer
+	Boundaries []*Boundary
+}
+
+// boundaryLink is one of the boundaries enclosing an element, outermost first
+type boundaryLink struct {
+	key    interface{}
+	parent gostructurizr.Namer
+	group  string
+	name   string
+	tags   []string
+}
+
+// Boundaries splits the elements of a view between the ones drawn at the top level and the boundaries enclosing
+// the other ones. Containers and components are drawn in the boundary of their parent, and grouped elements
+// in the boundary of their group, nested in the boundary of the enclosing group. When enterprise is true,
+// the people and software systems located inside the enterprise are drawn in its boundary.
+func Boundaries(m *gostructurizr.ModelNode, content *gostructurizr.ViewContent, enterprise bool) ([]gostructurizr.Namer, []*Boundary) {
+	var elements []gostructurizr.Namer
+	var boundaries []*Boundary
+	byKey := map[interface{}]*Boundary{}
+	for _, e := range content.Elements() {
+		var links []boundaryLink
+		// a parent displayed as an element can't also be drawn as a boundary
+		parentLink := func(parent gostructurizr.Namer) {
+			if !content.Contains(parent) {
+				links = append(links, boundaryLink{key: parent, parent: parent, name: parent.Name()})
+			}
+		}
+		if enterprise && m.Enterprise() != nil && m.Enterprise().Contains(e) {
+			links = append(links, boundaryLink{key: m.Enterprise(), parent: m.Enterprise(), name: m.Enterprise().Name()})
+		}
+		switch e := e.(type) {
+		case *gostructurizr.PersonNode:
+			links = append(links, groupLinks(m.GroupOf(e))...)
+		case *gostructurizr.SoftwareSystemNode:
+			links = append(links, groupLinks(m.GroupOf(e))...)
+		case *gostructurizr.ContainerNode:
+			parentLink(e.Parent())
+			links = append(links, groupLinks(e.Parent().GroupOf(e))...)
+		case *gostructurizr.ComponentNode:
+			parentLink(e.Parent())
+			links = append(links, groupLinks(e.Parent().GroupOf(e))...)
+		}
+		if len(links) == 0 {
+			elements = append(elements, e)
+			continue
+		}
+		var enclosing *Boundary
+		for _, link := range links {
+			b, ok := byKey[link.key]
+			if !ok {
+				b = &Boundary{Parent: link.parent, Group: link.group, Name: link.name, Tags: link.tags}
+				byKey[link.key] = b
+				if enclosing == nil {
+					boundaries = append(boundaries, b)
+				} else {
+					enclosing.Boundaries = append(enclosing.Boundaries, b)
+				}
+			}
+			enclosing = b
+		}
+		enclosing.Elements = append(enclosing.Elements, e)
+	}
+	return elements, boundaries
+}
+
+// groupLinks returns the boundaries of a group and of the groups enclosing it, outermost first
+func groupLinks[K gostructurizr.Namer](g *gostructurizr.GroupNode[K]) []boundaryLink {
+	var links []boundaryLink
+	for ; g != nil; g = g.Parent() {
+		links = append([]boundaryLink{{
+			key:   g,
+			group: g.FullName(),
+			name:  g.Name(),
+			tags:  []string{tags.Group.String(), g.Tag().String()},
+		}}, links...)
+	}
+	return links
+}
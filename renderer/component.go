@@ -7,9 +7,9 @@ import (
 	"strings"
 )
 
-func renderComponent(c *gostructurizr.ComponentNode, renderer *strings.Builder, level int) error {
+func renderComponent(c *gostructurizr.ComponentNode, ctx *dslContext, renderer *strings.Builder, level int) error {
 	var line []string
-	line = append(line, generateVarName(c.Name()), dsl.Space, dsl.Equal, dsl.Space, dsl.Component, dsl.Space, generateStringIdentifier(c.Name()))
+	line = append(line, ctx.local(c), dsl.Space, dsl.Equal, dsl.Space, dsl.Component, dsl.Space, generateStringIdentifier(c.Name()))
 	if c.Description() != nil {
 		line = append(line, dsl.Space, generateStringIdentifier(*c.Description()))
 	}
@@ -7,9 +7,9 @@ import (
 	"strings"
 )
 
-func renderContainer(c *gostructurizr.ContainerNode, renderer *strings.Builder, level int) error {
+func renderContainer(c *gostructurizr.ContainerNode, ctx *dslContext, renderer *strings.Builder, level int) error {
 	var line []string
-	line = append(line, generateVarName(c.Name()), dsl.Space, dsl.Equal, dsl.Space, dsl.Container, dsl.Space, generateStringIdentifier(c.Name()))
+	line = append(line, ctx.local(c), dsl.Space, dsl.Equal, dsl.Space, dsl.Container, dsl.Space, generateStringIdentifier(c.Name()))
 	if c.Description() != nil {
 		line = append(line, dsl.Space, generateStringIdentifier(*c.Description()))
 	}
@@ -20,7 +20,7 @@ func renderContainer(c *gostructurizr.ContainerNode, renderer *strings.Builder,
 		line = append(line, dsl.Space, generateStringIdentifier(*c.Technology()))
 	}
 	components := c.Components()
-	if (c.Tags() == nil || len(c.Tags().List()) == 0) && (components == nil || len(components) == 0) {
+	if (c.Tags() == nil || len(c.Tags().List()) == 0) && (components == nil || len(components) == 0) && len(c.Properties().Properties) == 0 {
 		writeLine(renderer, level, line...)
 		return nil
 	}
@@ -31,10 +31,25 @@ func renderContainer(c *gostructurizr.ContainerNode, renderer *strings.Builder,
 		tagList := strings.Join(c.Tags().List(), ", ")
 		fmt.Fprintf(renderer, "%s%s %q\n", indent, dsl.Tags, tagList)
 	}
-	for _, component := range components {
-		if err := renderComponent(component, renderer, level+1); err != nil {
+	renderProperties(renderer, c.Properties(), level+1)
+	render := func(component *gostructurizr.ComponentNode, level int) error {
+		if err := renderComponent(component, ctx, renderer, level); err != nil {
 			return fmt.Errorf("can't render component: %w", err)
 		}
+		return nil
+	}
+	for _, component := range ordered(ctx, components) {
+		if c.GroupOf(component) != nil {
+			continue
+		}
+		if err := render(component, level+1); err != nil {
+			return err
+		}
+	}
+	// components of another container can't be declared here, Validate reports them
+	owned := func(component *gostructurizr.ComponentNode) bool { return component.Parent() == c }
+	if err := renderGroups(c.Groups(), ctx, renderer, level+1, owned, render); err != nil {
+		return err
 	}
 	writeLine(renderer, level, dsl.CloseBracket)
 	return nil
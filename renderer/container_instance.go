@@ -1,11 +1,11 @@
 package renderer
 
 import (
-	"fmt"
 	"io"
 
 	"github.com/platelk/gostructurizr"
 	"github.com/platelk/gostructurizr/dsl"
+	"github.com/platelk/gostructurizr/tags"
 )
 
 // ContainerInstanceRenderer renders a container instance to DSL
@@ -23,39 +23,29 @@ func NewContainerInstanceRenderer(w io.Writer, level int) *ContainerInstanceRend
 	}
 }
 
-// Render renders a container instance to DSL
+// Render renders a container instance to DSL, referencing the container by its identifier.
+// Instance ids aren't part of the DSL, Structurizr numbers the instances of a container itself.
 func (r *ContainerInstanceRenderer) Render(instance *gostructurizr.ContainerInstanceNode) error {
-	r.WriteLine(dsl.ContainerInstance + dsl.Space + dsl.OpenBracket)
-	r.level++
-
-	// Reference to container
-	containerRef := fmt.Sprintf("%s.%s", 
-		instance.Container().Parent().Name(), 
-		instance.Container().Name())
-	r.WriteLine(fmt.Sprintf("%s %q", dsl.Container, containerRef))
-
-	// Instance ID if not default
-	if instance.InstanceId() != 1 {
-		r.WriteLine(fmt.Sprintf("%s %d", dsl.InstanceId, instance.InstanceId()))
+	line := r.ctx.local(instance) + dsl.Space + dsl.Equal + dsl.Space + dsl.ContainerInstance + dsl.Space + r.ctx.id(instance.Container())
+	customTags := withoutDefaultTags(instance.Tags(), tags.ContainerInstance)
+	if len(customTags.List()) == 0 && len(instance.Properties().Properties) == 0 && len(instance.HealthChecks()) == 0 {
+		r.WriteLine("%s", line)
+		return nil
 	}
+	r.WriteLine("%s", line+dsl.Space+dsl.OpenBracket)
+	r.level++
 
-	// Properties
+	renderTags(r.w, customTags, r.level)
 	renderProperties(r.w, instance.Properties(), r.level)
 
-	// Tags
-	renderTags(r.w, instance.Tags(), r.level)
-
-	// Health checks
 	for _, healthCheck := range instance.HealthChecks() {
-		healthCheckRenderer := NewHealthCheckRenderer(r.w, r.level)
-		if err := healthCheckRenderer.Render(healthCheck); err != nil {
+		if err := NewHealthCheckRenderer(r.w, r.level).Render(healthCheck); err != nil {
 			return err
 		}
 	}
 
-	// End container instance
 	r.level--
 	r.WriteLine(dsl.CloseBracket)
 
 	return nil
-}
\ No newline at end of file
+}
@@ -1,11 +1,11 @@
 package renderer
 
 import (
-	"fmt"
 	"io"
 
 	"github.com/platelk/gostructurizr"
 	"github.com/platelk/gostructurizr/dsl"
+	"github.com/platelk/gostructurizr/tags"
 )
 
 // DeploymentNodeRenderer renders a deployment node to DSL
@@ -23,60 +23,74 @@ func NewDeploymentNodeRenderer(w io.Writer, level int) *DeploymentNodeRenderer {
 	}
 }
 
-// Render renders a deployment node to DSL
+// Render renders a deployment node to DSL, along with its children, infrastructure nodes and container instances
 func (r *DeploymentNodeRenderer) Render(node *gostructurizr.DeploymentNodeNode) error {
-	r.WriteLine(dsl.DeploymentNode + dsl.Space + dsl.OpenBracket)
-	r.level++
-
-	// Name
-	r.WriteLine(fmt.Sprintf("%s %q", dsl.Name, node.Name()))
-
-	// Description if available
-	if node.Description() != "" {
-		r.WriteLine(fmt.Sprintf("%s %q", dsl.Description, node.Description()))
-	}
-
-	// Technology
-	if node.Technology() != "" {
-		r.WriteLine(fmt.Sprintf("%s %q", dsl.Technology, node.Technology()))
+	line := r.ctx.local(node) + dsl.Space + dsl.Equal + dsl.Space + dsl.DeploymentNode + dsl.Space +
+		elementArguments(node.Name(), node.Description(), node.Technology())
+	customTags := withoutDefaultTags(node.Tags(), tags.DeploymentNode)
+	if len(customTags.List()) == 0 && len(node.Properties().Properties) == 0 &&
+		len(node.Children()) == 0 && len(node.InfrastructureNodes()) == 0 && len(node.ContainerInstances()) == 0 {
+		r.WriteLine("%s", line)
+		return nil
 	}
+	r.WriteLine("%s", line+dsl.Space+dsl.OpenBracket)
+	r.level++
 
-	// Environment (implicitly set from parent usually)
-	r.WriteLine(fmt.Sprintf("%s %q", dsl.Environment, string(node.Environment())))
-
-	// Properties
+	renderTags(r.w, customTags, r.level)
 	renderProperties(r.w, node.Properties(), r.level)
 
-	// Tags
-	renderTags(r.w, node.Tags(), r.level)
-
-	// Render child deployment nodes
-	for _, child := range node.Children() {
+	for _, child := range ordered(r.ctx, node.Children()) {
 		childRenderer := NewDeploymentNodeRenderer(r.w, r.level)
+		childRenderer.ctx = r.ctx
 		if err := childRenderer.Render(child); err != nil {
 			return err
 		}
 	}
-
-	// Render infrastructure nodes
-	for _, infra := range node.InfrastructureNodes() {
+	for _, infra := range ordered(r.ctx, node.InfrastructureNodes()) {
 		infraRenderer := NewInfrastructureNodeRenderer(r.w, r.level)
+		infraRenderer.ctx = r.ctx
 		if err := infraRenderer.Render(infra); err != nil {
 			return err
 		}
 	}
-
-	// Render container instances
-	for _, instance := range node.ContainerInstances() {
+	for _, instance := range ordered(r.ctx, node.ContainerInstances()) {
 		instanceRenderer := NewContainerInstanceRenderer(r.w, r.level)
+		instanceRenderer.ctx = r.ctx
 		if err := instanceRenderer.Render(instance); err != nil {
 			return err
 		}
 	}
 
-	// End deployment node
 	r.level--
 	r.WriteLine(dsl.CloseBracket)
 
 	return nil
-}
\ No newline at end of file
+}
+
+// elementArguments writes the quoted name, description and technology of an element,
+// leaving out the trailing empty ones
+func elementArguments(name, desc, technology string) string {
+	args := generateStringIdentifier(name)
+	if desc != "" || technology != "" {
+		args += dsl.Space + generateStringIdentifier(desc)
+	}
+	if technology != "" {
+		args += dsl.Space + generateStringIdentifier(technology)
+	}
+	return args
+}
+
+// withoutDefaultTags returns the tags of an element which are not given by default to its type
+func withoutDefaultTags(elementTags *gostructurizr.TagsNode, defaults ...tags.Tag) *gostructurizr.TagsNode {
+	custom := &gostructurizr.TagsNode{Tags: []string{}}
+	for _, tag := range elementTags.List() {
+		isDefault := tag == tags.Element.String()
+		for _, d := range defaults {
+			isDefault = isDefault || tag == d.String()
+		}
+		if !isDefault {
+			custom.Add(tag)
+		}
+	}
+	return custom
+}
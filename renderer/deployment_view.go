@@ -3,9 +3,9 @@ package renderer
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/platelk/gostructurizr"
-	"github.com/platelk/gostructurizr/dsl"
 )
 
 // DeploymentViewRenderer renders a deployment view to DSL
@@ -25,50 +25,12 @@ func NewDeploymentViewRenderer(w io.Writer, level int) *DeploymentViewRenderer {
 
 // Render renders a deployment view to DSL
 func (r *DeploymentViewRenderer) Render(view *gostructurizr.DeploymentViewNode) error {
-	r.WriteLine(dsl.DeploymentView + dsl.Space + dsl.OpenBracket)
-	r.level++
-
-	// Generate software system
-	if view.SoftwareSystem() != nil {
-		r.WriteLine(fmt.Sprintf("%s %s", dsl.SoftwareSystem, renderNamer(view.SoftwareSystem())))
-	}
-
-	// Generate environment
-	environment := string(view.Environment())
-	if environment != "" {
-		r.WriteLine(fmt.Sprintf("%s %q", dsl.Environment, environment))
-	}
-
-	// Generate key
-	if view.GetKey() != "" {
-		r.WriteLine(fmt.Sprintf("%s %q", dsl.Key, view.GetKey()))
-	}
-
-	// Generate description
-	if view.GetDescription() != "" {
-		r.WriteLine(fmt.Sprintf("%s %q", dsl.Description, view.GetDescription()))
+	rendered := strings.Builder{}
+	if err := renderDeploymentView(view, r.ctx, &rendered, r.level); err != nil {
+		return err
 	}
-
-	// Generate auto layout
-	if view.IsAutoLayout() {
-		r.WriteLine(dsl.AutoLayout)
+	if _, err := io.WriteString(r.w, rendered.String()); err != nil {
+		return fmt.Errorf("can't write deployment view: %w", err)
 	}
-
-	// Include specific elements
-	for _, element := range view.Elements() {
-		r.WriteLine(fmt.Sprintf("%s %s", dsl.Include, renderNamer(element)))
-	}
-
-	// Include specific relationships
-	for _, rs := range view.RelationShips() {
-		from := rs.From().Name()
-		to := rs.To().Name()
-		r.WriteLine(fmt.Sprintf("%s %s %s %s", dsl.Include, from, dsl.Arrow, to))
-	}
-
-	// End deployment view
-	r.level--
-	r.WriteLine(dsl.CloseBracket)
-
 	return nil
-}
\ No newline at end of file
+}
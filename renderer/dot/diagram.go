@@ -0,0 +1,347 @@
+package dot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/platelk/gostructurizr"
+	"github.com/platelk/gostructurizr/renderer"
+	"github.com/platelk/gostructurizr/shapes"
+	"github.com/platelk/gostructurizr/tags"
+)
+
+// diagram accumulates the statements of a digraph
+type diagram struct {
+	title  string
+	styles *gostructurizr.StylesNode
+	// orders numbers the steps of dynamic views
+	orders  map[*gostructurizr.RelationShipNode]int
+	body    strings.Builder
+	aliases *renderer.Aliases
+	// nodes holds the elements drawn as nodes, the only ones edges can be drawn between
+	nodes map[gostructurizr.Namer]bool
+}
+
+// cluster is the alias key of the subgraph drawn around the children of a deployment node
+type cluster struct {
+	parent gostructurizr.Namer
+}
+
+func newDiagram(title string, styles *gostructurizr.StylesNode) *diagram {
+	return &diagram{
+		title:   title,
+		styles:  styles,
+		aliases: renderer.NewAliases(),
+		nodes:   map[gostructurizr.Namer]bool{},
+	}
+}
+
+func (d *diagram) graph(key string) string {
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "digraph %s {\n", quote(key))
+	fmt.Fprintf(&b, "    graph [label=%s, labelloc=t, fontname=\"Arial\", rankdir=TB]\n", quote(d.title))
+	b.WriteString("    node [shape=box, style=filled, fillcolor=\"#dddddd\", fontname=\"Arial\"]\n")
+	b.WriteString("    edge [fontname=\"Arial\"]\n\n")
+	b.WriteString(d.body.String())
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func (d *diagram) writeLine(level int, format string, args ...interface{}) {
+	d.body.WriteString(strings.Repeat("    ", level))
+	fmt.Fprintf(&d.body, format, args...)
+	d.body.WriteString("\n")
+}
+
+// label writes the name, type and description of an element the way Structurizr draws them
+func label(name, elementType, technology, desc string) string {
+	if technology != "" {
+		elementType += ": " + technology
+	}
+	l := name + "\n[" + elementType + "]"
+	if desc != "" {
+		l += "\n\n" + desc
+	}
+	return l
+}
+
+func (d *diagram) element(n gostructurizr.Namer, level int) {
+	var l string
+	switch e := n.(type) {
+	case *gostructurizr.PersonNode:
+		l = label(e.Name(), "Person", "", deref(e.Description()))
+	case *gostructurizr.SoftwareSystemNode:
+		l = label(e.Name(), "Software System", "", deref(e.Description()))
+	case *gostructurizr.ContainerNode:
+		l = label(e.Name(), "Container", deref(e.Technology()), deref(e.Description()))
+	case *gostructurizr.ComponentNode:
+		l = label(e.Name(), "Component", deref(e.Technology()), deref(e.Description()))
+	case *gostructurizr.ContainerInstanceNode:
+		l = label(e.Name(), "Container", deref(e.Container().Technology()), deref(e.Container().Description()))
+	case *gostructurizr.InfrastructureNodeNode:
+		l = label(e.Name(), "Infrastructure Node", e.Technology(), e.Description())
+	case *gostructurizr.DeploymentNodeNode:
+		l = label(e.Name(), "Deployment Node", e.Technology(), e.Description())
+	default:
+		return
+	}
+	attributes := append([]string{"label=" + quote(l)}, d.elementAttributes(renderer.ElementTags(n))...)
+	if _, ok := n.(*gostructurizr.DeploymentNodeNode); ok && !d.hasShape(renderer.ElementTags(n)) {
+		attributes = append(attributes, "shape=box3d")
+	}
+	d.writeLine(level, "%s [%s]", quote(d.aliases.Alias(n, n.Name())), strings.Join(attributes, ", "))
+	d.nodes[n] = true
+}
+
+// clusteredElements writes the elements of a view, containers and components being drawn in a
+// cluster for their parent and grouped elements in a cluster for their group, enterprise adding a cluster
+// for the people and software systems inside the enterprise
+func (d *diagram) clusteredElements(m *gostructurizr.ModelNode, content *gostructurizr.ViewContent, enterprise bool) {
+	elements, boundaries := renderer.Boundaries(m, content, enterprise)
+	for _, e := range elements {
+		d.element(e, 1)
+	}
+	for _, b := range boundaries {
+		d.cluster(b, 1)
+	}
+	d.relationships(content.RelationShips())
+}
+
+func (d *diagram) cluster(b *renderer.Boundary, level int) {
+	l := b.Name
+	switch b.Parent.(type) {
+	case *gostructurizr.SoftwareSystemNode:
+		l += "\n[Software System]"
+	case *gostructurizr.ContainerNode:
+		l += "\n[Container]"
+	case *gostructurizr.EnterpriseNode:
+		l += "\n[Enterprise]"
+	}
+	d.writeLine(level, "subgraph %s {", quote("cluster_"+d.aliases.Alias(b, b.Name)))
+	d.writeLine(level+1, "label=%s", quote(l))
+	d.writeLine(level+1, "style=dashed")
+	for _, attribute := range d.clusterAttributes(b.Tags) {
+		d.writeLine(level+1, "%s", attribute)
+	}
+	for _, e := range b.Elements {
+		d.element(e, level+1)
+	}
+	for _, nested := range b.Boundaries {
+		d.cluster(nested, level+1)
+	}
+	d.writeLine(level, "}")
+}
+
+// clusterAttributes applies the colors of the styles matching the tags of a group, later styles overriding earlier ones
+func (d *diagram) clusterAttributes(groupTags []string) []string {
+	var color, fontColor string
+	for _, style := range d.styles.ElementsStyle() {
+		if hasTag(groupTags, style.Tag()) {
+			color = override(color, style.Stroke())
+			fontColor = override(fontColor, style.Color())
+		}
+	}
+	var attributes []string
+	if color != "" {
+		attributes = append(attributes, "color="+quote(color))
+	}
+	if fontColor != "" {
+		attributes = append(attributes, "fontcolor="+quote(fontColor))
+	}
+	return attributes
+}
+
+// deploymentNode draws a deployment node as a cluster holding its children, or as a node when
+// none of its children are displayed
+func (d *diagram) deploymentNode(n *gostructurizr.DeploymentNodeNode, content *gostructurizr.ViewContent, level int) {
+	var children []gostructurizr.Namer
+	for _, child := range n.Children() {
+		children = append(children, child)
+	}
+	for _, infra := range n.InfrastructureNodes() {
+		children = append(children, infra)
+	}
+	for _, instance := range n.ContainerInstances() {
+		children = append(children, instance)
+	}
+	var displayed []gostructurizr.Namer
+	for _, child := range children {
+		if content.Contains(child) {
+			displayed = append(displayed, child)
+		}
+	}
+	if len(displayed) == 0 {
+		d.element(n, level)
+		return
+	}
+	d.writeLine(level, "subgraph %s {", quote("cluster_"+d.aliases.Alias(cluster{n}, n.Name())))
+	d.writeLine(level+1, "label=%s", quote(n.Name()+"\n[Deployment Node: "+n.Technology()+"]"))
+	for _, child := range displayed {
+		if node, ok := child.(*gostructurizr.DeploymentNodeNode); ok {
+			d.deploymentNode(node, content, level+1)
+		} else {
+			d.element(child, level+1)
+		}
+	}
+	d.writeLine(level, "}")
+}
+
+func (d *diagram) relationships(relationships []*gostructurizr.RelationShipNode) {
+	for _, r := range relationships {
+		if !d.nodes[r.From()] || !d.nodes[r.To()] {
+			continue
+		}
+		from, _ := d.aliases.Lookup(r.From())
+		to, _ := d.aliases.Lookup(r.To())
+		l := deref(r.Description())
+		if order, ok := d.orders[r]; ok {
+			l = strconv.Itoa(order) + ". " + l
+		}
+		if r.Technology() != nil && *r.Technology() != "" {
+			l += "\n[" + *r.Technology() + "]"
+		}
+		attributes := append([]string{"label=" + quote(l)}, d.relationShipAttributes(renderer.RelationShipTags(r))...)
+		d.writeLine(1, "%s -> %s [%s]", quote(from), quote(to), strings.Join(attributes, ", "))
+	}
+}
+
+// elementAttributes applies the styles matching the tags of an element, later styles overriding earlier ones
+func (d *diagram) elementAttributes(elementTags []string) []string {
+	var background, color, stroke, shape, border, strokeWidth string
+	for _, style := range d.styles.ElementsStyle() {
+		if !hasTag(elementTags, style.Tag()) {
+			continue
+		}
+		background = override(background, style.Background())
+		color = override(color, style.Color())
+		stroke = override(stroke, style.Stroke())
+		if style.Shape() != nil {
+			shape = string(*style.Shape())
+		}
+		if style.BorderStyle() != nil {
+			border = strings.ToLower(string(*style.BorderStyle()))
+		}
+		if style.StrokeWidth() != nil {
+			strokeWidth = strconv.Itoa(*style.StrokeWidth())
+		}
+	}
+	var attributes []string
+	appendAttribute := func(name, value string) {
+		if value != "" {
+			attributes = append(attributes, name+"="+quote(value))
+		}
+	}
+	appendAttribute("fillcolor", background)
+	appendAttribute("fontcolor", color)
+	appendAttribute("color", stroke)
+	appendAttribute("penwidth", strokeWidth)
+	dotShape, rounded := dotShape(shape)
+	appendAttribute("shape", dotShape)
+	if rounded || (border != "" && border != "solid") {
+		styles := []string{"filled"}
+		if rounded {
+			styles = append(styles, "rounded")
+		}
+		if border != "" && border != "solid" {
+			styles = append(styles, border)
+		}
+		appendAttribute("style", strings.Join(styles, ","))
+	}
+	return attributes
+}
+
+// hasShape reports whether a style gives a shape to an element
+func (d *diagram) hasShape(elementTags []string) bool {
+	for _, style := range d.styles.ElementsStyle() {
+		if hasTag(elementTags, style.Tag()) && style.Shape() != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// relationShipAttributes applies the styles matching the tags of a relationship, later styles overriding earlier ones
+func (d *diagram) relationShipAttributes(relationShipTags []string) []string {
+	var color, fontColor, lineStyle, thickness string
+	for _, style := range d.styles.RelationshipStyles() {
+		if !hasTag(relationShipTags, style.Tag()) {
+			continue
+		}
+		color = override(color, style.Color())
+		fontColor = override(fontColor, style.FontColor())
+		if style.LineStyle() != nil {
+			lineStyle = strings.ToLower(string(*style.LineStyle()))
+		}
+		if style.Thickness() != nil {
+			thickness = strconv.Itoa(*style.Thickness())
+		}
+	}
+	var attributes []string
+	appendAttribute := func(name, value string) {
+		if value != "" {
+			attributes = append(attributes, name+"="+quote(value))
+		}
+	}
+	appendAttribute("color", color)
+	appendAttribute("fontcolor", fontColor)
+	appendAttribute("style", lineStyle)
+	appendAttribute("penwidth", thickness)
+	return attributes
+}
+
+// dotShape returns the Graphviz shape closest to a Structurizr shape, and whether its corners are rounded
+func dotShape(shape string) (string, bool) {
+	switch strings.ToLower(shape) {
+	case "":
+		return "", false
+	case strings.ToLower(string(shapes.Person)), strings.ToLower(string(shapes.RoundedBox)):
+		return "box", true
+	case strings.ToLower(string(shapes.Cylinder)):
+		return "cylinder", false
+	case strings.ToLower(string(shapes.Pipe)):
+		return "cds", false
+	case strings.ToLower(string(shapes.Hexagon)):
+		return "hexagon", false
+	case strings.ToLower(string(shapes.Circle)):
+		return "circle", false
+	case strings.ToLower(string(shapes.Ellipse)):
+		return "ellipse", false
+	case strings.ToLower(string(shapes.Folder)):
+		return "folder", false
+	case strings.ToLower(string(shapes.Component)):
+		return "component", false
+	}
+	return "box", false
+}
+
+func override(current string, value *string) string {
+	if value == nil || *value == "" {
+		return current
+	}
+	return *value
+}
+
+func hasTag(elementTags []string, tag tags.Tag) bool {
+	for _, t := range elementTags {
+		if t == tag.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// quote writes a DOT string, line breaks being kept as escape sequences
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,102 @@
+// Package dot renders the views of a workspace as Graphviz digraphs, for generating images
+// with the dot command alone (dot -Tsvg -O views.dot).
+package dot
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/platelk/gostructurizr"
+	"github.com/platelk/gostructurizr/renderer"
+)
+
+// Diagram is the DOT source of a single view
+type Diagram struct {
+	Key     string
+	Content string
+}
+
+// Renderer writes one digraph per view, named after the key of the view
+type Renderer struct {
+	writer io.Writer
+}
+
+func NewRenderer(writer io.Writer) *Renderer {
+	return &Renderer{
+		writer: writer,
+	}
+}
+
+func (r *Renderer) Render(w *gostructurizr.WorkspaceNode) error {
+	var graphs []string
+	for _, d := range Diagrams(w) {
+		graphs = append(graphs, d.Content)
+	}
+	if _, err := io.WriteString(r.writer, strings.Join(graphs, "\n")); err != nil {
+		return fmt.Errorf("can't write dot: %w", err)
+	}
+	return nil
+}
+
+// Diagrams returns the digraph of every view of the workspace, filtered views excepted
+func Diagrams(w *gostructurizr.WorkspaceNode) []Diagram {
+	views := w.Views()
+	keys := renderer.ViewKeys(views)
+	styles := views.Configuration().Styles()
+	var diagrams []Diagram
+	add := func(view interface{}, d *diagram) {
+		diagrams = append(diagrams, Diagram{Key: keys[view], Content: d.graph(keys[view])})
+	}
+	for _, v := range views.SystemLandscapeViews() {
+		scope := ""
+		if w.Model().Enterprise() != nil {
+			scope = w.Model().Enterprise().Name()
+		}
+		d := newDiagram(renderer.ViewTitle("System Landscape", v.Description(), scope), styles)
+		d.clusteredElements(w.Model(), v.Content(), v.IsEnterpriseBoundaryVisible())
+		add(v, d)
+	}
+	for _, v := range views.SystemContextViews() {
+		d := newDiagram(renderer.ViewTitle("System Context", v.Description(), v.SoftwareSystem().Name()), styles)
+		d.clusteredElements(w.Model(), v.Content(), v.IsEnterpriseBoundaryVisible())
+		add(v, d)
+	}
+	for _, v := range views.ContainerViews() {
+		d := newDiagram(renderer.ViewTitle("Container", v.Description(), v.SoftwareSystem().Name()), styles)
+		d.clusteredElements(w.Model(), v.Content(), false)
+		add(v, d)
+	}
+	for _, v := range views.ComponentViews() {
+		d := newDiagram(renderer.ViewTitle("Component", v.Description(), v.Container().Parent().Name()+" - "+v.Container().Name()), styles)
+		d.clusteredElements(w.Model(), v.Content(), false)
+		add(v, d)
+	}
+	for _, v := range views.DynamicViews() {
+		scope := ""
+		if v.Identifier() != nil {
+			scope = v.Identifier().Name()
+		}
+		d := newDiagram(renderer.ViewTitle("Dynamic", v.Description(), scope), styles)
+		d.orders = renderer.StepOrders(v)
+		d.clusteredElements(w.Model(), v.Content(), false)
+		add(v, d)
+	}
+	for _, v := range views.DeploymentViews() {
+		scope := string(v.Environment())
+		if v.SoftwareSystem() != nil {
+			scope = v.SoftwareSystem().Name() + " - " + scope
+		}
+		desc := v.GetDescription()
+		d := newDiagram(renderer.ViewTitle("Deployment", &desc, scope), styles)
+		content := v.Content()
+		for _, e := range v.Elements() {
+			if node, ok := e.(*gostructurizr.DeploymentNodeNode); ok {
+				d.deploymentNode(node, content, 1)
+			}
+		}
+		d.relationships(content.RelationShips())
+		add(v, d)
+	}
+	return diagrams
+}
@@ -0,0 +1,89 @@
+package dot
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platelk/gostructurizr"
+	"github.com/platelk/gostructurizr/shapes"
+	"github.com/platelk/gostructurizr/tags"
+)
+
+func testWorkspace() *gostructurizr.WorkspaceNode {
+	w := gostructurizr.Workspace()
+	m := w.Model()
+	customer := m.AddPerson("Customer", "Buys things")
+	shop := m.AddSoftwareSystem("Online Shop", "Sells things")
+	payment := m.AddSoftwareSystem("Payment", "Takes the \"money\"").WithTag(tags.External.String())
+	web := shop.AddContainer("Web", "Storefront", "Go")
+	db := shop.AddContainer("Database", "Stores orders", "PostgreSQL").WithTag(tags.Database.String())
+	web.AddComponent("Cart").WithTechnology("Go package")
+	customer.Uses(shop, "Shops at")
+	customer.Uses(web, "Browses").WithTechnology("HTTPS")
+	web.Uses(db, "Reads from")
+	web.Uses(payment, "Charges").WithInteractionStyle(gostructurizr.Asynchronous)
+
+	prod := m.AddProdNode("AWS", "Amazon", "Cloud")
+	prod.AddChildNode("Cluster", "Kubernetes", "EKS").AddContainerInstance(web)
+	prod.AddChildNode("Backup", "Cold storage", "S3")
+
+	views := w.Views()
+	views.CreateSystemContextView(shop).WithKey("Context").AddAllElements()
+	views.CreateContainerView(shop).AddAllElements()
+	views.CreateComponentView(web).AddAllElements()
+	views.CreateDynamicView(shop).WithKey("Checkout").Add(customer, web, "Checks out").Add(web, payment, "Charges")
+	views.CreateDeploymentView(shop, gostructurizr.ProductionEnvironment).WithKey("Prod").AddDeploymentNode(prod)
+	styles := views.Configuration().Styles()
+	styles.AddElementStyle(tags.Person).WithShape(shapes.Person).WithBackground("#08427b").WithColor("#ffffff")
+	styles.AddElementStyle(tags.Database).WithShape(shapes.Cylinder)
+	styles.AddElementStyle(tags.External).WithBorderStyle(gostructurizr.Dashed).WithStroke("#999999")
+	styles.AddAdvancedRelationshipStyle(tags.Asynchronous).WithDashed().WithColor("#aa0000").WithThickness(2)
+	return w
+}
+
+func TestDiagrams(t *testing.T) {
+	diagrams := Diagrams(testWorkspace())
+	require.Len(t, diagrams, 5)
+	assert.Equal(t, "Container-001", diagrams[1].Key)
+
+	assert.Equal(t, `digraph "Container-001" {
+    graph [label="[Container] Online Shop", labelloc=t, fontname="Arial", rankdir=TB]
+    node [shape=box, style=filled, fillcolor="#dddddd", fontname="Arial"]
+    edge [fontname="Arial"]
+
+    "customer" [label="Customer\n[Person]\n\nBuys things", fillcolor="#08427b", fontcolor="#ffffff", shape="box", style="filled,rounded"]
+    "payment" [label="Payment\n[Software System]\n\nTakes the \"money\"", color="#999999", style="filled,dashed"]
+    subgraph "cluster_onlineShop" {
+        label="Online Shop\n[Software System]"
+        style=dashed
+        "web" [label="Web\n[Container: Go]\n\nStorefront"]
+        "database" [label="Database\n[Container: PostgreSQL]\n\nStores orders", shape="cylinder"]
+    }
+    "customer" -> "web" [label="Browses\n[HTTPS]"]
+    "web" -> "database" [label="Reads from"]
+    "web" -> "payment" [label="Charges", color="#aa0000", style="dashed", penwidth="2"]
+}
+`, diagrams[1].Content)
+
+	assert.Contains(t, diagrams[0].Content, `"customer" -> "onlineShop" [label="Shops at"]`)
+	assert.Contains(t, diagrams[2].Content, `subgraph "cluster_web" {`)
+	assert.Contains(t, diagrams[3].Content, `"web" -> "payment" [label="2. Charges"`)
+	assert.Contains(t, diagrams[4].Content, `    subgraph "cluster_aws" {
+        label="AWS\n[Deployment Node: Cloud]"
+        subgraph "cluster_cluster" {
+            label="Cluster\n[Deployment Node: EKS]"
+            "web" [label="Web\n[Container: Go]\n\nStorefront"]
+        }
+        "backup" [label="Backup\n[Deployment Node: S3]\n\nCold storage", shape=box3d]
+    }`)
+}
+
+func TestRenderer_Render(t *testing.T) {
+	buf := bytes.Buffer{}
+	require.NoError(t, NewRenderer(&buf).Render(testWorkspace()))
+	assert.Equal(t, 5, strings.Count(buf.String(), "digraph "))
+}
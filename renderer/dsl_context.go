@@ -0,0 +1,65 @@
+package renderer
+
+import (
+	"sort"
+
+	"github.com/platelk/gostructurizr"
+)
+
+// dslContext holds what the DSL renderers need beyond the node they render.
+// A nil context renders identifiers generated from the names, in model order.
+type dslContext struct {
+	ids *gostructurizr.ElementIdentifiers
+	// canonical sorts the elements and relationships by identifier instead of keeping the model order
+	canonical bool
+	// explicitImplied writes the implied relationships instead of the strategy deriving them
+	explicitImplied bool
+}
+
+// id returns the identifier an element is referenced by
+func (c *dslContext) id(n gostructurizr.Namer) string {
+	if c == nil {
+		return gostructurizr.ElementIdentifier(n)
+	}
+	return c.ids.Of(n)
+}
+
+// local returns the identifier an element is declared with
+func (c *dslContext) local(n gostructurizr.Namer) string {
+	if c == nil {
+		return gostructurizr.ElementIdentifier(n)
+	}
+	return c.ids.Local(n)
+}
+
+// ordered returns the elements in the order they are written in
+func ordered[T gostructurizr.Namer](c *dslContext, elements []T) []T {
+	if c == nil || !c.canonical {
+		return elements
+	}
+	sorted := append([]T(nil), elements...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return c.id(sorted[i]) < c.id(sorted[j])
+	})
+	return sorted
+}
+
+// orderedRelationShips returns the relationships in the order they are written in,
+// sorted by source, destination then description in canonical order
+func orderedRelationShips(c *dslContext, relationShips []*gostructurizr.RelationShipNode) []*gostructurizr.RelationShipNode {
+	if c == nil || !c.canonical {
+		return relationShips
+	}
+	sorted := append([]*gostructurizr.RelationShipNode(nil), relationShips...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if c.id(a.From()) != c.id(b.From()) {
+			return c.id(a.From()) < c.id(b.From())
+		}
+		if c.id(a.To()) != c.id(b.To()) {
+			return c.id(a.To()) < c.id(b.To())
+		}
+		return deref(a.Description()) < deref(b.Description())
+	})
+	return sorted
+}
@@ -0,0 +1,114 @@
+package renderer_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platelk/gostructurizr"
+	"github.com/platelk/gostructurizr/renderer"
+	"github.com/platelk/gostructurizr/renderer/dot"
+	"github.com/platelk/gostructurizr/renderer/mermaid"
+	"github.com/platelk/gostructurizr/renderer/plantuml"
+	"github.com/platelk/gostructurizr/shapes"
+	"github.com/platelk/gostructurizr/tags"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// goldenWorkspace builds a workspace using the features whose rendering could depend on map ordering
+func goldenWorkspace() *gostructurizr.WorkspaceNode {
+	w := gostructurizr.Workspace().WithName("Shop").WithDesc("Golden workspace")
+	m := w.Model()
+	customer := m.AddPerson("Customer", "Buys things")
+	customer.Tags().Add("External")
+	shop := m.AddSoftwareSystem("Shop", "Sells things")
+	web := shop.AddContainer("Web", "Storefront", "Go")
+	api := shop.AddContainer("API", "Backend", "Go")
+	api.WithTag("Service").WithTag("Critical")
+	api.Properties().Add("openapi", "Shop API 1.0.0")
+	orders := api.AddComponent("Orders")
+	payment := m.AddSoftwareSystem("Payment", "Charges cards")
+	m.AddGroup("Retail").Add(shop).AddGroup("Billing").Add(payment)
+	m.SetEnterprise("Acme").Add(shop, payment)
+	shop.AddGroup("Frontend").Add(web)
+	customer.Uses(web, "Visits").WithTechnology("HTTPS")
+	web.Uses(api, "Calls")
+	orders.Uses(payment, "Charges").WithInteractionStyle(gostructurizr.Asynchronous)
+
+	server := m.AddProdNode("Server", "Hosts the shop", "Linux")
+	server.Properties().Add("zone", "eu-west-1a").Add("cpu", "4").Add("memory", "16GB").Add("arch", "arm64")
+	webInstance := server.AddContainerInstance(web)
+	webInstance.AddHealthCheck("Ready", "http://localhost/ready").WithInterval(30)
+	server.AddContainerInstance(api).Properties().Add("replicas", "3").Add("image", "shop/api")
+	loadBalancer := server.AddInfrastructureNode("Load Balancer", "", "nginx")
+	loadBalancer.Uses(webInstance, "Forwards to")
+	server.AddChildNode("Database", "", "PostgreSQL").WithTag("Storage")
+
+	views := w.Views()
+	views.CreateSystemLandscapeView().WithKey("Landscape").AddAllElements()
+	views.CreateSystemContextView(shop).WithKey("Context").AddAllElements()
+	views.CreateContainerView(shop).WithKey("Containers").AddAllContainers().WithAutoLayout()
+	views.CreateComponentView(api).WithKey("Components").AddAllComponents()
+	views.CreateProdView(shop).WithKey("Production").AddDeploymentNode(server).WithAutoLayout()
+	styles := views.Configuration().Styles()
+	styles.AddElementStyle(tags.Person).WithShape(shapes.Person).WithBackground("#08427b")
+	styles.AddElementStyle("Critical").WithColor("#ff0000")
+	styles.AddElementStyle(tags.Container).WithBackground("#438dd5")
+	styles.AddElementStyle(tags.Group).WithStroke("#999999")
+	styles.AddRelationshipStyle(tags.Asynchronous).WithThickness(2).WithColor("#aa0000").WithDash(true).WithOrthogonalRouting()
+	return w
+}
+
+type renderFunc func(w *gostructurizr.WorkspaceNode, out *bytes.Buffer) error
+
+func TestGolden(t *testing.T) {
+	tests := []struct {
+		file   string
+		render renderFunc
+	}{
+		{"workspace.dsl", func(w *gostructurizr.WorkspaceNode, out *bytes.Buffer) error {
+			return renderer.NewDSLRenderer(out).Render(w)
+		}},
+		{"workspace_canonical.dsl", func(w *gostructurizr.WorkspaceNode, out *bytes.Buffer) error {
+			return renderer.NewDSLRenderer(out).WithCanonicalOrder().Render(w)
+		}},
+		{"workspace.json", func(w *gostructurizr.WorkspaceNode, out *bytes.Buffer) error {
+			return renderer.NewJSONRenderer(out).Render(w)
+		}},
+		{"workspace.puml", func(w *gostructurizr.WorkspaceNode, out *bytes.Buffer) error {
+			return plantuml.NewRenderer(out).Render(w)
+		}},
+		{"workspace.mmd", func(w *gostructurizr.WorkspaceNode, out *bytes.Buffer) error {
+			return mermaid.NewRenderer(out).Render(w)
+		}},
+		{"workspace.dot", func(w *gostructurizr.WorkspaceNode, out *bytes.Buffer) error {
+			return dot.NewRenderer(out).Render(w)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			out := bytes.Buffer{}
+			require.NoError(t, tt.render(goldenWorkspace(), &out))
+			path := filepath.Join("testdata", tt.file+".golden")
+			if *update {
+				require.NoError(t, os.WriteFile(path, out.Bytes(), 0o644))
+			}
+			golden, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, string(golden), out.String())
+
+			// map iteration order changes between runs, rendering again has to give the same bytes
+			for i := 0; i < 20; i++ {
+				again := bytes.Buffer{}
+				require.NoError(t, tt.render(goldenWorkspace(), &again))
+				require.Equal(t, out.String(), again.String())
+			}
+		})
+	}
+}
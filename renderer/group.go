@@ -0,0 +1,59 @@
+package renderer
+
+import (
+	"strings"
+
+	"github.com/platelk/gostructurizr"
+	"github.com/platelk/gostructurizr/dsl"
+)
+
+// renderGroups renders groups and their nested groups, skipping the ones holding no member accepted by include
+func renderGroups[K gostructurizr.Namer](groups []*gostructurizr.GroupNode[K], ctx *dslContext, renderer *strings.Builder, level int,
+	include func(K) bool, render func(K, int) error) error {
+	for _, g := range groups {
+		if !groupIncludes(g, include) {
+			continue
+		}
+		writeLine(renderer, level, dsl.Group, dsl.Space, generateStringIdentifier(g.Name()), dsl.Space, dsl.OpenBracket)
+		for _, member := range ordered(ctx, g.Members()) {
+			if !include(member) {
+				continue
+			}
+			if err := render(member, level+1); err != nil {
+				return err
+			}
+		}
+		if err := renderGroups(g.Groups(), ctx, renderer, level+1, include, render); err != nil {
+			return err
+		}
+		writeLine(renderer, level, dsl.CloseBracket)
+	}
+	return nil
+}
+
+func groupIncludes[K gostructurizr.Namer](g *gostructurizr.GroupNode[K], include func(K) bool) bool {
+	for _, member := range g.Members() {
+		if include(member) {
+			return true
+		}
+	}
+	for _, nested := range g.Groups() {
+		if groupIncludes(nested, include) {
+			return true
+		}
+	}
+	return false
+}
+
+// modelProperties returns the properties of the model, with the group separator nested groups require
+func modelProperties(m *gostructurizr.ModelNode) *gostructurizr.Properties {
+	if !m.HasNestedGroups() {
+		return m.Properties()
+	}
+	properties := gostructurizr.NewProperties()
+	for key, value := range m.Properties().Properties {
+		properties.Add(key, value)
+	}
+	properties.Add(dsl.GroupSeparator, gostructurizr.GroupSeparator)
+	return &properties
+}
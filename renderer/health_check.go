@@ -1,8 +1,8 @@
 package renderer
 
 import (
-	"fmt"
 	"io"
+	"strconv"
 
 	"github.com/platelk/gostructurizr"
 	"github.com/platelk/gostructurizr/dsl"
@@ -23,33 +23,17 @@ func NewHealthCheckRenderer(w io.Writer, level int) *HealthCheckRenderer {
 	}
 }
 
-// Render renders a health check to DSL
+// Render renders a health check to DSL as `healthCheck <name> <url> [interval] [timeout]`
 func (r *HealthCheckRenderer) Render(healthCheck *gostructurizr.HealthCheckNode) error {
-	r.WriteLine(dsl.HealthCheck + dsl.Space + dsl.OpenBracket)
-	r.level++
-
-	// Name
-	r.WriteLine(fmt.Sprintf("%s %q", dsl.Name, healthCheck.Name()))
-
-	// URL
-	r.WriteLine(fmt.Sprintf("%s %q", dsl.Url, healthCheck.Url()))
-
-	// Interval if not default
-	if healthCheck.Interval() != 60 {
-		r.WriteLine(fmt.Sprintf("%s %d", dsl.Interval, healthCheck.Interval()))
+	line := dsl.HealthCheck + dsl.Space + generateStringIdentifier(healthCheck.Name()) + dsl.Space + generateStringIdentifier(healthCheck.Url())
+	// the interval has to be written for the timeout to be
+	if healthCheck.Interval() != 60 || healthCheck.Timeout() != 1000 {
+		line += dsl.Space + strconv.Itoa(healthCheck.Interval())
 	}
-
-	// Timeout if not default
 	if healthCheck.Timeout() != 1000 {
-		r.WriteLine(fmt.Sprintf("%s %d", dsl.Timeout, healthCheck.Timeout()))
+		line += dsl.Space + strconv.Itoa(healthCheck.Timeout())
 	}
-
-	// Properties
-	renderProperties(r.w, healthCheck.Properties(), r.level)
-
-	// End health check
-	r.level--
-	r.WriteLine(dsl.CloseBracket)
+	r.WriteLine("%s", line)
 
 	return nil
-}
\ No newline at end of file
+}
@@ -1,11 +1,11 @@
 package renderer
 
 import (
-	"fmt"
 	"io"
 
 	"github.com/platelk/gostructurizr"
 	"github.com/platelk/gostructurizr/dsl"
+	"github.com/platelk/gostructurizr/tags"
 )
 
 // InfrastructureNodeRenderer renders an infrastructure node to DSL
@@ -25,31 +25,21 @@ func NewInfrastructureNodeRenderer(w io.Writer, level int) *InfrastructureNodeRe
 
 // Render renders an infrastructure node to DSL
 func (r *InfrastructureNodeRenderer) Render(node *gostructurizr.InfrastructureNodeNode) error {
-	r.WriteLine(dsl.InfrastructureNode + dsl.Space + dsl.OpenBracket)
-	r.level++
-
-	// Name
-	r.WriteLine(fmt.Sprintf("%s %q", dsl.Name, node.Name()))
-
-	// Description if available
-	if node.Description() != "" {
-		r.WriteLine(fmt.Sprintf("%s %q", dsl.Description, node.Description()))
-	}
-
-	// Technology
-	if node.Technology() != "" {
-		r.WriteLine(fmt.Sprintf("%s %q", dsl.Technology, node.Technology()))
+	line := r.ctx.local(node) + dsl.Space + dsl.Equal + dsl.Space + dsl.InfrastructureNode + dsl.Space +
+		elementArguments(node.Name(), node.Description(), node.Technology())
+	customTags := withoutDefaultTags(node.Tags(), tags.InfrastructureNode)
+	if len(customTags.List()) == 0 && len(node.Properties().Properties) == 0 {
+		r.WriteLine("%s", line)
+		return nil
 	}
+	r.WriteLine("%s", line+dsl.Space+dsl.OpenBracket)
+	r.level++
 
-	// Properties
+	renderTags(r.w, customTags, r.level)
 	renderProperties(r.w, node.Properties(), r.level)
 
-	// Tags
-	renderTags(r.w, node.Tags(), r.level)
-
-	// End infrastructure node
 	r.level--
 	r.WriteLine(dsl.CloseBracket)
 
 	return nil
-}
\ No newline at end of file
+}
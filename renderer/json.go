@@ -0,0 +1,549 @@
+package renderer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/platelk/gostructurizr"
+	"github.com/platelk/gostructurizr/schema"
+	"github.com/platelk/gostructurizr/tags"
+)
+
+// JSONRenderer renders a workspace using the Structurizr JSON workspace format
+type JSONRenderer struct {
+	writer io.Writer
+}
+
+func NewJSONRenderer(writer io.Writer) *JSONRenderer {
+	return &JSONRenderer{
+		writer: writer,
+	}
+}
+
+func (r *JSONRenderer) Render(w *gostructurizr.WorkspaceNode) error {
+	return renderWrapper(r.writer, func(renderer *strings.Builder) error {
+		encoder := json.NewEncoder(renderer)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(JSONWorkspace(w)); err != nil {
+			return fmt.Errorf("can't encode workspace: %w", err)
+		}
+		return nil
+	})
+}
+
+// JSONWorkspace converts a workspace to its Structurizr JSON representation.
+// Elements and relationships get numeric identifiers assigned in model order,
+// so the same workspace always produces the same identifiers.
+func JSONWorkspace(w *gostructurizr.WorkspaceNode) *schema.Workspace {
+	b := &jsonBuilder{
+		elementIDs:      map[gostructurizr.Namer]string{},
+		relationshipIDs: map[*gostructurizr.RelationShipNode]string{},
+		relationships:   map[string][]schema.Relationship{},
+		stepRelations:   map[*gostructurizr.RelationShipNode]*gostructurizr.RelationShipNode{},
+		viewKeys:        ViewKeys(w.Views()),
+	}
+	b.assignIDs(w.Model())
+	b.assignStepIDs(w.Views())
+	doc := &schema.Workspace{
+		Model: b.model(w.Model()),
+		Views: b.views(w.Views()),
+	}
+	if w.Name() != nil {
+		doc.Name = *w.Name()
+	}
+	if w.Desc() != nil {
+		doc.Description = *w.Desc()
+	}
+	return doc
+}
+
+type jsonBuilder struct {
+	next            int
+	elementIDs      map[gostructurizr.Namer]string
+	relationshipIDs map[*gostructurizr.RelationShipNode]string
+	relationships   map[string][]schema.Relationship // by source element id
+	modelRelations  []*gostructurizr.RelationShipNode
+	stepRelations   map[*gostructurizr.RelationShipNode]*gostructurizr.RelationShipNode // dynamic view steps to the relationships they illustrate
+	viewKeys        map[interface{}]string
+}
+
+func (b *jsonBuilder) newID() string {
+	b.next++
+	return strconv.Itoa(b.next)
+}
+
+func (b *jsonBuilder) assignIDs(m *gostructurizr.ModelNode) {
+	for _, p := range m.Persons() {
+		b.elementIDs[p] = b.newID()
+	}
+	for _, s := range m.SoftwareSystems() {
+		b.elementIDs[s] = b.newID()
+		for _, c := range s.Containers() {
+			b.elementIDs[c] = b.newID()
+			for _, component := range c.Components() {
+				b.elementIDs[component] = b.newID()
+			}
+		}
+	}
+	var assignDeploymentNode func(d *gostructurizr.DeploymentNodeNode)
+	assignDeploymentNode = func(d *gostructurizr.DeploymentNodeNode) {
+		b.elementIDs[d] = b.newID()
+		for _, child := range d.Children() {
+			assignDeploymentNode(child)
+		}
+		for _, infra := range d.InfrastructureNodes() {
+			b.elementIDs[infra] = b.newID()
+		}
+		for _, instance := range d.ContainerInstances() {
+			b.elementIDs[instance] = b.newID()
+		}
+	}
+	for _, d := range m.DeploymentNodes() {
+		assignDeploymentNode(d)
+	}
+	for _, r := range m.RelationShip() {
+		b.addRelationship(r)
+	}
+}
+
+// assignStepIDs maps the steps of the dynamic views to the model relationships they illustrate.
+// A step matching no relationship of the model is added as a relationship of its own.
+func (b *jsonBuilder) assignStepIDs(v *gostructurizr.ViewsNode) {
+	for _, d := range v.DynamicViews() {
+		for _, step := range d.Steps() {
+			r := b.stepRelationship(step.RelationShip)
+			if r == nil && b.addRelationship(step.RelationShip) {
+				r = step.RelationShip
+			}
+			if r != nil {
+				b.stepRelations[step.RelationShip] = r
+			}
+		}
+	}
+}
+
+// stepRelationship returns the model relationship between the source and destination of a step,
+// preferring the one with the same description
+func (b *jsonBuilder) stepRelationship(step *gostructurizr.RelationShipNode) *gostructurizr.RelationShipNode {
+	var match *gostructurizr.RelationShipNode
+	for _, r := range b.modelRelations {
+		if r.From() != step.From() || r.To() != step.To() {
+			continue
+		}
+		if deref(r.Description()) == deref(step.Description()) {
+			return r
+		}
+		if match == nil {
+			match = r
+		}
+	}
+	return match
+}
+
+// addRelationship assigns an identifier to a relationship between two known elements,
+// it returns false when one of them isn't part of the model
+func (b *jsonBuilder) addRelationship(r *gostructurizr.RelationShipNode) bool {
+	sourceID, ok := b.elementIDs[r.From()]
+	if !ok {
+		return false
+	}
+	destinationID, ok := b.elementIDs[r.To()]
+	if !ok {
+		return false
+	}
+	id := b.newID()
+	b.relationshipIDs[r] = id
+	b.modelRelations = append(b.modelRelations, r)
+	b.relationships[sourceID] = append(b.relationships[sourceID], b.relationship(r, id, sourceID, destinationID))
+	return true
+}
+
+func (b *jsonBuilder) relationship(r *gostructurizr.RelationShipNode, id, sourceID, destinationID string) schema.Relationship {
+	rel := schema.Relationship{
+		ID:            id,
+		SourceID:      sourceID,
+		DestinationID: destinationID,
+		Description:   deref(r.Description()),
+		Technology:    deref(r.Technology()),
+		Tags:          jsonTags(r.Tags(), relationShipDefaults(r)...),
+		URL:           deref(r.URL()),
+	}
+	if r.InteractionStyle() != nil {
+		rel.InteractionStyle = capitalize(string(*r.InteractionStyle()))
+	}
+	if len(r.Properties().Properties) > 0 {
+		rel.Properties = r.Properties().Properties
+	}
+	for _, p := range r.Perspectives() {
+		rel.Perspectives = append(rel.Perspectives, schema.Perspective{Name: p.Name, Description: p.Description, Value: p.Value})
+	}
+	if r.IsImplied() {
+		rel.LinkedRelationshipID = b.relationshipIDs[r.ImpliedBy()]
+	}
+	return rel
+}
+
+// element fills the fields shared by all the elements
+func (b *jsonBuilder) element(n gostructurizr.Namer, name, desc string, elementTags *gostructurizr.TagsNode, properties *gostructurizr.Properties, defaults ...tags.Tag) schema.Element {
+	id := b.elementIDs[n]
+	e := schema.Element{
+		ID:            id,
+		Name:          name,
+		Description:   desc,
+		Tags:          jsonTags(elementTags, defaults...),
+		Relationships: b.relationships[id],
+	}
+	if properties != nil && len(properties.Properties) > 0 {
+		e.Properties = properties.Properties
+	}
+	return e
+}
+
+func (b *jsonBuilder) model(m *gostructurizr.ModelNode) schema.Model {
+	var model schema.Model
+	if m.Enterprise() != nil {
+		model.Enterprise = &schema.Enterprise{Name: m.Enterprise().Name()}
+	}
+	if properties := modelProperties(m); len(properties.Properties) > 0 {
+		model.Properties = properties.Properties
+	}
+	for _, p := range m.Persons() {
+		person := schema.Person{
+			Element:  b.element(p, p.Name(), deref(p.Description()), p.Tags(), nil, tags.Element, tags.Person),
+			Location: string(p.Location()),
+		}
+		person.Group = groupName(m.GroupOf(p))
+		model.People = append(model.People, person)
+	}
+	for _, s := range m.SoftwareSystems() {
+		system := schema.SoftwareSystem{
+			Element:  b.element(s, s.Name(), deref(s.Description()), s.Tags(), nil, tags.Element, tags.SoftwareSystem),
+			Location: string(s.Location()),
+		}
+		system.Group = groupName(m.GroupOf(s))
+		for _, c := range s.Containers() {
+			container := schema.Container{
+				Element:    b.element(c, c.Name(), deref(c.Description()), c.Tags(), c.Properties(), tags.Element, tags.Container),
+				Technology: deref(c.Technology()),
+			}
+			container.Group = groupName(s.GroupOf(c))
+			for _, co := range c.Components() {
+				component := schema.Component{
+					Element:    b.element(co, co.Name(), deref(co.Description()), co.Tags(), nil, tags.Element, tags.Component),
+					Technology: deref(co.Technology()),
+				}
+				component.Group = groupName(c.GroupOf(co))
+				container.Components = append(container.Components, component)
+			}
+			system.Containers = append(system.Containers, container)
+		}
+		model.SoftwareSystems = append(model.SoftwareSystems, system)
+	}
+	for _, d := range m.DeploymentNodes() {
+		model.DeploymentNodes = append(model.DeploymentNodes, b.deploymentNode(d))
+	}
+	return model
+}
+
+// groupName returns the full name of a group, empty for elements in none
+func groupName[K gostructurizr.Namer](g *gostructurizr.GroupNode[K]) string {
+	if g == nil {
+		return ""
+	}
+	return g.FullName()
+}
+
+func (b *jsonBuilder) deploymentNode(d *gostructurizr.DeploymentNodeNode) schema.DeploymentNode {
+	environment := string(d.Environment())
+	node := schema.DeploymentNode{
+		Element:     b.element(d, d.Name(), d.Description(), d.Tags(), d.Properties(), tags.Element),
+		Technology:  d.Technology(),
+		Environment: environment,
+	}
+	for _, child := range d.Children() {
+		node.Children = append(node.Children, b.deploymentNode(child))
+	}
+	for _, infra := range d.InfrastructureNodes() {
+		node.InfrastructureNodes = append(node.InfrastructureNodes, schema.InfrastructureNode{
+			Element:     b.element(infra, infra.Name(), infra.Description(), infra.Tags(), infra.Properties(), tags.Element),
+			Technology:  infra.Technology(),
+			Environment: environment,
+		})
+	}
+	for _, instance := range d.ContainerInstances() {
+		containerInstance := schema.ContainerInstance{
+			// container instances are identified by their container, they don't carry a name
+			Element:     b.element(instance, "", "", instance.Tags(), instance.Properties()),
+			ContainerID: b.elementIDs[instance.Container()],
+			InstanceID:  instance.InstanceId(),
+			Environment: environment,
+		}
+		for _, healthCheck := range instance.HealthChecks() {
+			check := schema.HealthCheck{
+				Name:     healthCheck.Name(),
+				URL:      healthCheck.Url(),
+				Interval: healthCheck.Interval(),
+				Timeout:  healthCheck.Timeout(),
+			}
+			if len(healthCheck.Properties().Properties) > 0 {
+				check.Properties = healthCheck.Properties().Properties
+			}
+			containerInstance.HealthChecks = append(containerInstance.HealthChecks, check)
+		}
+		node.ContainerInstances = append(node.ContainerInstances, containerInstance)
+	}
+	return node
+}
+
+func (b *jsonBuilder) view(key string, desc *string, autoLayout bool, content *gostructurizr.ViewContent, layout *gostructurizr.LayoutNode) schema.View {
+	v := schema.View{
+		Key:         key,
+		Description: deref(desc),
+	}
+	if autoLayout {
+		v.AutomaticLayout = &schema.AutomaticLayout{
+			Implementation: "Graphviz",
+			RankDirection:  "TopBottom",
+			RankSeparation: 300,
+			NodeSeparation: 300,
+		}
+	}
+	v.Elements = b.elementViews(content, layout)
+	for _, r := range content.RelationShips() {
+		if id, ok := b.relationshipIDs[r]; ok {
+			v.Relationships = append(v.Relationships, schema.RelationshipView{ID: id, Vertices: jsonVertices(layout.Vertices(r))})
+		}
+	}
+	return v
+}
+
+// elementViews lists the elements of a view with the position they were given in the layout
+func (b *jsonBuilder) elementViews(content *gostructurizr.ViewContent, layout *gostructurizr.LayoutNode) []schema.ElementView {
+	var elements []schema.ElementView
+	for _, e := range content.Elements() {
+		id, ok := b.elementIDs[e]
+		if !ok {
+			continue
+		}
+		element := schema.ElementView{ID: id}
+		if p, ok := layout.ElementPosition(e); ok {
+			element.X, element.Y = p.X, p.Y
+		}
+		elements = append(elements, element)
+	}
+	return elements
+}
+
+func jsonVertices(positions []gostructurizr.Position) []schema.Vertex {
+	var vertices []schema.Vertex
+	for _, p := range positions {
+		vertices = append(vertices, schema.Vertex{X: p.X, Y: p.Y})
+	}
+	return vertices
+}
+
+// hidden writes a visibility Structurizr defaults to true only when it is turned off
+func hidden(visible bool) *bool {
+	if visible {
+		return nil
+	}
+	return &visible
+}
+
+func (b *jsonBuilder) views(v *gostructurizr.ViewsNode) schema.Views {
+	var views schema.Views
+	for _, s := range v.SystemLandscapeViews() {
+		views.SystemLandscapeViews = append(views.SystemLandscapeViews, schema.SystemLandscapeView{
+			View:                      b.view(b.viewKeys[s], s.Description(), s.AutoLayout(), s.Content(), s.Layout()),
+			EnterpriseBoundaryVisible: hidden(s.IsEnterpriseBoundaryVisible()),
+		})
+	}
+	for _, s := range v.SystemContextViews() {
+		views.SystemContextViews = append(views.SystemContextViews, schema.SystemContextView{
+			View:                      b.view(b.viewKeys[s], s.Description(), s.AutoLayout(), s.Content(), s.Layout()),
+			SoftwareSystemID:          b.elementIDs[s.SoftwareSystem()],
+			EnterpriseBoundaryVisible: hidden(s.IsEnterpriseBoundaryVisible()),
+		})
+	}
+	for _, c := range v.ContainerViews() {
+		views.ContainerViews = append(views.ContainerViews, schema.ContainerView{
+			View:             b.view(b.viewKeys[c], c.Description(), c.AutoLayout(), c.Content(), c.Layout()),
+			SoftwareSystemID: b.elementIDs[c.SoftwareSystem()],
+		})
+	}
+	for _, c := range v.ComponentViews() {
+		views.ComponentViews = append(views.ComponentViews, schema.ComponentView{
+			View:        b.view(b.viewKeys[c], c.Description(), c.AutoLayout(), c.Content(), c.Layout()),
+			ContainerID: b.elementIDs[c.Container()],
+		})
+	}
+	for _, d := range v.DynamicViews() {
+		views.DynamicViews = append(views.DynamicViews, b.dynamicView(d))
+	}
+	for _, d := range v.DeploymentViews() {
+		desc := d.GetDescription()
+		view := schema.DeploymentView{
+			View:        b.view(b.viewKeys[d], &desc, d.IsAutoLayout(), d.Content(), d.Layout()),
+			Environment: string(d.Environment()),
+		}
+		if d.SoftwareSystem() != nil {
+			view.SoftwareSystemID = b.elementIDs[d.SoftwareSystem()]
+		}
+		views.DeploymentViews = append(views.DeploymentViews, view)
+	}
+	for _, f := range v.FilteredViews() {
+		views.FilteredViews = append(views.FilteredViews, b.filteredView(f))
+	}
+	views.Configuration = schema.Configuration{Styles: jsonStyles(v.Configuration().Styles())}
+	return views
+}
+
+func (b *jsonBuilder) dynamicView(d *gostructurizr.DynamicViewNode) schema.DynamicView {
+	content := d.Content()
+	view := schema.DynamicView{
+		View: schema.View{
+			Key:         b.viewKeys[d],
+			Description: deref(d.Description()),
+		},
+	}
+	if d.Identifier() != nil {
+		view.ElementID = b.elementIDs[d.Identifier()]
+	}
+	view.Elements = b.elementViews(content, d.Layout())
+	// steps reference the relationships of the model they illustrate
+	for _, step := range d.Steps() {
+		r, ok := b.stepRelations[step.RelationShip]
+		if !ok {
+			continue
+		}
+		view.Relationships = append(view.Relationships, schema.RelationshipView{
+			ID:          b.relationshipIDs[r],
+			Description: deref(step.RelationShip.Description()),
+			Order:       strconv.Itoa(step.Order),
+			Vertices:    jsonVertices(d.Layout().Vertices(step.RelationShip)),
+		})
+	}
+	return view
+}
+
+func (b *jsonBuilder) filteredView(f *gostructurizr.FilteredViewNode) schema.FilteredView {
+	view := schema.FilteredView{
+		Key:         b.viewKeys[f],
+		Title:       f.Title(),
+		Description: f.Description(),
+		Tags:        []string{},
+	}
+	if f.BaseView() != nil {
+		view.BaseViewKey = b.viewKeys[f.BaseView()]
+		if view.BaseViewKey == "" && f.BaseView().Key() != nil {
+			view.BaseViewKey = *f.BaseView().Key()
+		}
+	}
+	// the JSON format supports a single mode per filtered view, the one of the first tag filter wins
+	for _, criteria := range f.FilterCriteria() {
+		if criteria.Type != gostructurizr.TagFilter {
+			continue
+		}
+		if view.Mode == "" {
+			view.Mode = string(criteria.Mode)
+		}
+		if string(criteria.Mode) == view.Mode {
+			view.Tags = append(view.Tags, criteria.Value)
+		}
+	}
+	if view.Mode == "" {
+		view.Mode = string(gostructurizr.Include)
+	}
+	return view
+}
+
+func jsonStyles(s *gostructurizr.StylesNode) schema.Styles {
+	var styles schema.Styles
+	for _, e := range s.ElementsStyle() {
+		style := schema.ElementStyle{
+			Tag:         e.Tag().String(),
+			Width:       e.Width(),
+			Height:      e.Height(),
+			Background:  deref(e.Background()),
+			Stroke:      deref(e.Stroke()),
+			StrokeWidth: e.StrokeWidth(),
+			Color:       deref(e.Color()),
+			FontSize:    e.FontSize(),
+			Icon:        deref(e.Icon()),
+			Opacity:     e.Opacity(),
+			Metadata:    e.Metadata(),
+			Description: e.Description(),
+		}
+		if e.Shape() != nil {
+			style.Shape = capitalize(e.Shape().String())
+		}
+		if e.BorderStyle() != nil {
+			style.Border = capitalize(string(*e.BorderStyle()))
+		}
+		styles.Elements = append(styles.Elements, style)
+	}
+	for _, r := range s.RelationshipStyles() {
+		style := schema.RelationshipStyle{
+			Tag:       r.Tag().String(),
+			Thickness: r.Thickness(),
+			Width:     r.Width(),
+			Color:     deref(r.Color()),
+			FontSize:  r.FontSize(),
+			Position:  r.Position(),
+			Opacity:   r.Opacity(),
+		}
+		if r.LineStyle() != nil {
+			style.Style = capitalize(string(*r.LineStyle()))
+			dashed := *r.LineStyle() == gostructurizr.DashedLine
+			style.Dashed = &dashed
+		}
+		if r.Routing() != nil {
+			style.Routing = capitalize(string(*r.Routing()))
+		}
+		styles.Relationships = append(styles.Relationships, style)
+	}
+	return styles
+}
+
+// jsonTags joins the default tags of an element type with the tags of the element, without duplicates
+func jsonTags(elementTags *gostructurizr.TagsNode, defaults ...tags.Tag) string {
+	var all []string
+	seen := map[string]bool{}
+	add := func(tag string) {
+		if tag == "" || seen[tag] {
+			return
+		}
+		seen[tag] = true
+		all = append(all, tag)
+	}
+	for _, tag := range defaults {
+		add(tag.String())
+	}
+	if elementTags != nil {
+		for _, tag := range elementTags.List() {
+			add(tag)
+		}
+	}
+	return strings.Join(all, ",")
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,141 @@
+package renderer
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platelk/gostructurizr"
+	"github.com/platelk/gostructurizr/schema"
+	"github.com/platelk/gostructurizr/shapes"
+	"github.com/platelk/gostructurizr/tags"
+)
+
+func jsonTestWorkspace() *gostructurizr.WorkspaceNode {
+	w := gostructurizr.Workspace().WithName("Banking").WithDesc("Banking workspace")
+	m := w.Model()
+	customer := m.AddPerson("Customer", "A customer")
+	banking := m.AddSoftwareSystem("Internet Banking", "Online banking")
+	mainframe := m.AddSoftwareSystem("Mainframe", "Core banking").WithTag("Existing")
+	web := banking.AddContainer("Web", "Web application", "Go")
+	api := banking.AddContainer("API", "JSON API", "Go")
+	api.AddComponent("Accounts").WithTechnology("Go package")
+	customer.Uses(banking, "Uses")
+	customer.Uses(web, "Visits")
+	web.Uses(api, "Calls").WithTechnology("HTTPS").WithInteractionStyle(gostructurizr.Synchronous)
+	banking.Uses(mainframe, "Gets account information from")
+
+	prod := m.AddProdNode("AWS", "Amazon Web Services", "Cloud")
+	prod.Properties().Add("region", "eu-west-1")
+	prod.AddContainerInstance(web).AddHealthCheck("Web health", "https://example.com/health")
+
+	views := w.Views()
+	views.CreateSystemContextView(banking).WithKey("Context").WithAutoLayout().AddAllElements()
+	views.CreateContainerView(banking).AddAllElements()
+	views.CreateDeploymentView(banking, gostructurizr.ProductionEnvironment).WithKey("Prod").AddDeploymentNode(prod)
+	styles := views.Configuration().Styles()
+	styles.AddElementStyle(tags.Person).WithShape(shapes.Person).WithBackground("#08427b").WithBorderStyle(gostructurizr.Dashed)
+	styles.AddAdvancedRelationshipStyle(tags.Asynchronous).WithDashed().WithOrthogonalRouting()
+	return w
+}
+
+func TestJSONRenderer_Render(t *testing.T) {
+	buf := bytes.Buffer{}
+	require.NoError(t, NewJSONRenderer(&buf).Render(jsonTestWorkspace()))
+
+	var doc schema.Workspace
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+
+	assert.Equal(t, "Banking", doc.Name)
+	assert.Equal(t, "Banking workspace", doc.Description)
+
+	require.Len(t, doc.Model.People, 1)
+	customer := doc.Model.People[0]
+	assert.Equal(t, "1", customer.ID)
+	assert.Equal(t, "Element,Person", customer.Tags)
+	require.Len(t, customer.Relationships, 2)
+	assert.Equal(t, "2", customer.Relationships[0].DestinationID)
+
+	require.Len(t, doc.Model.SoftwareSystems, 2)
+	banking := doc.Model.SoftwareSystems[0]
+	require.Len(t, banking.Containers, 2)
+	web := banking.Containers[0]
+	assert.Equal(t, "3", web.ID)
+	assert.Equal(t, "Go", web.Technology)
+	require.Len(t, web.Relationships, 1)
+	assert.Equal(t, "HTTPS", web.Relationships[0].Technology)
+	assert.Equal(t, "Synchronous", web.Relationships[0].InteractionStyle)
+	assert.Equal(t, "Go package", banking.Containers[1].Components[0].Technology)
+	assert.Equal(t, "Element,Software system,Existing", doc.Model.SoftwareSystems[1].Tags)
+
+	require.Len(t, doc.Model.DeploymentNodes, 1)
+	aws := doc.Model.DeploymentNodes[0]
+	assert.Equal(t, "Production", aws.Environment)
+	assert.Equal(t, map[string]string{"region": "eu-west-1"}, aws.Properties)
+	require.Len(t, aws.ContainerInstances, 1)
+	assert.Equal(t, web.ID, aws.ContainerInstances[0].ContainerID)
+	assert.Equal(t, "https://example.com/health", aws.ContainerInstances[0].HealthChecks[0].URL)
+
+	require.Len(t, doc.Views.SystemContextViews, 1)
+	context := doc.Views.SystemContextViews[0]
+	assert.Equal(t, "Context", context.Key)
+	assert.Equal(t, banking.ID, context.SoftwareSystemID)
+	assert.NotNil(t, context.AutomaticLayout)
+	assert.Equal(t, []schema.ElementView{{ID: "2"}, {ID: "1"}, {ID: "6"}}, context.Elements)
+	assert.Len(t, context.Relationships, 2)
+
+	require.Len(t, doc.Views.ContainerViews, 1)
+	assert.Equal(t, "Container-001", doc.Views.ContainerViews[0].Key)
+	assert.Len(t, doc.Views.ContainerViews[0].Elements, 3)
+
+	require.Len(t, doc.Views.DeploymentViews, 1)
+	assert.Len(t, doc.Views.DeploymentViews[0].Elements, 2)
+
+	styles := doc.Views.Configuration.Styles
+	require.Len(t, styles.Elements, 1)
+	assert.Equal(t, "Person", styles.Elements[0].Shape)
+	assert.Equal(t, "Dashed", styles.Elements[0].Border)
+	require.Len(t, styles.Relationships, 1)
+	assert.True(t, *styles.Relationships[0].Dashed)
+	assert.Equal(t, "Orthogonal", styles.Relationships[0].Routing)
+}
+
+func TestJSONRenderer_Render_stableIdentifiers(t *testing.T) {
+	first, second := bytes.Buffer{}, bytes.Buffer{}
+	require.NoError(t, NewJSONRenderer(&first).Render(jsonTestWorkspace()))
+	require.NoError(t, NewJSONRenderer(&second).Render(jsonTestWorkspace()))
+	assert.Equal(t, first.String(), second.String())
+}
+
+func TestJSONRenderer_Render_dynamicSteps(t *testing.T) {
+	w := gostructurizr.Workspace()
+	m := w.Model()
+	customer := m.AddPerson("Customer", "")
+	shop := m.AddSoftwareSystem("Shop", "")
+	payment := m.AddSoftwareSystem("Payment", "")
+	customer.Uses(shop, "Browses")
+	customer.Uses(shop, "Buys from")
+	w.Views().CreateDynamicView(nil).WithKey("Checkout").
+		Add(customer, shop, "Buys from").
+		Add(shop, payment, "Charges")
+
+	buf := bytes.Buffer{}
+	require.NoError(t, NewJSONRenderer(&buf).Render(w))
+	var doc schema.Workspace
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+
+	require.Len(t, doc.Model.People[0].Relationships, 2)
+	require.Len(t, doc.Model.SoftwareSystems[0].Relationships, 1)
+	charges := doc.Model.SoftwareSystems[0].Relationships[0]
+	assert.Equal(t, "Charges", charges.Description)
+	require.Len(t, doc.Views.DynamicViews, 1)
+	steps := doc.Views.DynamicViews[0].Relationships
+	require.Len(t, steps, 2)
+	assert.Equal(t, doc.Model.People[0].Relationships[1].ID, steps[0].ID)
+	assert.Equal(t, "1", steps[0].Order)
+	assert.Equal(t, charges.ID, steps[1].ID)
+	assert.Equal(t, "2", steps[1].Order)
+}
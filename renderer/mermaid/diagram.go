@@ -0,0 +1,247 @@
+package mermaid
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/platelk/gostructurizr"
+	"github.com/platelk/gostructurizr/renderer"
+	"github.com/platelk/gostructurizr/tags"
+)
+
+// diagram accumulates the statements of a Mermaid C4 diagram
+type diagram struct {
+	kind   string
+	styles *gostructurizr.StylesNode
+	// orders numbers the steps of dynamic views
+	orders  map[*gostructurizr.RelationShipNode]int
+	body    strings.Builder
+	updates []string
+	aliases *renderer.Aliases
+}
+
+func newDiagram(kind, title string, styles *gostructurizr.StylesNode) *diagram {
+	d := &diagram{
+		kind:    kind,
+		styles:  styles,
+		aliases: renderer.NewAliases(),
+	}
+	d.writeLine(1, "title %s", title)
+	return d
+}
+
+func (d *diagram) String() string {
+	b := strings.Builder{}
+	b.WriteString(d.kind + "\n")
+	b.WriteString(d.body.String())
+	for _, update := range d.updates {
+		b.WriteString("    " + update + "\n")
+	}
+	return b.String()
+}
+
+func (d *diagram) writeLine(level int, format string, args ...interface{}) {
+	d.body.WriteString(strings.Repeat("    ", level))
+	fmt.Fprintf(&d.body, format, args...)
+	d.body.WriteString("\n")
+}
+
+func (d *diagram) element(n gostructurizr.Namer, level int) {
+	var macro string
+	var args []string
+	switch e := n.(type) {
+	case *gostructurizr.PersonNode:
+		macro, args = "Person", []string{deref(e.Description())}
+	case *gostructurizr.SoftwareSystemNode:
+		macro, args = "System", []string{deref(e.Description())}
+	case *gostructurizr.ContainerNode:
+		macro, args = "Container", []string{deref(e.Technology()), deref(e.Description())}
+	case *gostructurizr.ComponentNode:
+		macro, args = "Component", []string{deref(e.Technology()), deref(e.Description())}
+	case *gostructurizr.ContainerInstanceNode:
+		macro, args = "Container", []string{deref(e.Container().Technology()), deref(e.Container().Description())}
+	case *gostructurizr.InfrastructureNodeNode:
+		macro, args = "Container", []string{e.Technology(), e.Description()}
+	default:
+		return
+	}
+	elementTags := renderer.ElementTags(n)
+	if macro != "Person" {
+		macro += shapeSuffix(elementTags)
+	}
+	if hasTag(elementTags, tags.External) {
+		macro += "_Ext"
+	}
+	alias := d.aliases.Alias(n, n.Name())
+	line := []string{alias, quote(n.Name())}
+	for _, arg := range args {
+		line = append(line, quote(arg))
+	}
+	d.writeLine(level, "%s(%s)", macro, strings.Join(line, ", "))
+	d.updateElementStyle(alias, elementTags)
+}
+
+// boundedElements writes the elements of a view, containers and components being drawn in the
+// boundary of their parent and grouped elements in the boundary of their group, enterprise adding a boundary
+// for the people and software systems inside the enterprise
+func (d *diagram) boundedElements(m *gostructurizr.ModelNode, content *gostructurizr.ViewContent, enterprise bool) {
+	elements, boundaries := renderer.Boundaries(m, content, enterprise)
+	for _, e := range elements {
+		d.element(e, 1)
+	}
+	for _, b := range boundaries {
+		d.boundary(b, 1)
+	}
+	d.relationships(content.RelationShips())
+}
+
+func (d *diagram) boundary(b *renderer.Boundary, level int) {
+	macro := "Boundary"
+	switch b.Parent.(type) {
+	case *gostructurizr.SoftwareSystemNode:
+		macro = "System_Boundary"
+	case *gostructurizr.ContainerNode:
+		macro = "Container_Boundary"
+	case *gostructurizr.EnterpriseNode:
+		macro = "Enterprise_Boundary"
+	}
+	alias := d.aliases.Alias(b, b.Name+" Boundary")
+	d.writeLine(level, "%s(%s, %s) {", macro, alias, quote(b.Name))
+	d.updateElementStyle(alias, b.Tags)
+	for _, e := range b.Elements {
+		d.element(e, level+1)
+	}
+	for _, nested := range b.Boundaries {
+		d.boundary(nested, level+1)
+	}
+	d.writeLine(level, "}")
+}
+
+func (d *diagram) deploymentNode(n *gostructurizr.DeploymentNodeNode, content *gostructurizr.ViewContent, level int) {
+	alias := d.aliases.Alias(n, n.Name())
+	d.writeLine(level, "Deployment_Node(%s, %s, %s, %s) {", alias, quote(n.Name()), quote(n.Technology()), quote(n.Description()))
+	d.updateElementStyle(alias, renderer.ElementTags(n))
+	for _, child := range n.Children() {
+		if content.Contains(child) {
+			d.deploymentNode(child, content, level+1)
+		}
+	}
+	for _, infra := range n.InfrastructureNodes() {
+		if content.Contains(infra) {
+			d.element(infra, level+1)
+		}
+	}
+	for _, instance := range n.ContainerInstances() {
+		if content.Contains(instance) {
+			d.element(instance, level+1)
+		}
+	}
+	d.writeLine(level, "}")
+}
+
+func (d *diagram) relationships(relationships []*gostructurizr.RelationShipNode) {
+	for _, r := range relationships {
+		from, ok := d.aliases.Lookup(r.From())
+		if !ok {
+			continue
+		}
+		to, ok := d.aliases.Lookup(r.To())
+		if !ok {
+			continue
+		}
+		line := []string{from, to, quote(deref(r.Description()))}
+		if r.Technology() != nil {
+			line = append(line, quote(*r.Technology()))
+		}
+		if order, ok := d.orders[r]; ok {
+			d.writeLine(1, "RelIndex(%d, %s)", order, strings.Join(line, ", "))
+		} else {
+			d.writeLine(1, "Rel(%s)", strings.Join(line, ", "))
+		}
+		d.updateRelStyle(from, to, renderer.RelationShipTags(r))
+	}
+}
+
+// updateElementStyle applies the styles matching the tags of an element, later styles overriding earlier ones
+func (d *diagram) updateElementStyle(alias string, elementTags []string) {
+	var background, color, stroke *string
+	for _, style := range d.styles.ElementsStyle() {
+		if !hasTag(elementTags, style.Tag()) {
+			continue
+		}
+		background = override(background, style.Background())
+		color = override(color, style.Color())
+		stroke = override(stroke, style.Stroke())
+	}
+	args := appendArg(nil, "$fontColor", color)
+	args = appendArg(args, "$bgColor", background)
+	args = appendArg(args, "$borderColor", stroke)
+	if len(args) > 0 {
+		d.updates = append(d.updates, fmt.Sprintf("UpdateElementStyle(%s, %s)", alias, strings.Join(args, ", ")))
+	}
+}
+
+// updateRelStyle applies the styles matching the tags of a relationship, later styles overriding earlier ones
+func (d *diagram) updateRelStyle(from, to string, relationShipTags []string) {
+	var text, line *string
+	for _, style := range d.styles.RelationshipStyles() {
+		if !hasTag(relationShipTags, style.Tag()) {
+			continue
+		}
+		text = override(text, style.FontColor())
+		line = override(line, style.Color())
+	}
+	args := appendArg(nil, "$textColor", text)
+	args = appendArg(args, "$lineColor", line)
+	if len(args) > 0 {
+		d.updates = append(d.updates, fmt.Sprintf("UpdateRelStyle(%s, %s, %s)", from, to, strings.Join(args, ", ")))
+	}
+}
+
+func override(current, value *string) *string {
+	if value == nil || *value == "" {
+		return current
+	}
+	return value
+}
+
+func appendArg(args []string, name string, value *string) []string {
+	if value == nil {
+		return args
+	}
+	return append(args, name+"="+quote(*value))
+}
+
+// shapeSuffix selects the database and queue variants of the C4 macros
+func shapeSuffix(elementTags []string) string {
+	switch {
+	case hasTag(elementTags, tags.Database):
+		return "Db"
+	case hasTag(elementTags, tags.Queue):
+		return "Queue"
+	}
+	return ""
+}
+
+func hasTag(elementTags []string, tag tags.Tag) bool {
+	for _, t := range elementTags {
+		if t == tag.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// quote writes a macro argument, Mermaid strings can't contain double quotes nor line breaks
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `"`, "'")
+	s = strings.ReplaceAll(s, "\n", " ")
+	return `"` + s + `"`
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
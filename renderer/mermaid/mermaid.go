@@ -0,0 +1,102 @@
+// Package mermaid renders the views of a workspace as Mermaid C4 diagrams
+// (https://mermaid.js.org/syntax/c4.html), which GitHub and GitLab display in markdown.
+package mermaid
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/platelk/gostructurizr"
+	"github.com/platelk/gostructurizr/renderer"
+)
+
+// Diagram is the Mermaid source of a single view
+type Diagram struct {
+	Key     string
+	Content string
+}
+
+// Renderer writes every view as a mermaid code block, ready to be embedded in a markdown document
+type Renderer struct {
+	writer io.Writer
+}
+
+func NewRenderer(writer io.Writer) *Renderer {
+	return &Renderer{
+		writer: writer,
+	}
+}
+
+func (r *Renderer) Render(w *gostructurizr.WorkspaceNode) error {
+	var blocks []string
+	for _, d := range Diagrams(w) {
+		blocks = append(blocks, "```mermaid\n"+d.Content+"```\n")
+	}
+	if _, err := io.WriteString(r.writer, strings.Join(blocks, "\n")); err != nil {
+		return fmt.Errorf("can't write mermaid: %w", err)
+	}
+	return nil
+}
+
+// Diagrams returns the Mermaid diagram of every view of the workspace, filtered views excepted
+func Diagrams(w *gostructurizr.WorkspaceNode) []Diagram {
+	views := w.Views()
+	keys := renderer.ViewKeys(views)
+	styles := views.Configuration().Styles()
+	var diagrams []Diagram
+	add := func(view interface{}, d *diagram) {
+		diagrams = append(diagrams, Diagram{Key: keys[view], Content: d.String()})
+	}
+	for _, v := range views.SystemLandscapeViews() {
+		scope := ""
+		if w.Model().Enterprise() != nil {
+			scope = w.Model().Enterprise().Name()
+		}
+		d := newDiagram("C4Context", renderer.ViewTitle("System Landscape", v.Description(), scope), styles)
+		d.boundedElements(w.Model(), v.Content(), v.IsEnterpriseBoundaryVisible())
+		add(v, d)
+	}
+	for _, v := range views.SystemContextViews() {
+		d := newDiagram("C4Context", renderer.ViewTitle("System Context", v.Description(), v.SoftwareSystem().Name()), styles)
+		d.boundedElements(w.Model(), v.Content(), v.IsEnterpriseBoundaryVisible())
+		add(v, d)
+	}
+	for _, v := range views.ContainerViews() {
+		d := newDiagram("C4Container", renderer.ViewTitle("Container", v.Description(), v.SoftwareSystem().Name()), styles)
+		d.boundedElements(w.Model(), v.Content(), false)
+		add(v, d)
+	}
+	for _, v := range views.ComponentViews() {
+		d := newDiagram("C4Component", renderer.ViewTitle("Component", v.Description(), v.Container().Parent().Name()+" - "+v.Container().Name()), styles)
+		d.boundedElements(w.Model(), v.Content(), false)
+		add(v, d)
+	}
+	for _, v := range views.DynamicViews() {
+		scope := ""
+		if v.Identifier() != nil {
+			scope = v.Identifier().Name()
+		}
+		d := newDiagram("C4Dynamic", renderer.ViewTitle("Dynamic", v.Description(), scope), styles)
+		d.orders = renderer.StepOrders(v)
+		d.boundedElements(w.Model(), v.Content(), false)
+		add(v, d)
+	}
+	for _, v := range views.DeploymentViews() {
+		scope := string(v.Environment())
+		if v.SoftwareSystem() != nil {
+			scope = v.SoftwareSystem().Name() + " - " + scope
+		}
+		desc := v.GetDescription()
+		d := newDiagram("C4Deployment", renderer.ViewTitle("Deployment", &desc, scope), styles)
+		content := v.Content()
+		for _, e := range v.Elements() {
+			if node, ok := e.(*gostructurizr.DeploymentNodeNode); ok {
+				d.deploymentNode(node, content, 1)
+			}
+		}
+		d.relationships(content.RelationShips())
+		add(v, d)
+	}
+	return diagrams
+}
@@ -0,0 +1,86 @@
+package mermaid
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platelk/gostructurizr"
+	"github.com/platelk/gostructurizr/tags"
+)
+
+func testWorkspace() *gostructurizr.WorkspaceNode {
+	w := gostructurizr.Workspace()
+	m := w.Model()
+	customer := m.AddPerson("Customer", "Buys things")
+	shop := m.AddSoftwareSystem("Online Shop", "Sells things")
+	payment := m.AddSoftwareSystem("Payment", "Takes the money").WithTag(tags.External.String())
+	web := shop.AddContainer("Web", "Storefront", "Go")
+	db := shop.AddContainer("Database", "Stores orders", "PostgreSQL").WithTag(tags.Database.String())
+	web.AddComponent("Cart").WithTechnology("Go package")
+	customer.Uses(shop, "Shops at")
+	customer.Uses(web, "Browses").WithTechnology("HTTPS")
+	web.Uses(db, "Reads from")
+	web.Uses(payment, "Charges").WithInteractionStyle(gostructurizr.Asynchronous)
+
+	prod := m.AddProdNode("AWS", "Amazon", "Cloud")
+	prod.AddChildNode("Cluster", "Kubernetes", "EKS").AddContainerInstance(web)
+
+	views := w.Views()
+	views.CreateSystemContextView(shop).WithKey("Context").AddAllElements()
+	views.CreateContainerView(shop).AddAllElements()
+	views.CreateComponentView(web).AddAllElements()
+	views.CreateDynamicView(shop).WithKey("Checkout").Add(customer, web, "Checks out").Add(web, payment, "Charges")
+	views.CreateDeploymentView(shop, gostructurizr.ProductionEnvironment).WithKey("Prod").AddDeploymentNode(prod)
+	styles := views.Configuration().Styles()
+	styles.AddElementStyle(tags.Element).WithColor("#ffffff")
+	styles.AddElementStyle(tags.Person).WithBackground("#08427b")
+	styles.AddElementStyle(tags.External).WithBackground("#999999")
+	styles.AddAdvancedRelationshipStyle(tags.Asynchronous).WithColor("#aa0000")
+	return w
+}
+
+func TestDiagrams(t *testing.T) {
+	diagrams := Diagrams(testWorkspace())
+	require.Len(t, diagrams, 5)
+	assert.Equal(t, "Container-001", diagrams[1].Key)
+
+	assert.Equal(t, `C4Container
+    title [Container] Online Shop
+    Person(customer, "Customer", "Buys things")
+    System_Ext(payment, "Payment", "Takes the money")
+    System_Boundary(onlineShopBoundary, "Online Shop") {
+        Container(web, "Web", "Go", "Storefront")
+        ContainerDb(database, "Database", "PostgreSQL", "Stores orders")
+    }
+    Rel(customer, web, "Browses", "HTTPS")
+    Rel(web, database, "Reads from")
+    Rel(web, payment, "Charges")
+    UpdateElementStyle(customer, $fontColor="#ffffff", $bgColor="#08427b")
+    UpdateElementStyle(payment, $fontColor="#ffffff", $bgColor="#999999")
+    UpdateElementStyle(web, $fontColor="#ffffff")
+    UpdateElementStyle(database, $fontColor="#ffffff")
+    UpdateRelStyle(web, payment, $lineColor="#aa0000")
+`, diagrams[1].Content)
+
+	assert.True(t, strings.HasPrefix(diagrams[0].Content, "C4Context\n"))
+	assert.Contains(t, diagrams[0].Content, `System(onlineShop, "Online Shop", "Sells things")`)
+	assert.Contains(t, diagrams[0].Content, `Rel(customer, onlineShop, "Shops at")`)
+	assert.Contains(t, diagrams[2].Content, `Container_Boundary(webBoundary, "Web") {`)
+	assert.Contains(t, diagrams[3].Content, `RelIndex(2, web, payment, "Charges")`)
+	assert.Contains(t, diagrams[4].Content, `    Deployment_Node(aws, "AWS", "Cloud", "Amazon") {
+        Deployment_Node(cluster, "Cluster", "EKS", "Kubernetes") {
+            Container(web, "Web", "Go", "Storefront")
+        }
+    }`)
+}
+
+func TestRenderer_Render(t *testing.T) {
+	buf := bytes.Buffer{}
+	require.NoError(t, NewRenderer(&buf).Render(testWorkspace()))
+	assert.Equal(t, 5, strings.Count(buf.String(), "```mermaid\n"))
+	assert.True(t, strings.HasSuffix(buf.String(), "```\n"))
+}
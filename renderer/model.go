@@ -8,27 +8,44 @@ import (
 	"strings"
 )
 
-func renderModel(m *gostructurizr.ModelNode, writer io.Writer, level int) error {
+func renderModel(m *gostructurizr.ModelNode, ctx *dslContext, writer io.Writer, level int) error {
 	rendered := strings.Builder{}
 	var line []string
 
 	line = append(line, dsl.Model, dsl.Space, dsl.OpenBracket)
 	writeLine(&rendered, level, line...)
 
-	for _, p := range m.Persons() {
-		if err := renderPerson(p, &rendered, level+1); err != nil {
-			return fmt.Errorf("can't render person: %w", err)
+	strategy, derived := impliedRelationshipsStrategy(m, ctx)
+	writeLine(&rendered, level+1, dsl.ImpliedRelationships, dsl.Space, strategy)
+	renderProperties(&rendered, modelProperties(m), level+1)
+	// an enterprise without people nor software systems has nothing to draw
+	if m.Enterprise() != nil && len(m.Enterprise().Elements()) > 0 {
+		writeLine(&rendered, level+1, dsl.Enterprise, dsl.Space, generateStringIdentifier(m.Enterprise().Name()), dsl.Space, dsl.OpenBracket)
+		if err := renderPeopleAndSoftwareSystems(m, ctx, &rendered, level+2, true); err != nil {
+			return err
 		}
+		writeLine(&rendered, level+1, dsl.CloseBracket)
 	}
-	//rendered.WriteString(dsl.NewLine)
-	for _, s := range m.SoftwareSystems() {
-		if err := renderSoftwareSystem(s, &rendered, level+1); err != nil {
-			return fmt.Errorf("can't render softwareSystem: %w", err)
+	if err := renderPeopleAndSoftwareSystems(m, ctx, &rendered, level+1, false); err != nil {
+		return err
+	}
+	for _, env := range deploymentEnvironments(m) {
+		writeLine(&rendered, level+1, dsl.DeploymentEnvironment, dsl.Space, generateStringIdentifier(string(env)), dsl.Space, dsl.OpenBracket)
+		for _, node := range ordered(ctx, m.FindDeploymentNodesForEnvironment(env)) {
+			r := NewDeploymentNodeRenderer(&rendered, level+2)
+			r.ctx = ctx
+			if err := r.Render(node); err != nil {
+				return fmt.Errorf("can't render deploymentNode: %w", err)
+			}
 		}
+		writeLine(&rendered, level+1, dsl.CloseBracket)
 	}
 	rendered.WriteString(dsl.NewLine)
-	for _, u := range m.RelationShip() {
-		if err := renderRelationShip(u, &rendered, level+1); err != nil {
+	for _, u := range orderedRelationShips(ctx, m.RelationShip()) {
+		if u.IsImplied() && derived {
+			continue
+		}
+		if err := renderRelationShip(u, ctx, &rendered, level+1); err != nil {
 			return fmt.Errorf("can't render relationship: %w", err)
 		}
 	}
@@ -42,3 +59,81 @@ func renderModel(m *gostructurizr.ModelNode, writer io.Writer, level int) error
 
 	return nil
 }
+
+// impliedRelationshipsStrategy returns the `!impliedRelationships` value matching the strategy of the model, and whether
+// the DSL derives the implied relationships from it. Structurizr creates implied relationships by default, so the
+// default strategy, strategies Structurizr doesn't know, and the explicit rendering turn it off and write the implied
+// relationships instead.
+func impliedRelationshipsStrategy(m *gostructurizr.ModelNode, ctx *dslContext) (string, bool) {
+	explicit := ctx != nil && ctx.explicitImplied
+	switch m.ImpliedRelationships() {
+	case gostructurizr.CreateImpliedRelationshipsUnlessAnyExist:
+		if !explicit {
+			return "true", true
+		}
+	case gostructurizr.CreateImpliedRelationshipsUnlessSameDescription:
+		if !explicit {
+			return generateStringIdentifier(dsl.ImpliedRelationshipsUnlessSameExist), true
+		}
+	}
+	return "false", false
+}
+
+// renderPeopleAndSoftwareSystems renders the people and software systems located inside the enterprise boundary,
+// or the other ones when internal is false. Without an enterprise boundary, everything is rendered as external.
+// Grouped elements are rendered in their group, after the other ones.
+func renderPeopleAndSoftwareSystems(m *gostructurizr.ModelNode, ctx *dslContext, rendered *strings.Builder, level int, internal bool) error {
+	inEnterprise := func(l gostructurizr.Location) bool {
+		return m.Enterprise() != nil && l == gostructurizr.InternalLocation
+	}
+	include := func(e gostructurizr.LandscapeElement) bool {
+		switch e := e.(type) {
+		case *gostructurizr.PersonNode:
+			return inEnterprise(e.Location()) == internal
+		case *gostructurizr.SoftwareSystemNode:
+			return inEnterprise(e.Location()) == internal
+		}
+		return false
+	}
+	render := func(e gostructurizr.LandscapeElement, level int) error {
+		switch e := e.(type) {
+		case *gostructurizr.PersonNode:
+			if err := renderPerson(e, ctx, rendered, level); err != nil {
+				return fmt.Errorf("can't render person: %w", err)
+			}
+		case *gostructurizr.SoftwareSystemNode:
+			if err := renderSoftwareSystem(e, ctx, rendered, level); err != nil {
+				return fmt.Errorf("can't render softwareSystem: %w", err)
+			}
+		}
+		return nil
+	}
+	for _, p := range ordered(ctx, m.Persons()) {
+		if include(p) && m.GroupOf(p) == nil {
+			if err := render(p, level); err != nil {
+				return err
+			}
+		}
+	}
+	for _, s := range ordered(ctx, m.SoftwareSystems()) {
+		if include(s) && m.GroupOf(s) == nil {
+			if err := render(s, level); err != nil {
+				return err
+			}
+		}
+	}
+	return renderGroups(m.Groups(), ctx, rendered, level, include, render)
+}
+
+// deploymentEnvironments returns the environments of the deployment nodes, in the order they first appear
+func deploymentEnvironments(m *gostructurizr.ModelNode) []gostructurizr.DeploymentEnvironment {
+	var environments []gostructurizr.DeploymentEnvironment
+	seen := map[gostructurizr.DeploymentEnvironment]bool{}
+	for _, node := range m.DeploymentNodes() {
+		if !seen[node.Environment()] {
+			seen[node.Environment()] = true
+			environments = append(environments, node.Environment())
+		}
+	}
+	return environments
+}
@@ -6,9 +6,9 @@ import (
 	"strings"
 )
 
-func renderPerson(p *gostructurizr.PersonNode, renderer *strings.Builder, level int) error {
+func renderPerson(p *gostructurizr.PersonNode, ctx *dslContext, renderer *strings.Builder, level int) error {
 	var line []string
-	line = append(line, generateVarName(p.Name()), dsl.Space, dsl.Equal, dsl.Space, dsl.Person, dsl.Space, generateStringIdentifier(p.Name()))
+	line = append(line, ctx.local(p), dsl.Space, dsl.Equal, dsl.Space, dsl.Person, dsl.Space, generateStringIdentifier(p.Name()))
 	if p.Description() != nil {
 		line = append(line, dsl.Space, generateStringIdentifier(*p.Description()))
 	}
@@ -0,0 +1,286 @@
+package plantuml
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/platelk/gostructurizr"
+	"github.com/platelk/gostructurizr/renderer"
+	"github.com/platelk/gostructurizr/shapes"
+	"github.com/platelk/gostructurizr/tags"
+)
+
+// diagram accumulates the body of a PlantUML document
+type diagram struct {
+	library string
+	title   string
+	styles  *gostructurizr.StylesNode
+	body    strings.Builder
+	aliases *renderer.Aliases
+	// orders numbers the steps of dynamic views
+	orders map[*gostructurizr.RelationShipNode]int
+}
+
+func newDiagram(library, title string, styles *gostructurizr.StylesNode) *diagram {
+	return &diagram{
+		library: library,
+		title:   title,
+		styles:  styles,
+		aliases: renderer.NewAliases(),
+	}
+}
+
+func (d *diagram) document(key string) string {
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "@startuml %s\n", key)
+	fmt.Fprintf(&b, "!include %s%s.puml\n\n", stdlib, d.library)
+	fmt.Fprintf(&b, "title %s\n\n", d.title)
+	if styles := d.styleDefinitions(); styles != "" {
+		b.WriteString(styles)
+		b.WriteString("\n")
+	}
+	b.WriteString(d.body.String())
+	b.WriteString("@enduml\n")
+	return b.String()
+}
+
+func (d *diagram) writeLine(level int, format string, args ...interface{}) {
+	d.body.WriteString(strings.Repeat("    ", level))
+	fmt.Fprintf(&d.body, format, args...)
+	d.body.WriteString("\n")
+}
+
+func (d *diagram) element(n gostructurizr.Namer) {
+	d.elementAt(n, 0)
+}
+
+func (d *diagram) elementAt(n gostructurizr.Namer, level int) {
+	var macro string
+	var args []string
+	switch e := n.(type) {
+	case *gostructurizr.PersonNode:
+		macro, args = "Person", []string{deref(e.Description())}
+	case *gostructurizr.SoftwareSystemNode:
+		macro, args = "System", []string{deref(e.Description())}
+	case *gostructurizr.ContainerNode:
+		macro, args = "Container", []string{deref(e.Technology()), deref(e.Description())}
+	case *gostructurizr.ComponentNode:
+		macro, args = "Component", []string{deref(e.Technology()), deref(e.Description())}
+	case *gostructurizr.ContainerInstanceNode:
+		macro, args = "Container", []string{deref(e.Container().Technology()), deref(e.Container().Description())}
+	case *gostructurizr.InfrastructureNodeNode:
+		macro, args = "Container", []string{e.Technology(), e.Description()}
+	default:
+		return
+	}
+	elementTags := renderer.ElementTags(n)
+	if macro != "Person" {
+		macro += shapeSuffix(elementTags)
+	}
+	if hasTag(elementTags, tags.External) {
+		macro += "_Ext"
+	}
+	line := []string{d.aliases.Alias(n, n.Name()), quote(n.Name())}
+	for _, arg := range args {
+		line = append(line, quote(arg))
+	}
+	if styled := d.styledElementTags(elementTags); styled != "" {
+		line = append(line, "$tags="+quote(styled))
+	}
+	d.writeLine(level, "%s(%s)", macro, strings.Join(line, ", "))
+}
+
+// boundedElements writes the elements of a view, containers and components being drawn in the
+// boundary of their parent and grouped elements in the boundary of their group, enterprise adding a boundary
+// for the people and software systems inside the enterprise
+func (d *diagram) boundedElements(m *gostructurizr.ModelNode, content *gostructurizr.ViewContent, enterprise bool) {
+	elements, boundaries := renderer.Boundaries(m, content, enterprise)
+	for _, e := range elements {
+		d.element(e)
+	}
+	for _, b := range boundaries {
+		d.boundary(b, 0)
+	}
+	d.relationships(content.RelationShips())
+}
+
+func (d *diagram) boundary(b *renderer.Boundary, level int) {
+	macro := "Boundary"
+	switch b.Parent.(type) {
+	case *gostructurizr.SoftwareSystemNode:
+		macro = "System_Boundary"
+	case *gostructurizr.ContainerNode:
+		macro = "Container_Boundary"
+	case *gostructurizr.EnterpriseNode:
+		macro = "Enterprise_Boundary"
+	}
+	line := []string{d.aliases.Alias(b, b.Name+" Boundary"), quote(b.Name)}
+	if styled := d.styledElementTags(b.Tags); styled != "" {
+		line = append(line, "$tags="+quote(styled))
+	}
+	d.writeLine(level, "%s(%s) {", macro, strings.Join(line, ", "))
+	for _, e := range b.Elements {
+		d.elementAt(e, level+1)
+	}
+	for _, nested := range b.Boundaries {
+		d.boundary(nested, level+1)
+	}
+	d.writeLine(level, "}")
+}
+
+func (d *diagram) deploymentNode(n *gostructurizr.DeploymentNodeNode, content *gostructurizr.ViewContent, level int) {
+	line := []string{d.aliases.Alias(n, n.Name()), quote(n.Name()), quote(n.Technology()), quote(n.Description())}
+	if styled := d.styledElementTags(renderer.ElementTags(n)); styled != "" {
+		line = append(line, "$tags="+quote(styled))
+	}
+	d.writeLine(level, "Deployment_Node(%s) {", strings.Join(line, ", "))
+	for _, child := range n.Children() {
+		if content.Contains(child) {
+			d.deploymentNode(child, content, level+1)
+		}
+	}
+	for _, infra := range n.InfrastructureNodes() {
+		if content.Contains(infra) {
+			d.elementAt(infra, level+1)
+		}
+	}
+	for _, instance := range n.ContainerInstances() {
+		if content.Contains(instance) {
+			d.elementAt(instance, level+1)
+		}
+	}
+	d.writeLine(level, "}")
+}
+
+func (d *diagram) relationships(relationships []*gostructurizr.RelationShipNode) {
+	for _, r := range relationships {
+		from, ok := d.aliases.Lookup(r.From())
+		if !ok {
+			continue
+		}
+		to, ok := d.aliases.Lookup(r.To())
+		if !ok {
+			continue
+		}
+		line := []string{from, to, quote(deref(r.Description()))}
+		if r.Technology() != nil {
+			line = append(line, quote(*r.Technology()))
+		}
+		if styled := d.styledRelationShipTags(renderer.RelationShipTags(r)); styled != "" {
+			line = append(line, "$tags="+quote(styled))
+		}
+		if order, ok := d.orders[r]; ok {
+			d.writeLine(0, "RelIndex(%d, %s)", order, strings.Join(line, ", "))
+		} else {
+			d.writeLine(0, "Rel(%s)", strings.Join(line, ", "))
+		}
+	}
+}
+
+// styledElementTags returns the tags of an element having a style, joined the C4-PlantUML way
+func (d *diagram) styledElementTags(elementTags []string) string {
+	var styled []string
+	for _, tag := range elementTags {
+		for _, style := range d.styles.ElementsStyle() {
+			if style.Tag().String() == tag {
+				styled = append(styled, tag)
+				break
+			}
+		}
+	}
+	return strings.Join(styled, "+")
+}
+
+func (d *diagram) styledRelationShipTags(relationShipTags []string) string {
+	var styled []string
+	for _, tag := range relationShipTags {
+		for _, style := range d.styles.RelationshipStyles() {
+			if style.Tag().String() == tag {
+				styled = append(styled, tag)
+				break
+			}
+		}
+	}
+	return strings.Join(styled, "+")
+}
+
+// styleDefinitions turns the styles of the workspace into element and relationship tags
+func (d *diagram) styleDefinitions() string {
+	b := strings.Builder{}
+	for _, s := range d.styles.ElementsStyle() {
+		args := []string{quote(s.Tag().String())}
+		args = appendArg(args, "$bgColor", s.Background())
+		args = appendArg(args, "$fontColor", s.Color())
+		args = appendArg(args, "$borderColor", s.Stroke())
+		if s.Shape() != nil {
+			switch *s.Shape() {
+			case shapes.RoundedBox:
+				args = append(args, "$shape=RoundedBoxShape()")
+			case shapes.Hexagon:
+				args = append(args, "$shape=EightSidedShape()")
+			}
+		}
+		if s.BorderStyle() != nil {
+			args = append(args, "$borderStyle="+string(*s.BorderStyle())+"Line()")
+		}
+		if s.StrokeWidth() != nil {
+			args = append(args, "$borderThickness="+quote(strconv.Itoa(*s.StrokeWidth())))
+		}
+		fmt.Fprintf(&b, "AddElementTag(%s)\n", strings.Join(args, ", "))
+	}
+	for _, s := range d.styles.RelationshipStyles() {
+		args := []string{quote(s.Tag().String())}
+		args = appendArg(args, "$textColor", s.FontColor())
+		args = appendArg(args, "$lineColor", s.Color())
+		if s.LineStyle() != nil {
+			args = append(args, "$lineStyle="+string(*s.LineStyle())+"Line()")
+		}
+		if s.Thickness() != nil {
+			args = append(args, "$lineThickness="+quote(strconv.Itoa(*s.Thickness())))
+		}
+		fmt.Fprintf(&b, "AddRelTag(%s)\n", strings.Join(args, ", "))
+	}
+	return b.String()
+}
+
+func appendArg(args []string, name string, value *string) []string {
+	if value == nil || *value == "" {
+		return args
+	}
+	return append(args, name+"="+quote(*value))
+}
+
+// shapeSuffix selects the database and queue variants of the C4-PlantUML macros
+func shapeSuffix(elementTags []string) string {
+	switch {
+	case hasTag(elementTags, tags.Database):
+		return "Db"
+	case hasTag(elementTags, tags.Queue):
+		return "Queue"
+	}
+	return ""
+}
+
+func hasTag(elementTags []string, tag tags.Tag) bool {
+	for _, t := range elementTags {
+		if t == tag.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// quote writes a macro argument, PlantUML strings can't contain double quotes nor line breaks
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `"`, "'")
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,105 @@
+// Package plantuml renders the views of a workspace as PlantUML documents using the
+// C4-PlantUML macros (https://github.com/plantuml-stdlib/C4-PlantUML).
+package plantuml
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/platelk/gostructurizr"
+	"github.com/platelk/gostructurizr/renderer"
+)
+
+const stdlib = "https://raw.githubusercontent.com/plantuml-stdlib/C4-PlantUML/master/"
+
+// Diagram is the PlantUML document of a single view
+type Diagram struct {
+	Key     string
+	Content string
+}
+
+// Renderer writes one PlantUML document per view, each in its own @startuml block named after
+// the key of the view, so PlantUML generates one image per view from the rendered file.
+type Renderer struct {
+	writer io.Writer
+}
+
+func NewRenderer(writer io.Writer) *Renderer {
+	return &Renderer{
+		writer: writer,
+	}
+}
+
+func (r *Renderer) Render(w *gostructurizr.WorkspaceNode) error {
+	var documents []string
+	for _, d := range Diagrams(w) {
+		documents = append(documents, d.Content)
+	}
+	if _, err := io.WriteString(r.writer, strings.Join(documents, "\n")); err != nil {
+		return fmt.Errorf("can't write plantuml: %w", err)
+	}
+	return nil
+}
+
+// Diagrams returns the PlantUML document of every view of the workspace, filtered views excepted
+func Diagrams(w *gostructurizr.WorkspaceNode) []Diagram {
+	views := w.Views()
+	keys := renderer.ViewKeys(views)
+	styles := views.Configuration().Styles()
+	var diagrams []Diagram
+	add := func(view interface{}, d *diagram) {
+		diagrams = append(diagrams, Diagram{Key: keys[view], Content: d.document(keys[view])})
+	}
+	for _, v := range views.SystemLandscapeViews() {
+		scope := ""
+		if w.Model().Enterprise() != nil {
+			scope = w.Model().Enterprise().Name()
+		}
+		d := newDiagram("C4_Context", renderer.ViewTitle("System Landscape", v.Description(), scope), styles)
+		d.boundedElements(w.Model(), v.Content(), v.IsEnterpriseBoundaryVisible())
+		add(v, d)
+	}
+	for _, v := range views.SystemContextViews() {
+		d := newDiagram("C4_Context", renderer.ViewTitle("System Context", v.Description(), v.SoftwareSystem().Name()), styles)
+		d.boundedElements(w.Model(), v.Content(), v.IsEnterpriseBoundaryVisible())
+		add(v, d)
+	}
+	for _, v := range views.ContainerViews() {
+		d := newDiagram("C4_Container", renderer.ViewTitle("Container", v.Description(), v.SoftwareSystem().Name()), styles)
+		d.boundedElements(w.Model(), v.Content(), false)
+		add(v, d)
+	}
+	for _, v := range views.ComponentViews() {
+		d := newDiagram("C4_Component", renderer.ViewTitle("Component", v.Description(), v.Container().Parent().Name()+" - "+v.Container().Name()), styles)
+		d.boundedElements(w.Model(), v.Content(), false)
+		add(v, d)
+	}
+	for _, v := range views.DynamicViews() {
+		scope := ""
+		if v.Identifier() != nil {
+			scope = v.Identifier().Name()
+		}
+		d := newDiagram("C4_Dynamic", renderer.ViewTitle("Dynamic", v.Description(), scope), styles)
+		d.orders = renderer.StepOrders(v)
+		d.boundedElements(w.Model(), v.Content(), false)
+		add(v, d)
+	}
+	for _, v := range views.DeploymentViews() {
+		scope := string(v.Environment())
+		if v.SoftwareSystem() != nil {
+			scope = v.SoftwareSystem().Name() + " - " + scope
+		}
+		desc := v.GetDescription()
+		d := newDiagram("C4_Deployment", renderer.ViewTitle("Deployment", &desc, scope), styles)
+		content := v.Content()
+		for _, e := range v.Elements() {
+			if node, ok := e.(*gostructurizr.DeploymentNodeNode); ok {
+				d.deploymentNode(node, content, 0)
+			}
+		}
+		d.relationships(content.RelationShips())
+		add(v, d)
+	}
+	return diagrams
+}
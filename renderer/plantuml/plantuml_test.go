@@ -0,0 +1,121 @@
+package plantuml
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platelk/gostructurizr"
+	"github.com/platelk/gostructurizr/tags"
+)
+
+func testWorkspace() *gostructurizr.WorkspaceNode {
+	w := gostructurizr.Workspace()
+	m := w.Model()
+	customer := m.AddPerson("Customer", "Buys things")
+	shop := m.AddSoftwareSystem("Online Shop", "Sells things")
+	payment := m.AddSoftwareSystem("Payment", "Takes the \"money\"").WithTag(tags.External.String())
+	web := shop.AddContainer("Web", "Storefront", "Go")
+	db := shop.AddContainer("Database", "Stores orders", "PostgreSQL").WithTag(tags.Database.String())
+	web.AddComponent("Cart").WithTechnology("Go package")
+	customer.Uses(shop, "Shops at")
+	customer.Uses(web, "Browses").WithTechnology("HTTPS")
+	web.Uses(db, "Reads from")
+	web.Uses(payment, "Charges").WithInteractionStyle(gostructurizr.Asynchronous)
+
+	prod := m.AddProdNode("AWS", "Amazon", "Cloud")
+	cluster := prod.AddChildNode("Cluster", "Kubernetes", "EKS")
+	cluster.AddContainerInstance(web)
+	prod.AddInfrastructureNode("Load Balancer", "Routes traffic", "ELB")
+
+	views := w.Views()
+	views.CreateSystemContextView(shop).WithKey("Context").AddAllElements()
+	views.CreateContainerView(shop).AddAllElements()
+	views.CreateComponentView(web).AddAllElements()
+	views.CreateDynamicView(shop).WithKey("Checkout").Add(customer, web, "Checks out").Add(web, payment, "Charges")
+	views.CreateDeploymentView(shop, gostructurizr.ProductionEnvironment).WithKey("Prod").AddDeploymentNode(prod)
+	styles := views.Configuration().Styles()
+	styles.AddElementStyle(tags.Person).WithBackground("#08427b").WithColor("#ffffff")
+	styles.AddElementStyle(tags.External).WithBorderStyle(gostructurizr.Dashed)
+	styles.AddAdvancedRelationshipStyle(tags.Asynchronous).WithDashed().WithColor("#aa0000").WithThickness(2)
+	return w
+}
+
+func TestDiagrams(t *testing.T) {
+	diagrams := Diagrams(testWorkspace())
+	require.Len(t, diagrams, 5)
+	keys := []string{}
+	for _, d := range diagrams {
+		keys = append(keys, d.Key)
+	}
+	assert.Equal(t, []string{"Context", "Container-001", "Component-001", "Checkout", "Prod"}, keys)
+
+	assert.Equal(t, `@startuml Container-001
+!include https://raw.githubusercontent.com/plantuml-stdlib/C4-PlantUML/master/C4_Container.puml
+
+title [Container] Online Shop
+
+AddElementTag("Person", $bgColor="#08427b", $fontColor="#ffffff")
+AddElementTag("External", $borderStyle=DashedLine())
+AddRelTag("asynchronous", $lineColor="#aa0000", $lineStyle=DashedLine(), $lineThickness="2")
+
+Person(customer, "Customer", "Buys things", $tags="Person")
+System_Ext(payment, "Payment", "Takes the 'money'", $tags="External")
+System_Boundary(onlineShopBoundary, "Online Shop") {
+    Container(web, "Web", "Go", "Storefront")
+    ContainerDb(database, "Database", "PostgreSQL", "Stores orders")
+}
+Rel(customer, web, "Browses", "HTTPS")
+Rel(web, database, "Reads from")
+Rel(web, payment, "Charges", $tags="asynchronous")
+@enduml
+`, diagrams[1].Content)
+
+	assert.Contains(t, diagrams[0].Content, "C4_Context.puml")
+	assert.Contains(t, diagrams[0].Content, `System(onlineShop, "Online Shop", "Sells things")`)
+	assert.Contains(t, diagrams[2].Content, `Container_Boundary(webBoundary, "Web") {`)
+	assert.Contains(t, diagrams[2].Content, `title [Component] Online Shop - Web`)
+	assert.Contains(t, diagrams[3].Content, `RelIndex(1, customer, web, "Checks out")`)
+	assert.Contains(t, diagrams[3].Content, `RelIndex(2, web, payment, "Charges")`)
+
+	assert.Contains(t, diagrams[4].Content, `Deployment_Node(aws, "AWS", "Cloud", "Amazon") {
+    Deployment_Node(cluster, "Cluster", "EKS", "Kubernetes") {
+        Container(web, "Web", "Go", "Storefront")
+    }
+    Container(loadBalancer, "Load Balancer", "ELB", "Routes traffic")
+}`)
+}
+
+func TestRenderer_Render(t *testing.T) {
+	buf := bytes.Buffer{}
+	require.NoError(t, NewRenderer(&buf).Render(testWorkspace()))
+	assert.Equal(t, 5, strings.Count(buf.String(), "@startuml"))
+	assert.Equal(t, 5, strings.Count(buf.String(), "@enduml"))
+}
+
+func TestDiagrams_enterpriseBoundary(t *testing.T) {
+	w := gostructurizr.Workspace()
+	m := w.Model()
+	enterprise := m.SetEnterprise("ACME")
+	staff := m.AddPerson("Staff", "Runs the shop")
+	shop := m.AddSoftwareSystem("Shop", "Sells things")
+	payment := m.AddSoftwareSystem("Payment", "Charges cards")
+	enterprise.Add(staff, shop)
+	staff.Uses(shop, "Manages")
+	shop.Uses(payment, "Charges")
+	w.Views().CreateSystemContextView(shop).WithKey("Context").AddAllElements()
+	w.Views().CreateSystemContextView(shop).WithKey("Flat").AddAllElements().WithoutEnterpriseBoundary()
+
+	diagrams := Diagrams(w)
+	require.Len(t, diagrams, 2)
+	assert.Contains(t, diagrams[0].Content, `System_Ext(payment, "Payment", "Charges cards")
+Enterprise_Boundary(acmeBoundary, "ACME") {
+    System(shop, "Shop", "Sells things")
+    Person(staff, "Staff", "Runs the shop")
+}
+`)
+	assert.NotContains(t, diagrams[1].Content, "Enterprise_Boundary")
+}
@@ -1,19 +1,49 @@
 package renderer
 
 import (
+	"strings"
+
 	"github.com/platelk/gostructurizr"
 	"github.com/platelk/gostructurizr/dsl"
-	"strings"
 )
 
-func renderRelationShip(r *gostructurizr.RelationShipNode, renderer *strings.Builder, level int) error {
+func renderRelationShip(r *gostructurizr.RelationShipNode, ctx *dslContext, renderer *strings.Builder, level int) error {
 	var line []string
 
-	line = append(line, generateVarName(r.From().Name()), dsl.Space, dsl.Arrow, dsl.Space, generateVarName(r.To().Name()))
-	if r.Description() != nil {
-		line = append(line, dsl.Space, generateStringIdentifier(*r.Description()))
+	line = append(line, ctx.id(r.From()), dsl.Space, dsl.Arrow, dsl.Space, ctx.id(r.To()))
+	// description, technology and tags are positional, empty strings holding the place of the missing ones
+	args := []string{deref(r.Description()), deref(r.Technology()), strings.Join(relationShipDSLTags(r), dsl.TagSeparator)}
+	for len(args) > 0 && args[len(args)-1] == "" {
+		args = args[:len(args)-1]
+	}
+	if len(args) == 0 && r.Description() != nil {
+		args = []string{""}
+	}
+	for _, arg := range args {
+		line = append(line, dsl.Space, generateStringIdentifier(arg))
 	}
+	if r.URL() == nil && len(r.Properties().Properties) == 0 && len(r.Perspectives()) == 0 {
+		writeLine(renderer, level, line...)
+		return nil
+	}
+	line = append(line, dsl.Space, dsl.OpenBracket)
 	writeLine(renderer, level, line...)
+	if r.URL() != nil {
+		writeLine(renderer, level+1, dsl.Url, dsl.Space, generateStringIdentifier(*r.URL()))
+	}
+	renderProperties(renderer, r.Properties(), level+1)
+	renderPerspectives(renderer, r.Perspectives(), level+1)
+	writeLine(renderer, level, dsl.CloseBracket)
 
 	return nil
 }
+
+// relationShipDSLTags returns the tags of a relationship, with the tag of the interaction style it inherits
+// when implied, the DSL always adding the default one
+func relationShipDSLTags(r *gostructurizr.RelationShipNode) []string {
+	all := splitTags(jsonTags(r.Tags()))
+	if style := r.InteractionStyle(); style != nil && !r.Tags().Contains(style.Tag().String()) {
+		all = append(all, style.Tag().String())
+	}
+	return all
+}
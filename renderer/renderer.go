@@ -2,7 +2,6 @@ package renderer
 
 import (
 	"fmt"
-	"github.com/iancoleman/strcase"
 	"github.com/platelk/gostructurizr"
 	"github.com/platelk/gostructurizr/dsl"
 	"io"
@@ -10,7 +9,10 @@ import (
 )
 
 type DSLRenderer struct {
-	writer io.Writer
+	writer          io.Writer
+	validate        bool
+	canonical       bool
+	explicitImplied bool
 }
 
 func NewDSLRenderer(writer io.Writer) *DSLRenderer {
@@ -19,9 +21,35 @@ func NewDSLRenderer(writer io.Writer) *DSLRenderer {
 	}
 }
 
+// WithValidation makes Render refuse the workspaces having errors reported by WorkspaceNode.Validate,
+// returning a *gostructurizr.ValidationError instead
+func (r *DSLRenderer) WithValidation() *DSLRenderer {
+	r.validate = true
+	return r
+}
+
+// WithCanonicalOrder writes the elements and relationships of the model sorted by identifier instead of
+// in the order they were added, so reordering the code building a workspace doesn't change its DSL
+func (r *DSLRenderer) WithCanonicalOrder() *DSLRenderer {
+	r.canonical = true
+	return r
+}
+
+// WithExplicitImpliedRelationships writes the implied relationships of the model as any other relationship,
+// instead of leaving them to the `!impliedRelationships` strategy of the DSL
+func (r *DSLRenderer) WithExplicitImpliedRelationships() *DSLRenderer {
+	r.explicitImplied = true
+	return r
+}
+
 func (r *DSLRenderer) Render(w *gostructurizr.WorkspaceNode) error {
+	if r.validate {
+		if errors := w.Validate().Errors(); len(errors) > 0 {
+			return &gostructurizr.ValidationError{Diagnostics: errors}
+		}
+	}
 	return renderWrapper(r.writer, func(renderer *strings.Builder) error {
-		return renderWorkspace(w, renderer, 0)
+		return renderWorkspace(w, &dslContext{canonical: r.canonical, explicitImplied: r.explicitImplied}, renderer, 0)
 	})
 }
 
@@ -45,10 +73,6 @@ func generateIdent(level int) string {
 	return strings.Repeat(" ", level*4)
 }
 
-func generateVarName(s string) string {
-	return strcase.ToLowerCamel(s)
-}
-
 func writeLine(renderer *strings.Builder, level int, values ...string) {
 	renderer.WriteString(generateIdent(level))
 	for _, value := range values {
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/platelk/gostructurizr"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"strings"
 	"testing"
 )
 
@@ -57,3 +59,359 @@ func Test_renderWorkspace_with_extend(t *testing.T) {
 
 	fmt.Println(buf.String())
 }
+
+func Test_renderModel_deploymentEnvironments(t *testing.T) {
+	w := gostructurizr.Workspace()
+	m := w.Model()
+	shop := m.AddSoftwareSystem("Shop", "Sells things")
+	web := shop.AddContainer("Web", "Storefront", "Go")
+	aws := m.AddProdNode("AWS", "Amazon", "Cloud").WithTag("Cloud")
+	cluster := aws.AddChildNode("Cluster", "", "EKS")
+	prodWeb := cluster.AddContainerInstance(web)
+	prodWeb.AddHealthCheck("Ping", "https://shop/ping").WithTimeout(500)
+	lb := aws.AddInfrastructureNode("Load Balancer", "Routes traffic", "ELB")
+	devWeb := m.AddDevNode("Laptop", "", "").AddContainerInstance(web)
+	lb.Uses(cluster, "Forwards to")
+	devWeb.Uses(prodWeb, "Replicates")
+
+	buf := bytes.Buffer{}
+	require.NoError(t, renderModel(m, &dslContext{ids: w.Identifiers().Assign(m)}, &buf, 0))
+	assert.Equal(t, `model {
+    !impliedRelationships false
+    shop = softwareSystem "Shop" "Sells things" {
+        web = container "Web" "Storefront" "Go"
+    }
+    deploymentEnvironment "Production" {
+        aws = deploymentNode "AWS" "Amazon" "Cloud" {
+            tags "Cloud"
+            cluster = deploymentNode "Cluster" "" "EKS" {
+                webInstance = containerInstance web {
+                    healthCheck "Ping" "https://shop/ping" 60 500
+                }
+            }
+            loadBalancer = infrastructureNode "Load Balancer" "Routes traffic" "ELB"
+        }
+    }
+    deploymentEnvironment "Development" {
+        laptop = deploymentNode "Laptop" {
+            webInstance2 = containerInstance web
+        }
+    }
+
+    loadBalancer -> cluster "Forwards to"
+    webInstance2 -> webInstance "Replicates"
+}
+`, buf.String())
+}
+
+func Test_renderViewDynamic(t *testing.T) {
+	w := gostructurizr.Workspace()
+	m := w.Model()
+	customer := m.AddPerson("Customer", "")
+	shop := m.AddSoftwareSystem("Shop", "")
+	web := shop.AddContainer("Web", "", "")
+	payment := m.AddSoftwareSystem("Payment", "")
+	stock := m.AddSoftwareSystem("Stock", "")
+	d := w.Views().CreateDynamicView(shop).WithKey("Checkout").WithAutoLayout().
+		Add(customer, web, "Checks out", "HTTPS").
+		StartParallelSequence().Add(web, payment, "Charges").EndParallelSequence().
+		StartParallelSequence().Add(web, stock, "Reserves").Add(stock, web, "").EndParallelSequence().
+		Add(web, customer, "Confirms")
+
+	rendered := strings.Builder{}
+	require.NoError(t, renderViewDynamic(d, nil, &rendered, 0))
+	assert.Equal(t, `dynamic shop "Checkout" {
+    customer -> web "Checks out" "HTTPS"
+    {
+        {
+            web -> payment "Charges"
+        }
+        {
+            web -> stock "Reserves"
+            stock -> web
+        }
+    }
+    web -> customer "Confirms"
+    autoLayout
+}
+`, rendered.String())
+
+	var orders []int
+	for _, step := range d.Steps() {
+		orders = append(orders, step.Order)
+	}
+	assert.Equal(t, []int{1, 2, 2, 3, 4}, orders)
+}
+
+func Test_renderViewComponent(t *testing.T) {
+	m := gostructurizr.Workspace().Model()
+	customer := m.AddPerson("Customer", "")
+	shop := m.AddSoftwareSystem("Shop", "")
+	web := shop.AddContainer("Web", "", "")
+	api := shop.AddContainer("API", "", "")
+	orders := api.AddComponent("Orders")
+	c := gostructurizr.Workspace().Views().CreateComponentView(api).WithKey("Components").
+		AddAllComponents().
+		AddContainer(web).
+		WithInclude(gostructurizr.On(customer).WithEfferent(true)).
+		WithExclude(gostructurizr.On(orders)).
+		WithAutoLayout()
+
+	rendered := strings.Builder{}
+	require.NoError(t, renderViewComponent(c, nil, &rendered, 0))
+	assert.Equal(t, `component api "Components" {
+    include *
+    include web
+    include customer->
+    exclude orders
+    autoLayout
+}
+`, rendered.String())
+}
+
+func Test_renderViewSystemLandscape(t *testing.T) {
+	w := gostructurizr.Workspace()
+	m := w.Model()
+	staff := m.AddPerson("Staff", "")
+	customer := m.AddPerson("Customer", "")
+	shop := m.AddSoftwareSystem("Shop", "")
+	payment := m.AddSoftwareSystem("Payment", "")
+	m.SetEnterprise("ACME").Add(staff, shop)
+	customer.Uses(shop, "Buys from")
+	shop.Uses(payment, "Charges")
+	l := w.Views().CreateSystemLandscapeView().WithKey("Landscape").WithDescription("The ACME landscape").
+		AddAllElements().
+		WithEnterpriseOnly().
+		WithInclude(gostructurizr.On(payment)).
+		WithAutoLayout()
+
+	rendered := strings.Builder{}
+	require.NoError(t, renderViewSystemLandscape(l, nil, &rendered, 0))
+	assert.Equal(t, `systemLandscape "Landscape" "The ACME landscape" {
+    include staff
+    include shop
+    include payment
+    autoLayout
+}
+`, rendered.String())
+	assert.Equal(t, []gostructurizr.Namer{staff, shop, payment}, l.Content().Elements())
+
+	rendered.Reset()
+	require.NoError(t, renderModel(m, nil, &rendered, 0))
+	assert.Equal(t, `model {
+    !impliedRelationships false
+    enterprise "ACME" {
+        staff = person "Staff" "" "Internal"
+        shop = softwareSystem "Shop" "" {
+            tags "Internal"
+        }
+    }
+    customer = person "Customer" "" "External"
+    payment = softwareSystem "Payment" "" {
+        tags "External"
+    }
+
+    customer -> shop "Buys from"
+    shop -> payment "Charges"
+}
+`, rendered.String())
+
+	// an enterprise without members isn't rendered, the location tags are
+	m = gostructurizr.Model()
+	m.SetEnterprise("ACME")
+	m.AddPerson("Customer", "")
+	rendered.Reset()
+	require.NoError(t, renderModel(m, nil, &rendered, 0))
+	assert.NotContains(t, rendered.String(), "enterprise")
+	assert.Contains(t, rendered.String(), `customer = person "Customer" "" "External"`)
+}
+
+func TestDSLRenderer_WithValidation(t *testing.T) {
+	w := gostructurizr.Workspace()
+	w.Model().AddPerson("Customer", "").Uses(gostructurizr.Model().AddPerson("Stranger", ""), "Talks to")
+	buf := bytes.Buffer{}
+
+	require.NoError(t, NewDSLRenderer(&buf).Render(w))
+
+	buf.Reset()
+	err := NewDSLRenderer(&buf).WithValidation().Render(w)
+	var validationErr *gostructurizr.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, gostructurizr.RuleDanglingReference, validationErr.Diagnostics[0].Rule)
+	assert.Empty(t, buf.String())
+}
+
+func TestDSLRenderer_hierarchicalIdentifiers(t *testing.T) {
+	w := gostructurizr.Workspace()
+	w.Identifiers().WithHierarchical()
+	shop := w.Model().AddSoftwareSystem("Shop", "")
+	shopGateway := shop.AddContainer("API Gateway", "", "")
+	backOffice := w.Model().AddSoftwareSystem("Back Office", "")
+	backOfficeGateway := backOffice.AddContainer("API Gateway", "", "")
+	w.Identifiers().Pin(backOffice, "office")
+	shopGateway.Uses(backOfficeGateway, "Forwards to")
+	buf := bytes.Buffer{}
+
+	require.NoError(t, NewDSLRenderer(&buf).Render(w))
+	assert.Contains(t, buf.String(), `workspace {
+    !identifiers hierarchical
+    model {
+        !impliedRelationships false
+        shop = softwareSystem "Shop" "" {
+            apiGateway = container "API Gateway" "" ""
+        }
+        office = softwareSystem "Back Office" "" {
+            apiGateway = container "API Gateway" "" ""
+        }
+
+        shop.apiGateway -> office.apiGateway "Forwards to"
+    }
+`)
+}
+
+func TestDSLRenderer_impliedRelationships(t *testing.T) {
+	newWorkspace := func(strategy gostructurizr.ImpliedRelationshipsStrategy) *gostructurizr.WorkspaceNode {
+		w := gostructurizr.Workspace()
+		w.Model().WithImpliedRelationships(strategy)
+		customer := w.Model().AddPerson("Customer", "")
+		shop := w.Model().AddSoftwareSystem("Shop", "")
+		customer.Uses(shop.AddContainer("Web", "", ""), "Browses")
+		return w
+	}
+	buf := bytes.Buffer{}
+
+	// Structurizr creates implied relationships unless told otherwise, the default strategy has to turn it off
+	require.NoError(t, NewDSLRenderer(&buf).Render(newWorkspace(gostructurizr.NoImpliedRelationships)))
+	assert.Contains(t, buf.String(), `    model {
+        !impliedRelationships false
+`)
+	assert.NotContains(t, buf.String(), `customer -> shop`)
+
+	buf.Reset()
+	require.NoError(t, NewDSLRenderer(&buf).Render(newWorkspace(gostructurizr.CreateImpliedRelationshipsUnlessAnyExist)))
+	assert.Contains(t, buf.String(), `    model {
+        !impliedRelationships true
+`)
+	assert.NotContains(t, buf.String(), `customer -> shop`)
+
+	buf.Reset()
+	require.NoError(t, NewDSLRenderer(&buf).Render(newWorkspace(gostructurizr.CreateImpliedRelationshipsUnlessSameDescription)))
+	assert.Contains(t, buf.String(), `!impliedRelationships "com.structurizr.model.CreateImpliedRelationshipsUnlessSameRelationshipExistsStrategy"`)
+
+	buf.Reset()
+	require.NoError(t, NewDSLRenderer(&buf).WithExplicitImpliedRelationships().Render(newWorkspace(gostructurizr.CreateImpliedRelationshipsUnlessAnyExist)))
+	assert.Contains(t, buf.String(), `!impliedRelationships false`)
+	assert.Contains(t, buf.String(), `customer -> shop "Browses"`)
+
+	// Structurizr has no strategy creating every implied relationship, they are written explicitly
+	buf.Reset()
+	require.NoError(t, NewDSLRenderer(&buf).Render(newWorkspace(gostructurizr.AlwaysCreateImpliedRelationships)))
+	assert.Contains(t, buf.String(), `!impliedRelationships false`)
+	assert.Contains(t, buf.String(), `customer -> shop "Browses"`)
+}
+
+func TestDSLRenderer_withoutImpliedRelationships(t *testing.T) {
+	w := gostructurizr.Workspace()
+	w.Model().WithImpliedRelationships(gostructurizr.CreateImpliedRelationshipsUnlessAnyExist)
+	customer := w.Model().AddPerson("Customer", "")
+	shop := w.Model().AddSoftwareSystem("Shop", "")
+	customer.Uses(shop.AddContainer("Web", "", ""), "Browses")
+	w.Views().CreateSystemLandscapeView().WithKey("Landscape").AddAllElements().WithoutImpliedRelationships()
+	buf := bytes.Buffer{}
+
+	require.NoError(t, NewDSLRenderer(&buf).Render(w))
+	assert.Contains(t, buf.String(), `        systemLandscape "Landscape" {
+            include *
+            exclude customer -> shop
+        }
+`)
+}
+
+func TestDSLRenderer_relationships(t *testing.T) {
+	w := gostructurizr.Workspace()
+	customer := w.Model().AddPerson("Customer", "")
+	shop := w.Model().AddSoftwareSystem("Shop", "")
+	events := w.Model().AddSoftwareSystem("Events", "")
+	customer.Uses(shop, "Buys from").WithTechnology("HTTPS").WithInteractionStyle(gostructurizr.Synchronous)
+	shop.Uses(events, "Publishes to").
+		WithInteractionStyle(gostructurizr.Asynchronous).
+		WithTag("Critical").
+		WithURL("https://wiki.example.com/events")
+	shop.Uses(customer, "").
+		WithPerspective(gostructurizr.Perspective{Name: "Security", Description: "Signed emails", Value: "high"}).
+		Properties().Add("owner", "Team Mail")
+	buf := bytes.Buffer{}
+
+	require.NoError(t, NewDSLRenderer(&buf).Render(w))
+	assert.Contains(t, buf.String(), `        customer -> shop "Buys from" "HTTPS" "synchronous"
+        shop -> events "Publishes to" "" "asynchronous,Critical" {
+            url "https://wiki.example.com/events"
+        }
+        shop -> customer "" {
+            properties {
+                "owner" "Team Mail"
+            }
+            perspectives {
+                "Security" "Signed emails" "high"
+            }
+        }
+`)
+}
+
+func Test_renderRelationshipStyle(t *testing.T) {
+	style := gostructurizr.RelationshipStyle("Relationship").
+		WithThickness(2).
+		WithColor("#aa0000").
+		WithFontColor("#ffffff").
+		WithFontStyle("italic").
+		WithStartTerminator(gostructurizr.Circle).
+		WithArrow()
+	rendered := strings.Builder{}
+
+	require.NoError(t, renderRelationshipStyle(style, &rendered, 0))
+	assert.Equal(t, `relationship "Relationship" {
+    thickness 2
+    color #aa0000
+}
+`, rendered.String())
+}
+
+func TestDSLRenderer_groups(t *testing.T) {
+	w := gostructurizr.Workspace()
+	m := w.Model()
+	m.AddPerson("Admin", "Runs the shop")
+	customer := m.AddPerson("Customer", "Buys things")
+	shop := m.AddSoftwareSystem("Shop", "Sells things")
+	billing := m.AddSoftwareSystem("Billing", "Sends invoices")
+	m.AddGroup("Sales").Add(customer, shop).AddGroup("Finance").Add(billing)
+	web := shop.AddContainer("Web", "Storefront", "Go")
+	api := shop.AddContainer("API", "Backend", "Go")
+	shop.AddGroup("Frontend").Add(web)
+	api.AddGroup("Domain").Add(api.AddComponent("Orders"))
+	buf := bytes.Buffer{}
+
+	require.NoError(t, NewDSLRenderer(&buf).Render(w))
+	assert.Contains(t, buf.String(), `    model {
+        !impliedRelationships false
+        properties {
+            "structurizr.groupSeparator" "/"
+        }
+        admin = person "Admin" "Runs the shop"
+        group "Sales" {
+            customer = person "Customer" "Buys things"
+            shop = softwareSystem "Shop" "Sells things" {
+                api = container "API" "Backend" "Go" {
+                    group "Domain" {
+                        orders = component "Orders"
+                    }
+                }
+                group "Frontend" {
+                    web = container "Web" "Storefront" "Go"
+                }
+            }
+            group "Finance" {
+                billing = softwareSystem "Billing" "Sends invoices"
+            }
+        }
+`)
+}
@@ -7,9 +7,9 @@ import (
 	"strings"
 )
 
-func renderSoftwareSystem(s *gostructurizr.SoftwareSystemNode, renderer *strings.Builder, level int) error {
+func renderSoftwareSystem(s *gostructurizr.SoftwareSystemNode, ctx *dslContext, renderer *strings.Builder, level int) error {
 	var line []string
-	line = append(line, generateVarName(s.Name()), dsl.Space, dsl.Equal, dsl.Space, dsl.SoftwareSystem, dsl.Space, generateStringIdentifier(s.Name()))
+	line = append(line, ctx.local(s), dsl.Space, dsl.Equal, dsl.Space, dsl.SoftwareSystem, dsl.Space, generateStringIdentifier(s.Name()))
 	if s.Description() != nil {
 		line = append(line, dsl.Space, generateStringIdentifier(*s.Description()))
 	}
@@ -25,10 +25,24 @@ func renderSoftwareSystem(s *gostructurizr.SoftwareSystemNode, renderer *strings
 		tagList := strings.Join(s.Tags().List(), ", ")
 		fmt.Fprintf(renderer, "%s%s %q\n", indent, dsl.Tags, tagList)
 	}
-	for _, container := range containers {
-		if err := renderContainer(container, renderer, level+1); err != nil {
+	render := func(c *gostructurizr.ContainerNode, level int) error {
+		if err := renderContainer(c, ctx, renderer, level); err != nil {
 			return fmt.Errorf("can't render container: %w", err)
 		}
+		return nil
+	}
+	for _, container := range ordered(ctx, containers) {
+		if s.GroupOf(container) != nil {
+			continue
+		}
+		if err := render(container, level+1); err != nil {
+			return err
+		}
+	}
+	// containers of another software system can't be declared here, Validate reports them
+	owned := func(c *gostructurizr.ContainerNode) bool { return c.Parent() == s }
+	if err := renderGroups(s.Groups(), ctx, renderer, level+1, owned, render); err != nil {
+		return err
 	}
 	writeLine(renderer, level, dsl.CloseBracket)
 	return nil
@@ -1,25 +1,32 @@
 package renderer
 
 import (
+	"fmt"
 	"github.com/platelk/gostructurizr"
 	"github.com/platelk/gostructurizr/dsl"
 	"strings"
 )
 
-func renderSystemContext(s *gostructurizr.SystemContextViewNode, renderer *strings.Builder, level int) error {
+func renderSystemContext(s *gostructurizr.SystemContextViewNode, ctx *dslContext, renderer *strings.Builder, level int) error {
 	var line []string
-	line = append(line, dsl.SystemContext, dsl.Space, generateVarName(s.SoftwareSystem().Name()))
+	line = append(line, dsl.SystemContext, dsl.Space, ctx.id(s.SoftwareSystem()))
 	if s.Key() != nil && *s.Key() != "" {
 		line = append(line, dsl.Space, generateStringIdentifier(*s.Key()))
 	}
 	if s.Description() != nil && *s.Description() != "" {
 		line = append(line, dsl.Space, generateStringIdentifier(*s.Description()))
 	}
-	line = append(line, dsl.OpenBracket)
+	line = append(line, dsl.Space, dsl.OpenBracket)
 	writeLine(renderer, level, line...)
 	if s.IsAllElements() && s.IsAllPeople() {
 		writeLine(renderer, level+1, dsl.Include, dsl.Space, dsl.All)
 	}
+	for _, e := range s.Includes() {
+		if err := renderInclude(e, ctx, renderer, level+1); err != nil {
+			return fmt.Errorf("can't render include: %w", err)
+		}
+	}
+	renderHiddenRelationShips(s.Content(), ctx, renderer, level+1)
 	if s.AutoLayout() {
 		writeLine(renderer, level+1, dsl.AutoLayout)
 	}
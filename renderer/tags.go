@@ -5,6 +5,7 @@ import (
 
 	"github.com/platelk/gostructurizr"
 	"github.com/platelk/gostructurizr/dsl"
+	"github.com/platelk/gostructurizr/tags"
 )
 
 // RenderTagsV1 is the legacy renderer for tags
@@ -16,3 +17,48 @@ func RenderTagsV1(p *gostructurizr.TagsNode, renderer *strings.Builder, level in
 	writeLine(renderer, level, line...)
 	return nil
 }
+
+// ElementTags returns the tags of an element, starting with the ones Structurizr gives by default
+// to its type, which is what element styles are matched against.
+func ElementTags(n gostructurizr.Namer) []string {
+	var defaults []tags.Tag
+	var elementTags *gostructurizr.TagsNode
+	switch e := n.(type) {
+	case *gostructurizr.PersonNode:
+		defaults, elementTags = []tags.Tag{tags.Element, tags.Person}, e.Tags()
+	case *gostructurizr.SoftwareSystemNode:
+		defaults, elementTags = []tags.Tag{tags.Element, tags.SoftwareSystem}, e.Tags()
+	case *gostructurizr.ContainerNode:
+		defaults, elementTags = []tags.Tag{tags.Element, tags.Container}, e.Tags()
+	case *gostructurizr.ComponentNode:
+		defaults, elementTags = []tags.Tag{tags.Element, tags.Component}, e.Tags()
+	case *gostructurizr.DeploymentNodeNode:
+		defaults, elementTags = []tags.Tag{tags.Element}, e.Tags()
+	case *gostructurizr.InfrastructureNodeNode:
+		defaults, elementTags = []tags.Tag{tags.Element}, e.Tags()
+	case *gostructurizr.ContainerInstanceNode:
+		defaults, elementTags = []tags.Tag{tags.Element}, e.Tags()
+	}
+	return splitTags(jsonTags(elementTags, defaults...))
+}
+
+// RelationShipTags returns the tags of a relationship, starting with the default relationship tag and
+// the tag of its interaction style, which implied relationships inherit
+func RelationShipTags(r *gostructurizr.RelationShipNode) []string {
+	return splitTags(jsonTags(r.Tags(), relationShipDefaults(r)...))
+}
+
+func relationShipDefaults(r *gostructurizr.RelationShipNode) []tags.Tag {
+	defaults := []tags.Tag{tags.RelationShip}
+	if r.InteractionStyle() != nil {
+		defaults = append(defaults, r.InteractionStyle().Tag())
+	}
+	return defaults
+}
+
+func splitTags(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
@@ -7,25 +7,35 @@ import (
 	"strings"
 )
 
-func renderView(v *gostructurizr.ViewsNode, renderer *strings.Builder, level int) error {
+func renderView(v *gostructurizr.ViewsNode, ctx *dslContext, renderer *strings.Builder, level int) error {
 	writeLine(renderer, level, dsl.Views, dsl.Space, dsl.OpenBracket)
+	for _, s := range v.SystemLandscapeViews() {
+		if err := renderViewSystemLandscape(s, ctx, renderer, level+1); err != nil {
+			return fmt.Errorf("can't generate system landscape view: %w", err)
+		}
+	}
 	for _, s := range v.SystemContextViews() {
-		if err := renderSystemContext(s, renderer, level+1); err != nil {
+		if err := renderSystemContext(s, ctx, renderer, level+1); err != nil {
 			return fmt.Errorf("can't generate system context view: %w", err)
 		}
 	}
 	for _, c := range v.ContainerViews() {
-		if err := renderViewContainer(c, renderer, level+1); err != nil {
+		if err := renderViewContainer(c, ctx, renderer, level+1); err != nil {
 			return fmt.Errorf("can't generate container view: %w", err)
 		}
 	}
 	for _, c := range v.ComponentViews() {
-		if err := renderViewComponent(c, renderer, level+1); err != nil {
+		if err := renderViewComponent(c, ctx, renderer, level+1); err != nil {
 			return fmt.Errorf("can't generate component view: %w", err)
 		}
 	}
+	for _, d := range v.DynamicViews() {
+		if err := renderViewDynamic(d, ctx, renderer, level+1); err != nil {
+			return fmt.Errorf("can't generate dynamic view: %w", err)
+		}
+	}
 	for _, d := range v.DeploymentViews() {
-		if err := renderDeploymentView(d, renderer, level+1); err != nil {
+		if err := renderDeploymentView(d, ctx, renderer, level+1); err != nil {
 			return fmt.Errorf("can't generate deployment view: %w", err)
 		}
 	}
@@ -85,47 +95,32 @@ func renderFilteredView(f *gostructurizr.FilteredViewNode, renderer *strings.Bui
 	return nil
 }
 
-func renderDeploymentView(d *gostructurizr.DeploymentViewNode, renderer *strings.Builder, level int) error {
-	writeLine(renderer, level, dsl.DeploymentView, dsl.Space, dsl.OpenBracket)
-	
-	// Software system
+// renderDeploymentView writes `deployment <*|softwareSystem> <environment> [key] [description]`
+// with the deployment nodes and relationships added to the view
+func renderDeploymentView(d *gostructurizr.DeploymentViewNode, ctx *dslContext, renderer *strings.Builder, level int) error {
+	scope := dsl.All
 	if d.SoftwareSystem() != nil {
-		writeLine(renderer, level+1, dsl.SoftwareSystem, dsl.Space, generateVarName(d.SoftwareSystem().Name()))
+		scope = ctx.id(d.SoftwareSystem())
 	}
-	
-	// Environment
-	environment := string(d.Environment())
-	if environment != "" {
-		writeLine(renderer, level+1, dsl.Environment, dsl.Space, generateStringIdentifier(environment))
-	}
-	
-	// Key
-	if d.GetKey() != "" {
-		writeLine(renderer, level+1, dsl.Key, dsl.Space, generateStringIdentifier(d.GetKey()))
+	line := []string{dsl.DeploymentView, dsl.Space, scope, dsl.Space, generateStringIdentifier(string(d.Environment()))}
+	// the key has to be written for the description to be
+	if d.GetKey() != "" || d.GetDescription() != "" {
+		line = append(line, dsl.Space, generateStringIdentifier(d.GetKey()))
 	}
-	
-	// Description
 	if d.GetDescription() != "" {
-		writeLine(renderer, level+1, dsl.Description, dsl.Space, generateStringIdentifier(d.GetDescription()))
+		line = append(line, dsl.Space, generateStringIdentifier(d.GetDescription()))
 	}
-	
-	// Auto layout
-	if d.IsAutoLayout() {
-		writeLine(renderer, level+1, dsl.AutoLayout)
-	}
-	
-	// Elements
+	line = append(line, dsl.Space, dsl.OpenBracket)
+	writeLine(renderer, level, line...)
 	for _, element := range d.Elements() {
-		writeLine(renderer, level+1, dsl.Include, dsl.Space, generateVarName(element.Name()))
+		writeLine(renderer, level+1, dsl.Include, dsl.Space, ctx.id(element))
 	}
-	
-	// Relationships
 	for _, rs := range d.RelationShips() {
-		from := generateVarName(rs.From().Name())
-		to := generateVarName(rs.To().Name())
-		writeLine(renderer, level+1, dsl.Include, dsl.Space, from, dsl.Space, dsl.Arrow, dsl.Space, to)
+		writeLine(renderer, level+1, dsl.Include, dsl.Space, ctx.id(rs.From()), dsl.Space, dsl.Arrow, dsl.Space, ctx.id(rs.To()))
+	}
+	if d.IsAutoLayout() {
+		writeLine(renderer, level+1, dsl.AutoLayout)
 	}
-	
 	writeLine(renderer, level, dsl.CloseBracket)
 	return nil
 }
@@ -1,25 +1,40 @@
 package renderer
 
 import (
+	"fmt"
 	"github.com/platelk/gostructurizr"
 	"github.com/platelk/gostructurizr/dsl"
 	"strings"
 )
 
-func renderViewComponent(c *gostructurizr.ComponentsViewNode, renderer *strings.Builder, level int) error {
+func renderViewComponent(c *gostructurizr.ComponentsViewNode, ctx *dslContext, renderer *strings.Builder, level int) error {
 	var line []string
-	line = append(line, dsl.Container, dsl.Space, generateVarName(c.Container().Name()))
+	line = append(line, dsl.Component, dsl.Space, ctx.id(c.Container()))
 	if c.Key() != nil && *c.Key() != "" {
 		line = append(line, dsl.Space, generateStringIdentifier(*c.Key()))
 	}
 	if c.Description() != nil && *c.Description() != "" {
 		line = append(line, dsl.Space, generateStringIdentifier(*c.Description()))
 	}
-	line = append(line, dsl.OpenBracket)
+	line = append(line, dsl.Space, dsl.OpenBracket)
 	writeLine(renderer, level, line...)
-	if c.IsAllElements() && c.IsAllPeople() {
+	if c.IsAllElements() {
 		writeLine(renderer, level+1, dsl.Include, dsl.Space, dsl.All)
 	}
+	for _, e := range c.Elements() {
+		writeLine(renderer, level+1, dsl.Include, dsl.Space, ctx.id(e))
+	}
+	for _, e := range c.Includes() {
+		if err := renderInclude(e, ctx, renderer, level+1); err != nil {
+			return fmt.Errorf("can't render include: %w", err)
+		}
+	}
+	for _, e := range c.Excludes() {
+		if err := renderExclude(e, ctx, renderer, level+1); err != nil {
+			return fmt.Errorf("can't render exclude: %w", err)
+		}
+	}
+	renderHiddenRelationShips(c.Content(), ctx, renderer, level+1)
 	if c.AutoLayout() {
 		writeLine(renderer, level+1, dsl.AutoLayout)
 	}
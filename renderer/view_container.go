@@ -7,9 +7,9 @@ import (
 	"strings"
 )
 
-func renderViewContainer(c *gostructurizr.ContainersViewNode, renderer *strings.Builder, level int) error {
+func renderViewContainer(c *gostructurizr.ContainersViewNode, ctx *dslContext, renderer *strings.Builder, level int) error {
 	var line []string
-	line = append(line, dsl.Container, dsl.Space, generateVarName(c.SoftwareSystem().Name()))
+	line = append(line, dsl.Container, dsl.Space, ctx.id(c.SoftwareSystem()))
 	if c.Key() != nil && *c.Key() != "" {
 		line = append(line, dsl.Space, generateStringIdentifier(*c.Key()))
 	}
@@ -21,11 +21,20 @@ func renderViewContainer(c *gostructurizr.ContainersViewNode, renderer *strings.
 	if c.IsAllElements() {
 		writeLine(renderer, level+1, dsl.Include, dsl.Space, dsl.All)
 	}
+	for _, system := range c.SoftwareSystems() {
+		writeLine(renderer, level+1, dsl.Include, dsl.Space, ctx.id(system))
+	}
 	for _, e := range c.Includes() {
-		if err := renderInclude(e, renderer, level+1); err != nil {
+		if err := renderInclude(e, ctx, renderer, level+1); err != nil {
 			return fmt.Errorf("can't render include: %w", err)
 		}
 	}
+	for _, e := range c.Excludes() {
+		if err := renderExclude(e, ctx, renderer, level+1); err != nil {
+			return fmt.Errorf("can't render exclude: %w", err)
+		}
+	}
+	renderHiddenRelationShips(c.Content(), ctx, renderer, level+1)
 	if c.AutoLayout() {
 		writeLine(renderer, level+1, dsl.AutoLayout)
 	}
@@ -0,0 +1,89 @@
+package renderer
+
+import (
+	"strings"
+
+	"github.com/platelk/gostructurizr"
+	"github.com/platelk/gostructurizr/dsl"
+)
+
+func renderViewDynamic(d *gostructurizr.DynamicViewNode, ctx *dslContext, renderer *strings.Builder, level int) error {
+	var line []string
+	scope := dsl.All
+	if d.Identifier() != nil {
+		scope = ctx.id(d.Identifier())
+	}
+	line = append(line, dsl.Dynamic, dsl.Space, scope)
+	if d.Key() != nil && *d.Key() != "" {
+		line = append(line, dsl.Space, generateStringIdentifier(*d.Key()))
+	}
+	if d.Description() != nil && *d.Description() != "" {
+		if d.Key() == nil || *d.Key() == "" {
+			line = append(line, dsl.Space, dsl.EmptyIdentifier)
+		}
+		line = append(line, dsl.Space, generateStringIdentifier(*d.Description()))
+	}
+	line = append(line, dsl.Space, dsl.OpenBracket)
+	writeLine(renderer, level, line...)
+
+	// steps of a group of parallel sequences are written in a block, with a nested block per sequence
+	group, sequence := 0, 0
+	for _, step := range d.Steps() {
+		if step.Sequence != sequence && sequence != 0 {
+			writeLine(renderer, level+2, dsl.CloseBracket)
+		}
+		if step.Group != group && group != 0 {
+			writeLine(renderer, level+1, dsl.CloseBracket)
+		}
+		if step.Group != group && step.Group != 0 {
+			writeLine(renderer, level+1, dsl.OpenBracket)
+		}
+		if step.Sequence != sequence && step.Sequence != 0 {
+			writeLine(renderer, level+2, dsl.OpenBracket)
+		}
+		group, sequence = step.Group, step.Sequence
+		stepLevel := level + 1
+		if sequence != 0 {
+			stepLevel = level + 3
+		}
+		renderDynamicStep(step.RelationShip, ctx, renderer, stepLevel)
+	}
+	if sequence != 0 {
+		writeLine(renderer, level+2, dsl.CloseBracket)
+		writeLine(renderer, level+1, dsl.CloseBracket)
+	}
+
+	if d.AutoLayout() {
+		writeLine(renderer, level+1, dsl.AutoLayout)
+	}
+	writeLine(renderer, level, dsl.CloseBracket)
+	return nil
+}
+
+// renderDynamicStep writes a step as `<from> -> <to> [description] [technology]`, the order of the
+// step being given by its position in the view
+func renderDynamicStep(r *gostructurizr.RelationShipNode, ctx *dslContext, renderer *strings.Builder, level int) {
+	var line []string
+	line = append(line, ctx.id(r.From()), dsl.Space, dsl.Arrow, dsl.Space, ctx.id(r.To()))
+	hasTechnology := r.Technology() != nil && *r.Technology() != ""
+	if (r.Description() != nil && *r.Description() != "") || hasTechnology {
+		desc := ""
+		if r.Description() != nil {
+			desc = *r.Description()
+		}
+		line = append(line, dsl.Space, generateStringIdentifier(desc))
+	}
+	if hasTechnology {
+		line = append(line, dsl.Space, generateStringIdentifier(*r.Technology()))
+	}
+	writeLine(renderer, level, line...)
+}
+
+// StepOrders returns the order of every step of a dynamic view, for the diagram renderers to number them
+func StepOrders(d *gostructurizr.DynamicViewNode) map[*gostructurizr.RelationShipNode]int {
+	orders := map[*gostructurizr.RelationShipNode]int{}
+	for _, step := range d.Steps() {
+		orders[step.RelationShip] = step.Order
+	}
+	return orders
+}
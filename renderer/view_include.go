@@ -6,10 +6,19 @@ import (
 	"strings"
 )
 
-func renderInclude(e *gostructurizr.ExpressionViewNode, renderer *strings.Builder, level int) error {
-	line := []string{dsl.Include, dsl.Space}
+func renderInclude(e *gostructurizr.ExpressionViewNode, ctx *dslContext, renderer *strings.Builder, level int) error {
+	return renderExpression(dsl.Include, e, ctx, renderer, level)
+}
+
+func renderExclude(e *gostructurizr.ExpressionViewNode, ctx *dslContext, renderer *strings.Builder, level int) error {
+	return renderExpression(dsl.Exclude, e, ctx, renderer, level)
+}
+
+// renderExpression writes an include or exclude statement
+func renderExpression(keyword string, e *gostructurizr.ExpressionViewNode, ctx *dslContext, renderer *strings.Builder, level int) error {
+	line := []string{keyword, dsl.Space}
 	if e.From() != nil {
-		line = append(line, dsl.Space, generateVarName(e.From().Name()))
+		line = append(line, dsl.Space, ctx.id(e.From()))
 	}
 	if e.Afferent() {
 		if e.From() != nil {
@@ -18,7 +27,7 @@ func renderInclude(e *gostructurizr.ExpressionViewNode, renderer *strings.Builde
 		line = append(line, dsl.Arrow)
 	}
 
-	line = append(line, generateVarName(e.On().Name()))
+	line = append(line, ctx.id(e.On()))
 	if e.Efferent() {
 		if e.To() != nil {
 			line = append(line, dsl.Space)
@@ -26,8 +35,26 @@ func renderInclude(e *gostructurizr.ExpressionViewNode, renderer *strings.Builde
 		line = append(line, dsl.Arrow)
 	}
 	if e.To() != nil {
-		line = append(line, dsl.Space, generateVarName(e.To().Name()))
+		line = append(line, dsl.Space, ctx.id(e.To()))
 	}
 	writeLine(renderer, level, line...)
 	return nil
 }
+
+// renderHiddenRelationShips excludes the relationships a view hides: the ones it excludes and its implied ones,
+// the DSL having no switch for them. A hidden relationship sharing its source and destination with a displayed one
+// is kept, as excluding it would exclude both.
+func renderHiddenRelationShips(content *gostructurizr.ViewContent, ctx *dslContext, renderer *strings.Builder, level int) {
+	excluded := map[string]bool{}
+	for _, displayed := range content.RelationShips() {
+		excluded[ctx.id(displayed.From())+dsl.Arrow+ctx.id(displayed.To())] = true
+	}
+	for _, r := range content.HiddenRelationShips() {
+		from, to := ctx.id(r.From()), ctx.id(r.To())
+		if excluded[from+dsl.Arrow+to] {
+			continue
+		}
+		excluded[from+dsl.Arrow+to] = true
+		writeLine(renderer, level, dsl.Exclude, dsl.Space, from, dsl.Space, dsl.Arrow, dsl.Space, to)
+	}
+}
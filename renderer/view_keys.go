@@ -0,0 +1,47 @@
+package renderer
+
+import (
+	"fmt"
+
+	"github.com/platelk/gostructurizr"
+)
+
+// ViewKeys returns the key of every view, indexed by view.
+// Views defined without a key get one generated from their type and rank, the way Structurizr
+// does (SystemContext-001, Container-001, ...), so a view keeps its key across renderings.
+func ViewKeys(v *gostructurizr.ViewsNode) map[interface{}]string {
+	keys := map[interface{}]string{}
+	counters := map[string]int{}
+	add := func(view interface{}, key *string, prefix string) {
+		if key != nil && *key != "" {
+			keys[view] = *key
+			return
+		}
+		counters[prefix]++
+		keys[view] = fmt.Sprintf("%s-%03d", prefix, counters[prefix])
+	}
+	for _, s := range v.SystemLandscapeViews() {
+		add(s, s.Key(), "SystemLandscape")
+	}
+	for _, s := range v.SystemContextViews() {
+		add(s, s.Key(), "SystemContext")
+	}
+	for _, c := range v.ContainerViews() {
+		add(c, c.Key(), "Container")
+	}
+	for _, c := range v.ComponentViews() {
+		add(c, c.Key(), "Component")
+	}
+	for _, d := range v.DynamicViews() {
+		add(d, d.Key(), "Dynamic")
+	}
+	for _, d := range v.DeploymentViews() {
+		key := d.GetKey()
+		add(d, &key, "Deployment")
+	}
+	for _, f := range v.FilteredViews() {
+		key := f.Key()
+		add(f, &key, "Filtered")
+	}
+	return keys
+}
@@ -0,0 +1,56 @@
+package renderer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/platelk/gostructurizr"
+	"github.com/platelk/gostructurizr/dsl"
+)
+
+func renderViewSystemLandscape(s *gostructurizr.SystemLandscapeViewNode, ctx *dslContext, renderer *strings.Builder, level int) error {
+	line := []string{dsl.SystemLandscape}
+	if s.Key() != nil && *s.Key() != "" {
+		line = append(line, dsl.Space, generateStringIdentifier(*s.Key()))
+	}
+	if s.Description() != nil && *s.Description() != "" {
+		if s.Key() == nil || *s.Key() == "" {
+			line = append(line, dsl.Space, dsl.EmptyIdentifier)
+		}
+		line = append(line, dsl.Space, generateStringIdentifier(*s.Description()))
+	}
+	line = append(line, dsl.Space, dsl.OpenBracket)
+	writeLine(renderer, level, line...)
+	if s.IsAllElements() && !s.IsEnterpriseOnly() {
+		writeLine(renderer, level+1, dsl.Include, dsl.Space, dsl.All)
+	}
+	// the DSL has no wildcard for the inside of the enterprise, its elements are listed instead
+	if s.IsAllElements() && s.IsEnterpriseOnly() && s.Model() != nil {
+		for _, p := range s.Model().Persons() {
+			if p.Location() == gostructurizr.InternalLocation {
+				writeLine(renderer, level+1, dsl.Include, dsl.Space, ctx.id(p))
+			}
+		}
+		for _, system := range s.Model().SoftwareSystems() {
+			if system.Location() == gostructurizr.InternalLocation {
+				writeLine(renderer, level+1, dsl.Include, dsl.Space, ctx.id(system))
+			}
+		}
+	}
+	for _, e := range s.Includes() {
+		if err := renderInclude(e, ctx, renderer, level+1); err != nil {
+			return fmt.Errorf("can't render include: %w", err)
+		}
+	}
+	for _, e := range s.Excludes() {
+		if err := renderExclude(e, ctx, renderer, level+1); err != nil {
+			return fmt.Errorf("can't render exclude: %w", err)
+		}
+	}
+	renderHiddenRelationShips(s.Content(), ctx, renderer, level+1)
+	if s.AutoLayout() {
+		writeLine(renderer, level+1, dsl.AutoLayout)
+	}
+	writeLine(renderer, level, dsl.CloseBracket)
+	return nil
+}
@@ -6,7 +6,7 @@ import (
 	"strings"
 )
 
-func renderWorkspace(w *gostructurizr.WorkspaceNode, renderer *strings.Builder, level int) error {
+func renderWorkspace(w *gostructurizr.WorkspaceNode, ctx *dslContext, renderer *strings.Builder, level int) error {
 	var line []string
 
 	line = append(line, dsl.Workspace, dsl.Space)
@@ -27,12 +27,16 @@ func renderWorkspace(w *gostructurizr.WorkspaceNode, renderer *strings.Builder,
 	line = append(line, dsl.OpenBracket)
 
 	writeLine(renderer, level, line...)
+	if w.Identifiers().IsHierarchical() {
+		writeLine(renderer, level+1, dsl.Identifiers, dsl.Space, dsl.Hierarchical)
+	}
 
-	err := renderModel(w.Model(), renderer, level+1)
+	ctx.ids = w.Identifiers().Assign(w.Model())
+	err := renderModel(w.Model(), ctx, renderer, level+1)
 	if err != nil {
 		return err
 	}
-	err = renderView(w.Views(), renderer, level+1)
+	err = renderView(w.Views(), ctx, renderer, level+1)
 	if err != nil {
 		return err
 	}
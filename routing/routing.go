@@ -1,3 +1,6 @@
+// Package routing holds the routings of the former relationship styles.
+//
+// Deprecated: use gostructurizr.RouteStyle.
 package routing
 
 type Routing string
@@ -0,0 +1,244 @@
+// Package schema holds the Go representation of the Structurizr JSON workspace format,
+// as consumed by Structurizr Lite, the cloud service and the Structurizr CLI.
+package schema
+
+type Workspace struct {
+	ID          int64  `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Model       Model  `json:"model"`
+	Views       Views  `json:"views"`
+}
+
+type Model struct {
+	Enterprise      *Enterprise       `json:"enterprise,omitempty"`
+	People          []Person          `json:"people,omitempty"`
+	SoftwareSystems []SoftwareSystem  `json:"softwareSystems,omitempty"`
+	DeploymentNodes []DeploymentNode  `json:"deploymentNodes,omitempty"`
+	Properties      map[string]string `json:"properties,omitempty"`
+}
+
+type Enterprise struct {
+	Name string `json:"name"`
+}
+
+// Element holds the fields shared by every model element
+type Element struct {
+	ID            string            `json:"id"`
+	Name          string            `json:"name,omitempty"`
+	Description   string            `json:"description,omitempty"`
+	Tags          string            `json:"tags,omitempty"`
+	URL           string            `json:"url,omitempty"`
+	Group         string            `json:"group,omitempty"`
+	Properties    map[string]string `json:"properties,omitempty"`
+	Relationships []Relationship    `json:"relationships,omitempty"`
+}
+
+type Person struct {
+	Element
+	Location string `json:"location,omitempty"`
+}
+
+type SoftwareSystem struct {
+	Element
+	Location   string      `json:"location,omitempty"`
+	Containers []Container `json:"containers,omitempty"`
+}
+
+type Container struct {
+	Element
+	Technology string      `json:"technology,omitempty"`
+	Components []Component `json:"components,omitempty"`
+}
+
+type Component struct {
+	Element
+	Technology string `json:"technology,omitempty"`
+}
+
+type DeploymentNode struct {
+	Element
+	Technology          string               `json:"technology,omitempty"`
+	Environment         string               `json:"environment,omitempty"`
+	Instances           string               `json:"instances,omitempty"`
+	Children            []DeploymentNode     `json:"children,omitempty"`
+	InfrastructureNodes []InfrastructureNode `json:"infrastructureNodes,omitempty"`
+	ContainerInstances  []ContainerInstance  `json:"containerInstances,omitempty"`
+}
+
+type InfrastructureNode struct {
+	Element
+	Technology  string `json:"technology,omitempty"`
+	Environment string `json:"environment,omitempty"`
+}
+
+type ContainerInstance struct {
+	Element
+	ContainerID  string        `json:"containerId"`
+	InstanceID   int           `json:"instanceId"`
+	Environment  string        `json:"environment,omitempty"`
+	HealthChecks []HealthCheck `json:"healthChecks,omitempty"`
+}
+
+type HealthCheck struct {
+	Name       string            `json:"name"`
+	URL        string            `json:"url"`
+	Interval   int               `json:"interval"`
+	Timeout    int               `json:"timeout"`
+	Properties map[string]string `json:"headers,omitempty"`
+}
+
+type Relationship struct {
+	ID               string            `json:"id"`
+	SourceID         string            `json:"sourceId"`
+	DestinationID    string            `json:"destinationId"`
+	Description      string            `json:"description,omitempty"`
+	Technology       string            `json:"technology,omitempty"`
+	Tags             string            `json:"tags,omitempty"`
+	URL              string            `json:"url,omitempty"`
+	InteractionStyle string            `json:"interactionStyle,omitempty"`
+	Properties       map[string]string `json:"properties,omitempty"`
+	Perspectives     []Perspective     `json:"perspectives,omitempty"`
+	// LinkedRelationshipID is the relationship an implied relationship is derived from
+	LinkedRelationshipID string `json:"linkedRelationshipId,omitempty"`
+}
+
+type Perspective struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Value       string `json:"value,omitempty"`
+}
+
+type Views struct {
+	SystemLandscapeViews []SystemLandscapeView `json:"systemLandscapeViews,omitempty"`
+	SystemContextViews   []SystemContextView   `json:"systemContextViews,omitempty"`
+	ContainerViews       []ContainerView       `json:"containerViews,omitempty"`
+	ComponentViews       []ComponentView       `json:"componentViews,omitempty"`
+	DynamicViews         []DynamicView         `json:"dynamicViews,omitempty"`
+	DeploymentViews      []DeploymentView      `json:"deploymentViews,omitempty"`
+	FilteredViews        []FilteredView        `json:"filteredViews,omitempty"`
+	Configuration        Configuration         `json:"configuration"`
+}
+
+// View holds the fields shared by every view
+type View struct {
+	Key             string             `json:"key"`
+	Description     string             `json:"description,omitempty"`
+	Title           string             `json:"title,omitempty"`
+	AutomaticLayout *AutomaticLayout   `json:"automaticLayout,omitempty"`
+	Elements        []ElementView      `json:"elements,omitempty"`
+	Relationships   []RelationshipView `json:"relationships,omitempty"`
+	Properties      map[string]string  `json:"properties,omitempty"`
+}
+
+type AutomaticLayout struct {
+	Implementation string `json:"implementation,omitempty"`
+	RankDirection  string `json:"rankDirection,omitempty"`
+	RankSeparation int    `json:"rankSeparation"`
+	NodeSeparation int    `json:"nodeSeparation"`
+	EdgeSeparation int    `json:"edgeSeparation"`
+	Vertices       bool   `json:"vertices"`
+}
+
+// ElementView is the placement of an element in a view
+type ElementView struct {
+	ID string `json:"id"`
+	X  int    `json:"x,omitempty"`
+	Y  int    `json:"y,omitempty"`
+}
+
+// RelationshipView is the placement of a relationship in a view
+type RelationshipView struct {
+	ID          string   `json:"id"`
+	Description string   `json:"description,omitempty"`
+	Order       string   `json:"order,omitempty"`
+	Vertices    []Vertex `json:"vertices,omitempty"`
+	Position    int      `json:"position,omitempty"`
+}
+
+type Vertex struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type SystemLandscapeView struct {
+	View
+	EnterpriseBoundaryVisible *bool `json:"enterpriseBoundaryVisible,omitempty"`
+}
+
+type SystemContextView struct {
+	View
+	SoftwareSystemID          string `json:"softwareSystemId"`
+	EnterpriseBoundaryVisible *bool  `json:"enterpriseBoundaryVisible,omitempty"`
+}
+
+type ContainerView struct {
+	View
+	SoftwareSystemID                        string `json:"softwareSystemId"`
+	ExternalSoftwareSystemBoundariesVisible *bool  `json:"externalSoftwareSystemBoundariesVisible,omitempty"`
+}
+
+type ComponentView struct {
+	View
+	ContainerID                        string `json:"containerId"`
+	ExternalContainerBoundariesVisible *bool  `json:"externalContainerBoundariesVisible,omitempty"`
+}
+
+type DynamicView struct {
+	View
+	ElementID string `json:"elementId,omitempty"`
+}
+
+type DeploymentView struct {
+	View
+	SoftwareSystemID string `json:"softwareSystemId,omitempty"`
+	Environment      string `json:"environment"`
+}
+
+type FilteredView struct {
+	Key         string   `json:"key"`
+	Description string   `json:"description,omitempty"`
+	Title       string   `json:"title,omitempty"`
+	BaseViewKey string   `json:"baseViewKey"`
+	Mode        string   `json:"mode"`
+	Tags        []string `json:"tags"`
+}
+
+type Configuration struct {
+	Styles Styles `json:"styles"`
+}
+
+type Styles struct {
+	Elements      []ElementStyle      `json:"elements,omitempty"`
+	Relationships []RelationshipStyle `json:"relationships,omitempty"`
+}
+
+type ElementStyle struct {
+	Tag         string `json:"tag"`
+	Width       *int   `json:"width,omitempty"`
+	Height      *int   `json:"height,omitempty"`
+	Background  string `json:"background,omitempty"`
+	Stroke      string `json:"stroke,omitempty"`
+	StrokeWidth *int   `json:"strokeWidth,omitempty"`
+	Color       string `json:"color,omitempty"`
+	FontSize    *int   `json:"fontSize,omitempty"`
+	Shape       string `json:"shape,omitempty"`
+	Icon        string `json:"icon,omitempty"`
+	Border      string `json:"border,omitempty"`
+	Opacity     *int   `json:"opacity,omitempty"`
+	Metadata    *bool  `json:"metadata,omitempty"`
+	Description *bool  `json:"description,omitempty"`
+}
+
+type RelationshipStyle struct {
+	Tag       string `json:"tag"`
+	Thickness *int   `json:"thickness,omitempty"`
+	Color     string `json:"color,omitempty"`
+	Dashed    *bool  `json:"dashed,omitempty"`
+	Style     string `json:"style,omitempty"`
+	Routing   string `json:"routing,omitempty"`
+	FontSize  *int   `json:"fontSize,omitempty"`
+	Width     *int   `json:"width,omitempty"`
+	Position  *int   `json:"position,omitempty"`
+	Opacity   *int   `json:"opacity,omitempty"`
+}
@@ -5,7 +5,9 @@ type SoftwareSystemNode struct {
 	name       string
 	desc       *string
 	containers []*ContainerNode
+	groups     []*GroupNode[*ContainerNode]
 	tags       *TagsNode
+	location   Location
 }
 
 func SoftwareSystem(name, desc string) *SoftwareSystemNode {
@@ -20,10 +22,22 @@ func (s *SoftwareSystemNode) Name() string {
 	return s.name
 }
 
+func (s *SoftwareSystemNode) landscapeElement() {}
+
+func (s *SoftwareSystemNode) WithDesc(desc string) *SoftwareSystemNode {
+	s.desc = &desc
+	return s
+}
+
 func (s *SoftwareSystemNode) Description() *string {
 	return s.desc
 }
 
+// Model returns the model the software system belongs to
+func (s *SoftwareSystemNode) Model() *ModelNode {
+	return s.model
+}
+
 func (s *SoftwareSystemNode) Uses(to Namer, desc string) *RelationShipNode {
 	return s.model.addRelationShip(s, to, desc)
 }
@@ -42,11 +56,41 @@ func (s *SoftwareSystemNode) Containers() []*ContainerNode {
 	return s.containers
 }
 
+// AddGroup adds a group of containers to the software system
+func (s *SoftwareSystemNode) AddGroup(name string) *GroupNode[*ContainerNode] {
+	g := Group[*ContainerNode](name)
+	g.top = &s.groups
+	s.groups = append(s.groups, g)
+	return g
+}
+
+// Groups returns the top level groups of containers
+func (s *SoftwareSystemNode) Groups() []*GroupNode[*ContainerNode] {
+	return s.groups
+}
+
+// GroupOf returns the innermost group holding a container, nil if it is in none
+func (s *SoftwareSystemNode) GroupOf(c *ContainerNode) *GroupNode[*ContainerNode] {
+	return groupOf(s.groups, c)
+}
+
 func (s *SoftwareSystemNode) WithTag(t string) *SoftwareSystemNode {
 	s.tags.Add(t)
 	return s
 }
 
+// WithLocation sets whether the software system is inside or outside of the enterprise, tagging it Internal or External
+func (s *SoftwareSystemNode) WithLocation(location Location) *SoftwareSystemNode {
+	relocate(s.tags, s.location, location)
+	s.location = location
+	return s
+}
+
+// Location returns the location of the software system, empty when unspecified
+func (s *SoftwareSystemNode) Location() Location {
+	return s.location
+}
+
 func (s *SoftwareSystemNode) Tags() *TagsNode {
 	return s.tags
 }
@@ -5,8 +5,8 @@ import (
 )
 
 type StylesNode struct {
-	elements               []*ElementStyleNode
-	advancedRelationships  []*AdvancedRelationshipStyleNode
+	elements      []*ElementStyleNode
+	relationships []*RelationShipStyleNode
 }
 
 func styles() *StylesNode {
@@ -23,18 +23,28 @@ func (s *StylesNode) ElementsStyle() []*ElementStyleNode {
 	return s.elements
 }
 
-func (s *StylesNode) AddRelationshipStyle(ship tags.Tag) *RelationShipStyleNode {
-	return &RelationShipStyleNode{}
+// AddRelationshipStyle adds a style for the relationships having a tag
+func (s *StylesNode) AddRelationshipStyle(tag tags.Tag) *RelationShipStyleNode {
+	r := RelationshipStyle(tag)
+	s.relationships = append(s.relationships, r)
+	return r
 }
 
-// AddAdvancedRelationshipStyle adds an advanced relationship style for a tag
-func (s *StylesNode) AddAdvancedRelationshipStyle(tag tags.Tag) *AdvancedRelationshipStyleNode {
-	r := AdvancedRelationshipStyle(tag)
-	s.advancedRelationships = append(s.advancedRelationships, r)
-	return r
+// RelationshipStyles returns the relationship styles, in the order they were added
+func (s *StylesNode) RelationshipStyles() []*RelationShipStyleNode {
+	return s.relationships
+}
+
+// AddAdvancedRelationshipStyle adds a style for the relationships having a tag.
+//
+// Deprecated: use AddRelationshipStyle.
+func (s *StylesNode) AddAdvancedRelationshipStyle(tag tags.Tag) *RelationShipStyleNode {
+	return s.AddRelationshipStyle(tag)
 }
 
-// AdvancedRelationships returns all advanced relationship styles
-func (s *StylesNode) AdvancedRelationships() []*AdvancedRelationshipStyleNode {
-	return s.advancedRelationships
+// AdvancedRelationships returns the relationship styles.
+//
+// Deprecated: use RelationshipStyles.
+func (s *StylesNode) AdvancedRelationships() []*RelationShipStyleNode {
+	return s.RelationshipStyles()
 }
@@ -6,6 +6,11 @@ type SystemContextViewNode struct {
 	addAllElements   bool
 	addAllPeople     bool
 	autoLayout       bool
+	includes         []*ExpressionViewNode
+	layout           *LayoutNode
+	withoutImplied   bool
+	excludedRels     []*RelationShipNode
+	hideEnterprise   bool
 }
 
 func systemContextView(softwareSystem *SoftwareSystemNode) *SystemContextViewNode {
@@ -47,6 +52,29 @@ func (s *SystemContextViewNode) WithAutoLayout() *SystemContextViewNode {
 	return s
 }
 
+// WithoutImpliedRelationships hides the implied relationships from the view, which only displays
+// the relationships added explicitly. The DSL renders it as an exclude statement per implied relationship.
+func (s *SystemContextViewNode) WithoutImpliedRelationships() *SystemContextViewNode {
+	s.withoutImplied = true
+	return s
+}
+
+// IsWithoutImpliedRelationships reports whether the implied relationships are hidden from the view
+func (s *SystemContextViewNode) IsWithoutImpliedRelationships() bool {
+	return s.withoutImplied
+}
+
+// ExcludeRelationShip hides a relationship from the view, its elements staying displayed
+func (s *SystemContextViewNode) ExcludeRelationShip(r *RelationShipNode) *SystemContextViewNode {
+	s.excludedRels = append(s.excludedRels, r)
+	return s
+}
+
+// ExcludedRelationShips returns the relationships hidden from the view
+func (s *SystemContextViewNode) ExcludedRelationShips() []*RelationShipNode {
+	return s.excludedRels
+}
+
 func (s *SystemContextViewNode) AutoLayout() bool {
 	return s.autoLayout
 }
@@ -63,3 +91,32 @@ func (s *SystemContextViewNode) AddAllPeople() *SystemContextViewNode {
 func (s *SystemContextViewNode) IsAllPeople() bool {
 	return s.addAllPeople
 }
+
+func (s *SystemContextViewNode) WithInclude(e *ExpressionViewNode) *SystemContextViewNode {
+	s.includes = append(s.includes, e)
+	return s
+}
+
+func (s *SystemContextViewNode) Includes() []*ExpressionViewNode {
+	return s.includes
+}
+
+// Layout returns the manual layout of the view
+func (s *SystemContextViewNode) Layout() *LayoutNode {
+	if s.layout == nil {
+		s.layout = layout()
+	}
+	return s.layout
+}
+
+// WithoutEnterpriseBoundary stops drawing the enterprise boundary around the people and software systems
+// located inside the enterprise, which diagrams draw by default.
+func (s *SystemContextViewNode) WithoutEnterpriseBoundary() *SystemContextViewNode {
+	s.hideEnterprise = true
+	return s
+}
+
+// IsEnterpriseBoundaryVisible reports whether the enterprise boundary is drawn
+func (s *SystemContextViewNode) IsEnterpriseBoundaryVisible() bool {
+	return !s.hideEnterprise
+}
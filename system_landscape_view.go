@@ -0,0 +1,135 @@
+package gostructurizr
+
+// SystemLandscapeViewNode shows the people and software systems of the model, regardless of a software system in scope
+type SystemLandscapeViewNode struct {
+	model            *ModelNode
+	key, description *string
+	addAllElements   bool
+	enterpriseOnly   bool
+	autoLayout       bool
+	includes         []*ExpressionViewNode
+	excludes         []*ExpressionViewNode
+	layout           *LayoutNode
+	withoutImplied   bool
+	excludedRels     []*RelationShipNode
+	hideEnterprise   bool
+}
+
+func systemLandscapeView(model *ModelNode) *SystemLandscapeViewNode {
+	return &SystemLandscapeViewNode{
+		model: model,
+	}
+}
+
+func (s *SystemLandscapeViewNode) WithKey(key string) *SystemLandscapeViewNode {
+	s.key = &key
+	return s
+}
+
+func (s *SystemLandscapeViewNode) Key() *string {
+	return s.key
+}
+
+func (s *SystemLandscapeViewNode) WithDescription(desc string) *SystemLandscapeViewNode {
+	s.description = &desc
+	return s
+}
+
+func (s *SystemLandscapeViewNode) Description() *string {
+	return s.description
+}
+
+// Model returns the model the view shows
+func (s *SystemLandscapeViewNode) Model() *ModelNode {
+	return s.model
+}
+
+func (s *SystemLandscapeViewNode) AddAllElements() *SystemLandscapeViewNode {
+	s.addAllElements = true
+	return s
+}
+
+func (s *SystemLandscapeViewNode) IsAllElements() bool {
+	return s.addAllElements
+}
+
+// WithEnterpriseOnly limits the elements added by AddAllElements to the ones located inside the enterprise
+func (s *SystemLandscapeViewNode) WithEnterpriseOnly() *SystemLandscapeViewNode {
+	s.enterpriseOnly = true
+	return s
+}
+
+func (s *SystemLandscapeViewNode) IsEnterpriseOnly() bool {
+	return s.enterpriseOnly
+}
+
+func (s *SystemLandscapeViewNode) WithAutoLayout() *SystemLandscapeViewNode {
+	s.autoLayout = true
+	return s
+}
+
+// WithoutImpliedRelationships hides the implied relationships from the view, which only displays
+// the relationships added explicitly. The DSL renders it as an exclude statement per implied relationship.
+func (s *SystemLandscapeViewNode) WithoutImpliedRelationships() *SystemLandscapeViewNode {
+	s.withoutImplied = true
+	return s
+}
+
+// IsWithoutImpliedRelationships reports whether the implied relationships are hidden from the view
+func (s *SystemLandscapeViewNode) IsWithoutImpliedRelationships() bool {
+	return s.withoutImplied
+}
+
+// ExcludeRelationShip hides a relationship from the view, its elements staying displayed
+func (s *SystemLandscapeViewNode) ExcludeRelationShip(r *RelationShipNode) *SystemLandscapeViewNode {
+	s.excludedRels = append(s.excludedRels, r)
+	return s
+}
+
+// ExcludedRelationShips returns the relationships hidden from the view
+func (s *SystemLandscapeViewNode) ExcludedRelationShips() []*RelationShipNode {
+	return s.excludedRels
+}
+
+func (s *SystemLandscapeViewNode) AutoLayout() bool {
+	return s.autoLayout
+}
+
+func (s *SystemLandscapeViewNode) WithInclude(e *ExpressionViewNode) *SystemLandscapeViewNode {
+	s.includes = append(s.includes, e)
+	return s
+}
+
+func (s *SystemLandscapeViewNode) Includes() []*ExpressionViewNode {
+	return s.includes
+}
+
+// WithExclude removes the elements matched by the expression from the view
+func (s *SystemLandscapeViewNode) WithExclude(e *ExpressionViewNode) *SystemLandscapeViewNode {
+	s.excludes = append(s.excludes, e)
+	return s
+}
+
+func (s *SystemLandscapeViewNode) Excludes() []*ExpressionViewNode {
+	return s.excludes
+}
+
+// Layout returns the manual layout of the view
+func (s *SystemLandscapeViewNode) Layout() *LayoutNode {
+	if s.layout == nil {
+		s.layout = layout()
+	}
+	return s.layout
+}
+
+// WithoutEnterpriseBoundary stops drawing the enterprise boundary around the people and software systems
+// located inside the enterprise, which diagrams draw by default.
+func (s *SystemLandscapeViewNode) WithoutEnterpriseBoundary() *SystemLandscapeViewNode {
+	s.hideEnterprise = true
+	return s
+}
+
+// IsEnterpriseBoundaryVisible reports whether the enterprise boundary is drawn
+func (s *SystemLandscapeViewNode) IsEnterpriseBoundaryVisible() bool {
+	return !s.hideEnterprise
+}
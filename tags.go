@@ -17,12 +17,36 @@ func NewTags() TagsNode {
 	}
 }
 
-// Add adds a tag to the node
+// Add adds a tag to the node, unless it already holds it
 func (t *TagsNode) Add(s string) *TagsNode {
-	t.Tags = append(t.Tags, s)
+	if !t.Contains(s) {
+		t.Tags = append(t.Tags, s)
+	}
+	return t
+}
+
+// Remove removes a tag from the node
+func (t *TagsNode) Remove(s string) *TagsNode {
+	kept := t.Tags[:0]
+	for _, tag := range t.Tags {
+		if tag != s {
+			kept = append(kept, tag)
+		}
+	}
+	t.Tags = kept
 	return t
 }
 
+// Contains reports whether the node holds a tag
+func (t *TagsNode) Contains(s string) bool {
+	for _, tag := range t.Tags {
+		if tag == s {
+			return true
+		}
+	}
+	return false
+}
+
 // String returns a string representation of all tags
 func (t *TagsNode) String() string {
 	return strings.Join(t.Tags, dsl.TagSeparator)
@@ -0,0 +1,436 @@
+package gostructurizr
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/platelk/gostructurizr/tags"
+)
+
+// Severity tells whether a diagnostic makes the workspace invalid or is only worth a look
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Rule identifies the check a diagnostic comes from
+type Rule string
+
+const (
+	// RuleDanglingReference reports a reference to an element, a relationship or a view that isn't part of the workspace
+	RuleDanglingReference Rule = "dangling-reference"
+	// RuleDuplicateName reports elements sharing the same name within the same scope
+	RuleDuplicateName Rule = "duplicate-name"
+	// RuleMissingViewKey reports views without a key, which get a generated one when rendered
+	RuleMissingViewKey Rule = "missing-view-key"
+	// RuleDuplicateViewKey reports views sharing the same key
+	RuleDuplicateViewKey Rule = "duplicate-view-key"
+	// RuleDuplicateIdentifier reports elements pinned to the same identifier
+	RuleDuplicateIdentifier Rule = "duplicate-identifier"
+	// RuleGroupMember reports group members which don't belong to the element owning the group
+	RuleGroupMember Rule = "group-member"
+	// RuleUnknownStyleTag reports styles targeting a tag no element or relationship carries
+	RuleUnknownStyleTag Rule = "unknown-style-tag"
+)
+
+// Diagnostic is a problem found while validating a workspace.
+// Path locates the offending node, the names of its enclosing nodes being separated by slashes (model/Shop/Web).
+type Diagnostic struct {
+	Severity Severity
+	Path     string
+	Rule     Rule
+	Message  string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s: %s [%s]", d.Severity, d.Path, d.Message, d.Rule)
+}
+
+// Diagnostics is the list of problems found while validating a workspace
+type Diagnostics []Diagnostic
+
+// Errors returns the diagnostics making the workspace invalid
+func (d Diagnostics) Errors() Diagnostics {
+	var errors Diagnostics
+	for _, diagnostic := range d {
+		if diagnostic.Severity == SeverityError {
+			errors = append(errors, diagnostic)
+		}
+	}
+	return errors
+}
+
+// HasErrors reports whether the workspace is invalid
+func (d Diagnostics) HasErrors() bool {
+	return len(d.Errors()) > 0
+}
+
+// ValidationError is returned when a workspace is refused because it is invalid
+type ValidationError struct {
+	Diagnostics Diagnostics
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Diagnostics))
+	for i, d := range e.Diagnostics {
+		messages[i] = d.String()
+	}
+	return fmt.Sprintf("invalid workspace: %s", strings.Join(messages, "; "))
+}
+
+// Validate checks the workspace for dangling references, duplicate names within a scope,
+// views without keys and styles targeting unknown tags.
+// The workspace is invalid when one of the returned diagnostics is an error.
+func (w *WorkspaceNode) Validate() Diagnostics {
+	v := &validator{
+		paths:            map[Namer]string{},
+		elementTags:      map[string]bool{},
+		relationshipTags: map[string]bool{},
+	}
+	v.indexModel(w.Model())
+	v.validateModel(w.Model())
+	v.validateIdentifiers(w.Identifiers().Assign(w.Model()))
+	v.validateViews(w.Views())
+	return v.diagnostics
+}
+
+type validator struct {
+	// paths holds the path of every element of the model, which is how references are checked
+	paths            map[Namer]string
+	elements         []Namer
+	elementTags      map[string]bool
+	relationshipTags map[string]bool
+	diagnostics      Diagnostics
+}
+
+func (v *validator) report(severity Severity, rule Rule, path, format string, args ...interface{}) {
+	v.diagnostics = append(v.diagnostics, Diagnostic{
+		Severity: severity,
+		Path:     path,
+		Rule:     rule,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func joinPath(parent, name string) string {
+	return parent + "/" + name
+}
+
+// indexElement records the path and the tags of an element, defaults being the tags Structurizr gives to its type
+func (v *validator) indexElement(n Namer, path string, elementTags *TagsNode, defaults ...tags.Tag) {
+	v.paths[n] = path
+	v.elements = append(v.elements, n)
+	for _, t := range defaults {
+		v.elementTags[strings.ToLower(t.String())] = true
+	}
+	if elementTags != nil {
+		for _, t := range elementTags.List() {
+			v.elementTags[strings.ToLower(strings.TrimSpace(t))] = true
+		}
+	}
+}
+
+func (v *validator) indexModel(m *ModelNode) {
+	for _, p := range m.Persons() {
+		v.indexElement(p, joinPath("model", p.Name()), p.Tags(), tags.Element, tags.Person)
+	}
+	for _, s := range m.SoftwareSystems() {
+		systemPath := joinPath("model", s.Name())
+		v.indexElement(s, systemPath, s.Tags(), tags.Element, tags.SoftwareSystem)
+		for _, c := range s.Containers() {
+			containerPath := joinPath(systemPath, c.Name())
+			v.indexElement(c, containerPath, c.Tags(), tags.Element, tags.Container)
+			for _, component := range c.Components() {
+				v.indexElement(component, joinPath(containerPath, component.Name()), component.Tags(), tags.Element, tags.Component)
+			}
+		}
+	}
+	for _, d := range m.DeploymentNodes() {
+		v.indexDeploymentNode(d, joinPath(joinPath("model", string(d.Environment())), d.Name()))
+	}
+	v.relationshipTags[strings.ToLower(tags.RelationShip.String())] = true
+	for _, r := range m.RelationShip() {
+		if r.InteractionStyle() != nil {
+			v.relationshipTags[strings.ToLower(string(*r.InteractionStyle()))] = true
+		}
+		for _, tag := range r.Tags().List() {
+			v.relationshipTags[strings.ToLower(tag)] = true
+		}
+	}
+}
+
+func (v *validator) indexDeploymentNode(d *DeploymentNodeNode, path string) {
+	v.indexElement(d, path, d.Tags(), tags.Element)
+	for _, child := range d.Children() {
+		v.indexDeploymentNode(child, joinPath(path, child.Name()))
+	}
+	for _, infra := range d.InfrastructureNodes() {
+		v.indexElement(infra, joinPath(path, infra.Name()), infra.Tags(), tags.Element)
+	}
+	for _, instance := range d.ContainerInstances() {
+		v.indexElement(instance, joinPath(path, instance.Name()), instance.Tags(), tags.Element)
+	}
+}
+
+// checkReference reports an error when n, referenced by the node at path, isn't an element of the model
+func (v *validator) checkReference(path, what string, n Namer) {
+	if n == nil || isNilNamer(n) {
+		v.report(SeverityError, RuleDanglingReference, path, "%s is missing", what)
+		return
+	}
+	if _, ok := v.paths[n]; !ok {
+		v.report(SeverityError, RuleDanglingReference, path, "%s %q is not part of the model", what, n.Name())
+	}
+}
+
+// isNilNamer reports whether n holds a nil pointer to one of the elements of the model
+func isNilNamer(n Namer) bool {
+	switch e := n.(type) {
+	case *PersonNode:
+		return e == nil
+	case *SoftwareSystemNode:
+		return e == nil
+	case *ContainerNode:
+		return e == nil
+	case *ComponentNode:
+		return e == nil
+	case *DeploymentNodeNode:
+		return e == nil
+	case *InfrastructureNodeNode:
+		return e == nil
+	case *ContainerInstanceNode:
+		return e == nil
+	}
+	return false
+}
+
+// checkDuplicateNames reports the elements whose name is already used within the scope at path
+func (v *validator) checkDuplicateNames(path string, elements []Namer) {
+	seen := map[string]bool{}
+	for _, e := range elements {
+		if seen[e.Name()] {
+			v.report(SeverityError, RuleDuplicateName, joinPath(path, e.Name()), "name %q is used more than once in %s", e.Name(), path)
+		}
+		seen[e.Name()] = true
+	}
+}
+
+func (v *validator) validateModel(m *ModelNode) {
+	var topLevel []Namer
+	for _, p := range m.Persons() {
+		topLevel = append(topLevel, p)
+	}
+	for _, s := range m.SoftwareSystems() {
+		topLevel = append(topLevel, s)
+	}
+	v.checkDuplicateNames("model", topLevel)
+	checkGroupMembers(v, "model", m.Groups(), func(e LandscapeElement) bool {
+		_, ok := v.paths[e]
+		return ok
+	})
+	for _, s := range m.SoftwareSystems() {
+		var containers []Namer
+		for _, c := range s.Containers() {
+			containers = append(containers, c)
+			var components []Namer
+			for _, component := range c.Components() {
+				components = append(components, component)
+			}
+			v.checkDuplicateNames(v.paths[c], components)
+			checkGroupMembers(v, v.paths[c], c.Groups(), func(component *ComponentNode) bool { return component.Parent() == c })
+		}
+		v.checkDuplicateNames(v.paths[s], containers)
+		checkGroupMembers(v, v.paths[s], s.Groups(), func(c *ContainerNode) bool { return c.Parent() == s })
+	}
+	environments := map[DeploymentEnvironment][]Namer{}
+	var order []DeploymentEnvironment
+	for _, d := range m.DeploymentNodes() {
+		if _, ok := environments[d.Environment()]; !ok {
+			order = append(order, d.Environment())
+		}
+		environments[d.Environment()] = append(environments[d.Environment()], d)
+		v.validateDeploymentNode(d)
+	}
+	for _, env := range order {
+		v.checkDuplicateNames(joinPath("model", string(env)), environments[env])
+	}
+	for i, r := range m.RelationShip() {
+		path := fmt.Sprintf("model/relationships[%d]", i)
+		v.checkReference(path, "source", r.From())
+		v.checkReference(path, "destination", r.To())
+	}
+}
+
+// checkGroupMembers reports the members of groups, nested ones included, which the element at path doesn't own
+func checkGroupMembers[K Namer](v *validator, path string, groups []*GroupNode[K], owns func(K) bool) {
+	for _, g := range groups {
+		for _, member := range g.Members() {
+			if !owns(member) {
+				v.report(SeverityError, RuleGroupMember, joinPath(path, g.FullName()), "%q doesn't belong to %s", member.Name(), path)
+			}
+		}
+		checkGroupMembers(v, path, g.Groups(), owns)
+	}
+}
+
+func (v *validator) validateDeploymentNode(d *DeploymentNodeNode) {
+	path := v.paths[d]
+	var scope []Namer
+	for _, child := range d.Children() {
+		scope = append(scope, child)
+		v.validateDeploymentNode(child)
+	}
+	for _, infra := range d.InfrastructureNodes() {
+		scope = append(scope, infra)
+	}
+	v.checkDuplicateNames(path, scope)
+	for _, instance := range d.ContainerInstances() {
+		v.checkReference(v.paths[instance], "container", instance.Container())
+	}
+}
+
+// validateIdentifiers reports the elements sharing their identifier with another one,
+// which only happens when identifiers are pinned
+func (v *validator) validateIdentifiers(ids *ElementIdentifiers) {
+	owners := map[string]Namer{}
+	for _, n := range v.elements {
+		if _, ok := ids.full[n]; !ok {
+			continue
+		}
+		id := ids.Of(n)
+		if owner, ok := owners[id]; ok {
+			v.report(SeverityError, RuleDuplicateIdentifier, v.paths[n], "identifier %q is already used by %s", id, v.paths[owner])
+			continue
+		}
+		owners[id] = n
+	}
+}
+
+// checkViewKey reports views without a key, and views sharing the key of another one
+func (v *validator) checkViewKey(keys map[string]bool, path string, key *string) {
+	if key == nil || *key == "" {
+		v.report(SeverityWarning, RuleMissingViewKey, path, "view has no key, one will be generated")
+		return
+	}
+	if keys[*key] {
+		v.report(SeverityError, RuleDuplicateViewKey, path, "key %q is used by another view", *key)
+	}
+	keys[*key] = true
+}
+
+// checkExpressions reports the include and exclude expressions of a view referencing elements outside of the model
+func (v *validator) checkExpressions(path string, expressions []*ExpressionViewNode) {
+	for _, e := range expressions {
+		for _, n := range []Namer{e.On(), e.From(), e.To()} {
+			if n != nil && isElement(n) {
+				v.checkReference(path, "element", n)
+			}
+		}
+	}
+}
+
+// isElement reports whether n is an element of a model rather than a wildcard
+func isElement(n Namer) bool {
+	switch n.(type) {
+	case *PersonNode, *SoftwareSystemNode, *ContainerNode, *ComponentNode,
+		*DeploymentNodeNode, *InfrastructureNodeNode, *ContainerInstanceNode:
+		return true
+	}
+	return false
+}
+
+// viewPath locates a view by its key, or by its type and rank when it has none
+func viewPath(kind string, n int, key *string) string {
+	if key != nil && *key != "" {
+		return joinPath("views", *key)
+	}
+	return joinPath("views", fmt.Sprintf("%s[%d]", kind, n))
+}
+
+func (v *validator) validateViews(views *ViewsNode) {
+	keys := map[string]bool{}
+	viewables := map[Viewable]bool{}
+	for n, s := range views.SystemLandscapeViews() {
+		path := viewPath("systemLandscape", n, s.Key())
+		viewables[s] = true
+		v.checkViewKey(keys, path, s.Key())
+		v.checkExpressions(path, s.Includes())
+		v.checkExpressions(path, s.Excludes())
+	}
+	for n, s := range views.SystemContextViews() {
+		path := viewPath("systemContext", n, s.Key())
+		viewables[s] = true
+		v.checkViewKey(keys, path, s.Key())
+		v.checkReference(path, "software system", s.SoftwareSystem())
+		v.checkExpressions(path, s.Includes())
+	}
+	for n, c := range views.ContainerViews() {
+		path := viewPath("container", n, c.Key())
+		viewables[c] = true
+		v.checkViewKey(keys, path, c.Key())
+		v.checkReference(path, "software system", c.SoftwareSystem())
+		v.checkExpressions(path, c.Includes())
+		v.checkExpressions(path, c.Excludes())
+	}
+	for n, c := range views.ComponentViews() {
+		path := viewPath("component", n, c.Key())
+		viewables[c] = true
+		v.checkViewKey(keys, path, c.Key())
+		v.checkReference(path, "container", c.Container())
+		v.checkExpressions(path, c.Includes())
+		v.checkExpressions(path, c.Excludes())
+	}
+	for n, d := range views.DynamicViews() {
+		path := viewPath("dynamic", n, d.Key())
+		viewables[d] = true
+		v.checkViewKey(keys, path, d.Key())
+		if d.Identifier() != nil && isElement(d.Identifier()) {
+			v.checkReference(path, "scope", d.Identifier())
+		}
+		for _, step := range d.Steps() {
+			v.checkReference(path, "step source", step.RelationShip.From())
+			v.checkReference(path, "step destination", step.RelationShip.To())
+		}
+	}
+	for n, d := range views.DeploymentViews() {
+		key := d.GetKey()
+		path := viewPath("deployment", n, &key)
+		v.checkViewKey(keys, path, &key)
+		if d.SoftwareSystem() != nil {
+			v.checkReference(path, "software system", d.SoftwareSystem())
+		}
+		for _, e := range d.Elements() {
+			v.checkReference(path, "element", e)
+		}
+	}
+	for n, f := range views.FilteredViews() {
+		key := f.Key()
+		path := viewPath("filtered", n, &key)
+		v.checkViewKey(keys, path, &key)
+		base := f.BaseView()
+		switch {
+		case base == nil:
+			v.report(SeverityError, RuleDanglingReference, path, "base view is missing")
+		case base.Key() == nil || *base.Key() == "":
+			v.report(SeverityError, RuleDanglingReference, path, "base view has no key to be referenced by")
+		case !viewables[base]:
+			v.report(SeverityError, RuleDanglingReference, path, "base view %q is not part of the workspace", *base.Key())
+		}
+	}
+	v.validateStyles(views.Configuration().Styles())
+}
+
+func (v *validator) validateStyles(s *StylesNode) {
+	for _, e := range s.ElementsStyle() {
+		if !v.elementTags[strings.ToLower(e.Tag().String())] {
+			v.report(SeverityWarning, RuleUnknownStyleTag, joinPath("views/styles/element", e.Tag().String()), "no element is tagged %q", e.Tag())
+		}
+	}
+	for _, r := range s.RelationshipStyles() {
+		if !v.relationshipTags[strings.ToLower(r.Tag().String())] {
+			v.report(SeverityWarning, RuleUnknownStyleTag, joinPath("views/styles/relationship", r.Tag().String()), "no relationship is tagged %q", r.Tag())
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package gostructurizr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platelk/gostructurizr/tags"
+)
+
+func TestWorkspace_Validate(t *testing.T) {
+	w := Workspace()
+	m := w.Model()
+	customer := m.AddPerson("Customer", "")
+	shop := m.AddSoftwareSystem("Shop", "")
+	web := shop.AddContainer("Web", "", "")
+	shop.AddContainer("Web", "", "")
+	customer.Uses(web, "Visits")
+
+	other := Model().AddSoftwareSystem("Payment", "")
+	web.Uses(other, "Charges")
+
+	server := m.AddProdNode("Server", "", "")
+	server.AddContainerInstance(web)
+	server.AddContainerInstance(Container("Orphan"))
+
+	views := w.Views()
+	context := views.CreateSystemContextView(shop).WithKey("Context")
+	views.CreateContainerView(shop).WithKey("Context")
+	views.CreateDynamicView(shop)
+	views.CreateFilteredView(views.CreateComponentView(web), "Filtered").WithKey("Filtered")
+	views.CreateFilteredView(context, "Context filtered").WithKey("ContextFiltered")
+
+	styles := views.Configuration().Styles()
+	styles.AddElementStyle(tags.Person)
+	styles.AddElementStyle("Legacy")
+	styles.AddAdvancedRelationshipStyle(tags.Asynchronous)
+
+	assert.Equal(t, Diagnostics{
+		{SeverityError, "model/Shop/Web", RuleDuplicateName, `name "Web" is used more than once in model/Shop`},
+		{SeverityError, "model/Production/Server/Orphan", RuleDanglingReference, `container "Orphan" is not part of the model`},
+		{SeverityError, "model/relationships[1]", RuleDanglingReference, `destination "Payment" is not part of the model`},
+		{SeverityError, "views/Context", RuleDuplicateViewKey, `key "Context" is used by another view`},
+		{SeverityWarning, "views/component[0]", RuleMissingViewKey, "view has no key, one will be generated"},
+		{SeverityWarning, "views/dynamic[0]", RuleMissingViewKey, "view has no key, one will be generated"},
+		{SeverityError, "views/Filtered", RuleDanglingReference, "base view has no key to be referenced by"},
+		{SeverityWarning, "views/styles/element/Legacy", RuleUnknownStyleTag, `no element is tagged "Legacy"`},
+		{SeverityWarning, "views/styles/relationship/asynchronous", RuleUnknownStyleTag, `no relationship is tagged "asynchronous"`},
+	}, w.Validate())
+	assert.Len(t, w.Validate().Errors(), 5)
+}
+
+func TestWorkspace_Validate_valid(t *testing.T) {
+	w := Workspace()
+	customer := w.Model().AddPerson("Customer", "")
+	shop := w.Model().AddSoftwareSystem("Shop", "")
+	customer.Uses(shop, "Buys from")
+	w.Views().CreateSystemContextView(shop).WithKey("Context").AddAllElements()
+
+	diagnostics := w.Validate()
+	require.Empty(t, diagnostics)
+	assert.False(t, diagnostics.HasErrors())
+}
+
+func TestWorkspace_Validate_duplicateIdentifier(t *testing.T) {
+	w := Workspace()
+	customer := w.Model().AddPerson("Customer", "")
+	shop := w.Model().AddSoftwareSystem("Shop", "")
+	w.Identifiers().Pin(customer, "c").Pin(shop, "c")
+
+	assert.Equal(t, Diagnostics{
+		{SeverityError, "model/Shop", RuleDuplicateIdentifier, `identifier "c" is already used by model/Customer`},
+	}, w.Validate())
+}
+
+func TestWorkspace_Validate_groupMember(t *testing.T) {
+	w := Workspace()
+	m := w.Model()
+	shop := m.AddSoftwareSystem("Shop", "")
+	web := shop.AddContainer("Web", "", "")
+	backOffice := m.AddSoftwareSystem("Back Office", "")
+	admin := backOffice.AddContainer("Admin", "", "")
+	orders := admin.AddComponent("Orders")
+	m.AddGroup("Retail").Add(shop, Person("Outsider", ""))
+	shop.AddGroup("Frontend").Add(web).AddGroup("Admin").Add(admin)
+	web.AddGroup("Domain").Add(orders)
+
+	assert.Equal(t, Diagnostics{
+		{SeverityError, "model/Retail", RuleGroupMember, `"Outsider" doesn't belong to model`},
+		{SeverityError, "model/Shop/Web/Domain", RuleGroupMember, `"Orders" doesn't belong to model/Shop/Web`},
+		{SeverityError, "model/Shop/Frontend/Admin", RuleGroupMember, `"Admin" doesn't belong to model/Shop`},
+	}, w.Validate())
+}
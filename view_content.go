@@ -0,0 +1,337 @@
+package gostructurizr
+
+// ViewContent lists the elements and relationships displayed by a view once its
+// include rules have been resolved against the model.
+type ViewContent struct {
+	elements      []Namer
+	relationships []*RelationShipNode
+	// withoutImplied ignores the implied relationships of the model
+	withoutImplied bool
+	// excluded are the relationships hidden from the view
+	excluded []*RelationShipNode
+	hidden   []*RelationShipNode
+}
+
+// Elements returns the elements displayed by the view, in the order they were included
+func (c *ViewContent) Elements() []Namer {
+	return c.elements
+}
+
+// RelationShips returns the relationships displayed by the view
+func (c *ViewContent) RelationShips() []*RelationShipNode {
+	return c.relationships
+}
+
+// HiddenRelationShips returns the relationships between displayed elements that the view hides,
+// the implied ones when it hides them and the ones it excludes
+func (c *ViewContent) HiddenRelationShips() []*RelationShipNode {
+	return c.hidden
+}
+
+// Contains reports whether an element is displayed by the view
+func (c *ViewContent) Contains(n Namer) bool {
+	for _, e := range c.elements {
+		if e == n {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *ViewContent) add(n Namer) {
+	if n == nil || c.Contains(n) {
+		return
+	}
+	c.elements = append(c.elements, n)
+}
+
+func (c *ViewContent) addRelationShip(r *RelationShipNode) {
+	for _, existing := range c.relationships {
+		if existing == r {
+			return
+		}
+	}
+	c.relationships = append(c.relationships, r)
+}
+
+// relationShipsOf returns the relationships of the model the view takes into account
+func (c *ViewContent) relationShipsOf(m *ModelNode) []*RelationShipNode {
+	if !c.withoutImplied {
+		return m.RelationShip()
+	}
+	var relationships []*RelationShipNode
+	for _, r := range m.RelationShip() {
+		if !r.IsImplied() {
+			relationships = append(relationships, r)
+		}
+	}
+	return relationships
+}
+
+// addNeighbours adds the elements directly connected to n that are accepted by the filter
+func (c *ViewContent) addNeighbours(m *ModelNode, n Namer, accept func(Namer) bool) {
+	if m == nil {
+		return
+	}
+	for _, r := range c.relationShipsOf(m) {
+		if r.from == n && accept(r.to) {
+			c.add(r.to)
+		}
+		if r.to == n && accept(r.from) {
+			c.add(r.from)
+		}
+	}
+}
+
+// addExpression adds the element targeted by an include expression and,
+// for afferent and efferent expressions, the elements connected to it.
+func (c *ViewContent) addExpression(m *ModelNode, e *ExpressionViewNode) {
+	c.add(e.On())
+	c.add(e.From())
+	c.add(e.To())
+	if e.On() == nil || m == nil {
+		return
+	}
+	for _, r := range c.relationShipsOf(m) {
+		if e.Afferent() && r.to == e.On() {
+			c.add(r.from)
+		}
+		if e.Efferent() && r.from == e.On() {
+			c.add(r.to)
+		}
+	}
+}
+
+// removeExpression removes the elements an exclude expression matches, which are the ones
+// an include expression would add
+func (c *ViewContent) removeExpression(m *ModelNode, e *ExpressionViewNode) {
+	matched := &ViewContent{withoutImplied: c.withoutImplied}
+	matched.addExpression(m, e)
+	var kept []Namer
+	for _, n := range c.elements {
+		if !matched.Contains(n) {
+			kept = append(kept, n)
+		}
+	}
+	c.elements = kept
+}
+
+// addModelRelationShips adds every relationship of the model connecting two displayed elements,
+// keeping aside the implied ones when the view hides them
+func (c *ViewContent) addModelRelationShips(m *ModelNode) {
+	if m == nil {
+		return
+	}
+	for _, r := range m.RelationShip() {
+		if !c.Contains(r.from) || !c.Contains(r.to) {
+			continue
+		}
+		if (c.withoutImplied && r.IsImplied()) || c.excludes(r) {
+			c.hidden = append(c.hidden, r)
+			continue
+		}
+		c.addRelationShip(r)
+	}
+}
+
+func (c *ViewContent) excludes(r *RelationShipNode) bool {
+	for _, excluded := range c.excluded {
+		if excluded == r {
+			return true
+		}
+	}
+	return false
+}
+
+func isPerson(n Namer) bool {
+	_, ok := n.(*PersonNode)
+	return ok
+}
+
+func isSoftwareSystem(n Namer) bool {
+	_, ok := n.(*SoftwareSystemNode)
+	return ok
+}
+
+// isInternal reports whether a person or a software system is located inside the enterprise
+func isInternal(n Namer) bool {
+	switch e := n.(type) {
+	case *PersonNode:
+		return e.Location() == InternalLocation
+	case *SoftwareSystemNode:
+		return e.Location() == InternalLocation
+	}
+	return false
+}
+
+func isContainer(n Namer) bool {
+	_, ok := n.(*ContainerNode)
+	return ok
+}
+
+// Content resolves the elements and relationships displayed by the view.
+// `include *` adds all the people and software systems of the model, only the ones
+// located inside the enterprise when the view is limited to it.
+func (s *SystemLandscapeViewNode) Content() *ViewContent {
+	c := &ViewContent{withoutImplied: s.withoutImplied, excluded: s.excludedRels}
+	m := s.model
+	if s.addAllElements && m != nil {
+		for _, p := range m.Persons() {
+			if !s.enterpriseOnly || isInternal(p) {
+				c.add(p)
+			}
+		}
+		for _, system := range m.SoftwareSystems() {
+			if !s.enterpriseOnly || isInternal(system) {
+				c.add(system)
+			}
+		}
+	}
+	for _, e := range s.includes {
+		c.addExpression(m, e)
+	}
+	for _, e := range s.excludes {
+		c.removeExpression(m, e)
+	}
+	c.addModelRelationShips(m)
+	return c
+}
+
+// Content resolves the elements and relationships displayed by the view.
+// `include *` adds the software system in scope and the people and software systems directly connected to it.
+func (s *SystemContextViewNode) Content() *ViewContent {
+	c := &ViewContent{withoutImplied: s.withoutImplied, excluded: s.excludedRels}
+	m := s.softwareSystem.model
+	c.add(s.softwareSystem)
+	if s.addAllElements {
+		c.addNeighbours(m, s.softwareSystem, func(n Namer) bool {
+			return isPerson(n) || isSoftwareSystem(n)
+		})
+	}
+	if s.addAllPeople && m != nil {
+		for _, p := range m.Persons() {
+			c.add(p)
+		}
+	}
+	for _, e := range s.includes {
+		c.addExpression(m, e)
+	}
+	c.addModelRelationShips(m)
+	return c
+}
+
+// Content resolves the elements and relationships displayed by the view.
+// `include *` adds the containers of the software system in scope and the people
+// and software systems directly connected to them.
+func (s *ContainersViewNode) Content() *ViewContent {
+	c := &ViewContent{withoutImplied: s.withoutImplied, excluded: s.excludedRels}
+	m := s.softwareSystem.model
+	if s.addAllElement {
+		for _, container := range s.softwareSystem.Containers() {
+			c.add(container)
+		}
+		for _, container := range s.softwareSystem.Containers() {
+			c.addNeighbours(m, container, func(n Namer) bool {
+				return isPerson(n) || (isSoftwareSystem(n) && n != s.softwareSystem)
+			})
+		}
+	}
+	if s.addAllPeople && m != nil {
+		for _, p := range m.Persons() {
+			c.add(p)
+		}
+	}
+	for _, system := range s.softwareSystems {
+		c.add(system)
+	}
+	for _, e := range s.includes {
+		c.addExpression(m, e)
+	}
+	for _, e := range s.excludes {
+		c.removeExpression(m, e)
+	}
+	c.addModelRelationShips(m)
+	return c
+}
+
+// Content resolves the elements and relationships displayed by the view.
+// `include *` adds the components of the container in scope and the people, software systems
+// and containers directly connected to them.
+func (s *ComponentsViewNode) Content() *ViewContent {
+	c := &ViewContent{withoutImplied: s.withoutImplied, excluded: s.excludedRels}
+	var m *ModelNode
+	if s.container.sys != nil {
+		m = s.container.sys.model
+	}
+	if s.addAllElement {
+		for _, component := range s.container.Components() {
+			c.add(component)
+		}
+		for _, component := range s.container.Components() {
+			c.addNeighbours(m, component, func(n Namer) bool {
+				return isPerson(n) || isSoftwareSystem(n) || (isContainer(n) && n != s.container)
+			})
+		}
+	}
+	if s.addAllPeople && m != nil {
+		for _, p := range m.Persons() {
+			c.add(p)
+		}
+	}
+	for _, e := range s.elements {
+		c.add(e)
+	}
+	for _, e := range s.includes {
+		c.addExpression(m, e)
+	}
+	for _, e := range s.excludes {
+		c.removeExpression(m, e)
+	}
+	c.addModelRelationShips(m)
+	return c
+}
+
+// Content resolves the elements and relationships displayed by the view.
+// Deployment nodes are displayed with all their children, infrastructure nodes and container instances.
+func (d *DeploymentViewNode) Content() *ViewContent {
+	c := &ViewContent{}
+	var add func(n Namer)
+	add = func(n Namer) {
+		c.add(n)
+		node, ok := n.(*DeploymentNodeNode)
+		if !ok {
+			return
+		}
+		for _, child := range node.Children() {
+			add(child)
+		}
+		for _, infra := range node.InfrastructureNodes() {
+			c.add(infra)
+		}
+		for _, instance := range node.ContainerInstances() {
+			c.add(instance)
+		}
+	}
+	for _, e := range d.Elements() {
+		add(e)
+	}
+	for _, r := range d.RelationShips() {
+		c.addRelationShip(r)
+	}
+	if d.softwareSystem != nil {
+		c.addModelRelationShips(d.softwareSystem.model)
+	}
+	return c
+}
+
+// Content resolves the elements and relationships displayed by the view, which are
+// the participants and the steps of the dynamic view in order.
+func (d *DynamicViewNode) Content() *ViewContent {
+	c := &ViewContent{}
+	for _, r := range d.relationShip {
+		c.add(r.from)
+		c.add(r.to)
+		c.addRelationShip(r)
+	}
+	return c
+}
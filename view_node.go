@@ -7,6 +7,7 @@ type ViewNode struct {
 	autoLayout    bool
 	elements      []Namer
 	relationships []*RelationShipNode
+	layout        *LayoutNode
 }
 
 // NewViewNode creates a new base ViewNode
@@ -70,4 +71,12 @@ func (v *ViewNode) AddRelationship(relationship *RelationShipNode) *ViewNode {
 // RelationShips returns all relationships in this view
 func (v *ViewNode) RelationShips() []*RelationShipNode {
 	return v.relationships
-}
\ No newline at end of file
+}
+
+// Layout returns the manual layout of the view
+func (v *ViewNode) Layout() *LayoutNode {
+	if v.layout == nil {
+		v.layout = layout()
+	}
+	return v.layout
+}
@@ -1,21 +1,31 @@
 package gostructurizr
 
 type ViewsNode struct {
-	configuration      *ViewConfiguration
-	systemContextViews []*SystemContextViewNode
-	containersView     []*ContainersViewNode
-	dynamicView        []*DynamicViewNode
-	componentViews     []*ComponentsViewNode
-	deploymentViews    []*DeploymentViewNode
-	filteredViews      []*FilteredViewNode
-}
-
-func views() *ViewsNode {
+	model                *ModelNode
+	configuration        *ViewConfiguration
+	systemLandscapeViews []*SystemLandscapeViewNode
+	systemContextViews   []*SystemContextViewNode
+	containersView       []*ContainersViewNode
+	dynamicView          []*DynamicViewNode
+	componentViews       []*ComponentsViewNode
+	deploymentViews      []*DeploymentViewNode
+	filteredViews        []*FilteredViewNode
+}
+
+func views(model *ModelNode) *ViewsNode {
 	return &ViewsNode{
+		model:         model,
 		configuration: NewViewConfiguration(),
 	}
 }
 
+// CreateSystemLandscapeView creates a view of the people and software systems of the model
+func (v *ViewsNode) CreateSystemLandscapeView() *SystemLandscapeViewNode {
+	view := systemLandscapeView(v.model)
+	v.systemLandscapeViews = append(v.systemLandscapeViews, view)
+	return view
+}
+
 func (v *ViewsNode) CreateSystemContextView(node *SoftwareSystemNode) *SystemContextViewNode {
 	view := systemContextView(node)
 	v.systemContextViews = append(v.systemContextViews, view)
@@ -36,7 +46,7 @@ func (v *ViewsNode) CreateComponentView(node *ContainerNode) *ComponentsViewNode
 }
 
 func (v *ViewsNode) CreateDynamicView(identifier Namer) *DynamicViewNode {
-	d := dynamicView()
+	d := dynamicView().WithIdentifier(identifier)
 	v.dynamicView = append(v.dynamicView, d)
 	return d
 }
@@ -68,6 +78,10 @@ func (v *ViewsNode) CreateProdView(softwareSystem *SoftwareSystemNode) *Deployme
 	return v.CreateDeploymentView(softwareSystem, ProductionEnvironment)
 }
 
+func (v *ViewsNode) SystemLandscapeViews() []*SystemLandscapeViewNode {
+	return v.systemLandscapeViews
+}
+
 func (v *ViewsNode) SystemContextViews() []*SystemContextViewNode {
 	return v.systemContextViews
 }
@@ -80,6 +94,10 @@ func (v *ViewsNode) ComponentViews() []*ComponentsViewNode {
 	return v.componentViews
 }
 
+func (v *ViewsNode) DynamicViews() []*DynamicViewNode {
+	return v.dynamicView
+}
+
 func (v *ViewsNode) DeploymentViews() []*DeploymentViewNode {
 	return v.deploymentViews
 }
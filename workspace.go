@@ -5,12 +5,15 @@ type WorkspaceNode struct {
 	extends           *string
 	model             *ModelNode
 	views             *ViewsNode
+	identifiers       *IdentifiersNode
 }
 
 func Workspace() *WorkspaceNode {
+	m := Model()
 	return &WorkspaceNode{
-		model: Model(),
-		views: views(),
+		model:       m,
+		views:       views(m),
+		identifiers: identifiers(),
 	}
 }
 
@@ -48,3 +51,8 @@ func (w *WorkspaceNode) Model() *ModelNode {
 func (w *WorkspaceNode) Views() *ViewsNode {
 	return w.views
 }
+
+// Identifiers returns the registry assigning the DSL identifiers of the elements
+func (w *WorkspaceNode) Identifiers() *IdentifiersNode {
+	return w.identifiers
+}